@@ -10,10 +10,16 @@ import (
 	"time"
 
 	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/config_loader"
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/criteria"
 	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/executor"
 	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/hyperfleet_api"
 	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/k8s_client"
 	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/maestro_client"
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/reload"
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/resync"
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/statuswatch"
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/synthetic"
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/transport_client"
 	"github.com/openshift-hyperfleet/hyperfleet-adapter/pkg/health"
 	"github.com/openshift-hyperfleet/hyperfleet-adapter/pkg/logger"
 	"github.com/openshift-hyperfleet/hyperfleet-adapter/pkg/otel"
@@ -86,7 +92,7 @@ and HyperFleet API calls.`,
 	serveCmd.Flags().StringVarP(&configPath, "config", "c", "",
 		fmt.Sprintf("Path to adapter deployment config file (can also use %s env var)", config_loader.EnvAdapterConfig))
 	serveCmd.Flags().StringVarP(&taskConfigPath, "task-config", "t", "",
-		fmt.Sprintf("Path to adapter task config file (can also use %s env var)", config_loader.EnvTaskConfigPath))
+		fmt.Sprintf("Path to adapter task config file, directory or comma-separated list (can also use %s env var)", config_loader.EnvTaskConfigPath))
 	serveFlags = serveCmd.Flags()
 
 	// Add Maestro override flags
@@ -178,17 +184,29 @@ func runServe() error {
 
 	// Load unified configuration (deployment + task configs)
 	log.Info(ctx, "Loading adapter configuration...")
-	config, err := config_loader.LoadConfig(
+	loadOpts := []config_loader.LoadOption{
 		config_loader.WithAdapterConfigPath(configPath),
 		config_loader.WithTaskConfigPath(taskConfigPath),
 		config_loader.WithAdapterVersion(version.Version),
 		config_loader.WithFlags(serveFlags),
-	)
+	}
+	configs, err := config_loader.LoadConfigs(loadOpts...)
 	if err != nil {
 		errCtx := logger.WithErrorField(ctx, err)
 		log.Errorf(errCtx, "Failed to load adapter configuration")
 		return fmt.Errorf("failed to load adapter configuration: %w", err)
 	}
+	// Deployment-level settings are identical in every merged config
+	config := configs[0]
+
+	// CEL programs are process-wide; config reloads replace them when the router swaps the configs
+	programs, err := config.CELPrograms()
+	if err != nil {
+		errCtx := logger.WithErrorField(ctx, err)
+		log.Errorf(errCtx, "Failed to configure CEL")
+		return fmt.Errorf("failed to configure CEL: %w", err)
+	}
+	criteria.UsePrograms(programs)
 
 	// Recreate logger with component name from config
 	log, err = logger.NewLogger(buildLoggerConfig(config.Metadata.Name))
@@ -196,18 +214,25 @@ func runServe() error {
 		return fmt.Errorf("failed to create logger with adapter config: %w", err)
 	}
 
-	log.Infof(ctx, "Adapter configuration loaded successfully: name=%s ",
-		config.Metadata.Name)
+	log.Infof(ctx, "Adapter configuration loaded successfully: name=%s tasks=%d",
+		config.Metadata.Name, len(configs))
 	log.Infof(ctx, "HyperFleet API client configured: timeout=%s retryAttempts=%d",
 		config.Spec.Clients.HyperfleetAPI.Timeout.String(),
 		config.Spec.Clients.HyperfleetAPI.RetryAttempts)
+	for _, taskConfig := range configs {
+		for _, warning := range taskConfig.Warnings {
+			log.Warnf(ctx, "Task config %s: %s", taskConfig.GetTaskName(), warning)
+		}
+	}
 	if config.Spec.DebugConfig {
-		configBytes, err := yaml.Marshal(config)
-		if err != nil {
-			errCtx := logger.WithErrorField(ctx, err)
-			log.Warnf(errCtx, "Failed to marshal adapter configuration for logging")
-		} else {
-			log.Infof(ctx, "Loaded adapter configuration:\n%s", string(configBytes))
+		for _, taskConfig := range configs {
+			configBytes, err := yaml.Marshal(taskConfig)
+			if err != nil {
+				errCtx := logger.WithErrorField(ctx, err)
+				log.Warnf(errCtx, "Failed to marshal adapter configuration for logging")
+			} else {
+				log.Infof(ctx, "Loaded adapter configuration for task %s:\n%s", taskConfig.GetTaskName(), string(configBytes))
+			}
 		}
 	}
 
@@ -277,23 +302,37 @@ func runServe() error {
 		return fmt.Errorf("failed to create HyperFleet API client: %w", err)
 	}
 
-	// Create transport client - only one transport is supported per adapter instance
-	execBuilder := executor.NewBuilder().
-		WithConfig(config).
-		WithAPIClient(apiClient).
-		WithLogger(log)
+	// Create the transport clients used by the task configs. Each resource is applied
+	// through the client registered for its transport.client type, so one task can mix
+	// Kubernetes and Maestro resources. The clients are shared by all executors.
+	// k8sTransport stays nil when no Kubernetes client is needed
+	transportClients := make(map[string]transport_client.TransportClient)
+	var k8sTransport transport_client.TransportClient
+	var maestroClient *maestro_client.Client
 
 	if config.Spec.Clients.Maestro != nil {
 		log.Info(ctx, "Creating Maestro transport client...")
-		maestroClient, err := createMaestroClient(ctx, config.Spec.Clients.Maestro, log)
+		maestroClient, err = createMaestroClient(ctx, config.Spec.Clients.Maestro, log)
 		if err != nil {
 			errCtx := logger.WithErrorField(ctx, err)
 			log.Errorf(errCtx, "Failed to create Maestro client")
 			return fmt.Errorf("failed to create Maestro client: %w", err)
 		}
-		execBuilder = execBuilder.WithTransportClient(maestroClient)
+		transportClients[config_loader.TransportClientMaestro] = maestroClient
 		log.Info(ctx, "Maestro transport client created successfully")
-	} else {
+	} else if usesTransportClient(configs, config_loader.TransportClientMaestro) {
+		err := fmt.Errorf("spec.clients.maestro is required by resources using the %s transport", config_loader.TransportClientMaestro)
+		errCtx := logger.WithErrorField(ctx, err)
+		log.Errorf(errCtx, "Missing required Maestro configuration")
+		return err
+	}
+
+	// The Kubernetes client is the default transport: it is created unless Maestro is the only
+	// transport in use and the resync loop does not list owned resources from Kubernetes
+	resyncFromK8s := config.Spec.Resync != nil && config.Spec.Resync.Enabled &&
+		config.Spec.Resync.Source != config_loader.ResyncSourceAPI
+	if config.Spec.Clients.Maestro == nil || resyncFromK8s ||
+		usesTransportClient(configs, config_loader.TransportClientKubernetes) {
 		log.Info(ctx, "Creating Kubernetes transport client...")
 		k8sClient, err := createK8sClient(ctx, config.Spec.Clients.Kubernetes, log)
 		if err != nil {
@@ -301,26 +340,110 @@ func runServe() error {
 			log.Errorf(errCtx, "Failed to create Kubernetes client")
 			return fmt.Errorf("failed to create Kubernetes client: %w", err)
 		}
-		execBuilder = execBuilder.WithTransportClient(k8sClient)
+		transportClients[config_loader.TransportClientKubernetes] = k8sClient
+		k8sTransport = k8sClient
 		log.Info(ctx, "Kubernetes transport client created successfully")
+
+		// Resources with transport.kubernetes.cluster reach remote clusters through
+		// kubeconfig Secrets read with the local client
+		if clusterCfg := config.Spec.Clients.Kubernetes.ClusterKubeconfig; clusterCfg != nil {
+			clusterClients, err := createClusterClients(k8sClient, config.Spec.Clients.Kubernetes, log)
+			if err != nil {
+				errCtx := logger.WithErrorField(ctx, err)
+				log.Errorf(errCtx, "Failed to create Kubernetes cluster clients")
+				return fmt.Errorf("failed to create Kubernetes cluster clients: %w", err)
+			}
+			transportClients[config_loader.TransportClientKubernetes] = clusterClients
+			log.Infof(ctx, "Kubernetes cluster targets enabled: secretNamespace=%s", clusterCfg.SecretNamespace)
+		}
+	}
+	if config.Spec.Clients.Kubernetes.ClusterKubeconfig == nil && usesClusterTargets(configs) {
+		err := fmt.Errorf("spec.clients.kubernetes.clusterKubeconfig is required by resources using transport.kubernetes.cluster")
+		errCtx := logger.WithErrorField(ctx, err)
+		log.Errorf(errCtx, "Missing required Kubernetes configuration")
+		return err
 	}
 
-	// Create the executor using the builder pattern
-	log.Info(ctx, "Creating event executor...")
-	exec, err := execBuilder.Build()
+	// Create one executor per task config using the builder pattern
+	log.Info(ctx, "Creating event executors...")
+	executors := make([]*executor.Executor, 0, len(configs))
+	for _, taskConfig := range configs {
+		builder := executor.NewBuilder().
+			WithConfig(taskConfig).
+			WithAPIClient(apiClient).
+			WithLogger(log)
+		for name, client := range transportClients {
+			builder = builder.WithNamedTransportClient(name, client)
+		}
+		exec, err := builder.Build()
+		if err != nil {
+			errCtx := logger.WithErrorField(ctx, err)
+			log.Errorf(errCtx, "Failed to create executor for task %s", taskConfig.GetTaskName())
+			return fmt.Errorf("failed to create executor for task %s: %w", taskConfig.GetTaskName(), err)
+		}
+		executors = append(executors, exec)
+	}
+	router, err := executor.NewRouter(log, executors...)
 	if err != nil {
 		errCtx := logger.WithErrorField(ctx, err)
-		log.Errorf(errCtx, "Failed to create executor")
-		return fmt.Errorf("failed to create executor: %w", err)
+		log.Errorf(errCtx, "Failed to create event router")
+		return fmt.Errorf("failed to create event router: %w", err)
 	}
 
-	// Create the event handler from the executor
-	// This handler will:
+	// Create the event handler from the router
+	// This handler will route each event to the executors of matching task configs, which:
 	// 1. Extract params from event data
 	// 2. Execute preconditions (API calls, condition checks)
 	// 3. Create/update Kubernetes resources
 	// 4. Execute post actions (status reporting)
-	handler := exec.CreateHandler()
+	handler := router.CreateHandler()
+
+	// Create the config reloader: task config changes are validated and swapped into
+	// the executors between events. Reloads changing deployment-level settings (clients,
+	// broker, resync) or needing a transport client not created above are rejected and
+	// require a restart.
+	reloader, err := reload.NewReloader(configs,
+		func() ([]*config_loader.Config, error) { return config_loader.LoadConfigs(loadOpts...) },
+		router, log,
+		reload.WithOnReload(func(hash string) { healthServer.SetDetail("configHash", hash) }),
+	)
+	if err != nil {
+		errCtx := logger.WithErrorField(ctx, err)
+		log.Errorf(errCtx, "Failed to create config reloader")
+		return fmt.Errorf("failed to create config reloader: %w", err)
+	}
+	healthServer.SetDetail("configHash", reloader.Hash())
+	go func() {
+		if err := reloader.Run(ctx); err != nil {
+			errCtx := logger.WithErrorField(ctx, err)
+			log.Warnf(errCtx, "Config reload disabled")
+		}
+	}()
+
+	// Create the optional resync loop, started once the broker subscription is established
+	var resyncer *resync.Resyncer
+	if resyncCfg := config.Spec.Resync; resyncCfg != nil && resyncCfg.Enabled {
+		resyncer, err = createResyncer(resyncCfg, config.Metadata.Name, apiClient, k8sTransport, router, log)
+		if err != nil {
+			errCtx := logger.WithErrorField(ctx, err)
+			log.Errorf(errCtx, "Failed to create resync loop")
+			return fmt.Errorf("failed to create resync loop: %w", err)
+		}
+	}
+
+	// Start the optional ManifestWork status watch; the reconcile trigger is started once the
+	// broker subscription is established
+	var statusTrigger *statuswatch.Trigger
+	if maestroClient != nil {
+		if statusWatch := config.Spec.Clients.Maestro.StatusWatch; statusWatch != nil && statusWatch.Enabled {
+			statusTrigger, err = startStatusWatch(ctx, maestroClient, statusWatch, router, log)
+			if err != nil {
+				errCtx := logger.WithErrorField(ctx, err)
+				log.Errorf(errCtx, "Failed to create status reconcile trigger")
+				return fmt.Errorf("failed to create status reconcile trigger: %w", err)
+			}
+		}
+	}
 
 	// Handle signals for graceful shutdown
 	sigCh := make(chan os.Signal, 1)
@@ -387,6 +510,13 @@ func runServe() error {
 	healthServer.SetBrokerReady(true)
 	log.Info(ctx, "Adapter is ready to process events")
 
+	if resyncer != nil {
+		go resyncer.Run(ctx)
+	}
+	if statusTrigger != nil {
+		go statusTrigger.Run(ctx)
+	}
+
 	// Channel to signal fatal errors from the errors goroutine
 	fatalErrCh := make(chan error, 1)
 
@@ -453,6 +583,52 @@ func runServe() error {
 	return nil
 }
 
+// usesTransportClient reports whether any resource or precondition resource lookup of the task configs
+// uses the transport client type
+func usesTransportClient(configs []*config_loader.Config, client string) bool {
+	for _, taskConfig := range configs {
+		if taskConfig.UsesTransportClient(client) {
+			return true
+		}
+	}
+	return false
+}
+
+// usesClusterTargets reports whether any resource or precondition resource lookup of the task configs
+// targets a remote cluster
+func usesClusterTargets(configs []*config_loader.Config) bool {
+	for _, taskConfig := range configs {
+		if taskConfig.UsesClusterTargets() {
+			return true
+		}
+	}
+	return false
+}
+
+// createResyncer creates the resync loop with the lister matching the configured source
+func createResyncer(resyncCfg *config_loader.ResyncConfig, adapterName string, apiClient hyperfleet_api.Client,
+	k8sTransport transport_client.TransportClient, exec synthetic.Executor, log logger.Logger) (*resync.Resyncer, error) {
+	var lister resync.Lister
+	switch resyncCfg.Source {
+	case config_loader.ResyncSourceAPI:
+		apiLister, err := resync.NewAPILister(apiClient, resyncCfg.API)
+		if err != nil {
+			return nil, err
+		}
+		lister = apiLister
+	default:
+		if k8sTransport == nil {
+			return nil, fmt.Errorf("resync source %q requires the kubernetes transport", config_loader.ResyncSourceKubernetes)
+		}
+		k8sLister, err := resync.NewKubernetesLister(k8sTransport, resyncCfg.Kubernetes, adapterName)
+		if err != nil {
+			return nil, err
+		}
+		lister = k8sLister
+	}
+	return resync.NewResyncer(resyncCfg, lister, exec, log)
+}
+
 // createAPIClient creates a HyperFleet API client from the config
 func createAPIClient(apiConfig config_loader.HyperfleetAPIConfig, log logger.Logger) (hyperfleet_api.Client, error) {
 	var opts []hyperfleet_api.ClientOption
@@ -510,6 +686,44 @@ func createK8sClient(ctx context.Context, k8sConfig config_loader.KubernetesConf
 	return k8s_client.NewClient(ctx, clientConfig, log)
 }
 
+// createClusterClients creates the remote cluster clients from the config
+func createClusterClients(local *k8s_client.Client, k8sConfig config_loader.KubernetesConfig, log logger.Logger) (*k8s_client.ClusterClients, error) {
+	clusterCfg := k8sConfig.ClusterKubeconfig
+	return k8s_client.NewClusterClients(local, k8s_client.ClusterClientsConfig{
+		SecretNamespace: clusterCfg.SecretNamespace,
+		SecretName:      clusterCfg.SecretName,
+		SecretKey:       clusterCfg.SecretKey,
+		CacheSize:       clusterCfg.CacheSize,
+		IdleTimeout:     clusterCfg.IdleTimeout,
+		QPS:             k8sConfig.QPS,
+		Burst:           k8sConfig.Burst,
+	}, log)
+}
+
+// startStatusWatch starts watching the status of the adapter's ManifestWorks. When a reconcile
+// condition is configured, it returns the trigger synthesizing events on status changes.
+func startStatusWatch(ctx context.Context, maestroClient *maestro_client.Client, statusWatch *config_loader.MaestroStatusWatchConfig,
+	exec synthetic.Executor, log logger.Logger) (*statuswatch.Trigger, error) {
+	var trigger *statuswatch.Trigger
+	var onChange maestro_client.StatusChangeFunc
+	if statusWatch.ReconcileWhen != "" {
+		var err error
+		trigger, err = statuswatch.NewTrigger(statusWatch, exec, log)
+		if err != nil {
+			return nil, err
+		}
+		onChange = trigger.OnStatusChange
+	}
+
+	go func() {
+		if err := maestroClient.WatchStatus(ctx, onChange); err != nil {
+			errCtx := logger.WithErrorField(ctx, err)
+			log.Warnf(errCtx, "ManifestWork status watch disabled")
+		}
+	}()
+	return trigger, nil
+}
+
 // createMaestroClient creates a Maestro client from the config
 func createMaestroClient(ctx context.Context, maestroConfig *config_loader.MaestroClientConfig, log logger.Logger) (*maestro_client.Client, error) {
 	config := &maestro_client.Config{
@@ -2,6 +2,14 @@ package config_loader
 
 import (
 	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/criteria"
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/hyperfleet_api"
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/pkg/utils"
 )
 
 // -----------------------------------------------------------------------------
@@ -167,6 +175,116 @@ func (c *Config) ResourceNames() []string {
 	return names
 }
 
+// TemplateOptions returns the template rendering options for the config.
+// Rendering is strict unless spec.templates.strict is explicitly false.
+func (c *Config) TemplateOptions() utils.TemplateOptions {
+	opts := utils.DefaultTemplateOptions
+	if c != nil && c.Spec.Templates.Strict != nil {
+		opts.Strict = *c.Spec.Templates.Strict
+	}
+	return opts
+}
+
+// -----------------------------------------------------------------------------
+// Parameter Accessors
+// -----------------------------------------------------------------------------
+
+// IsExpression returns true if the parameter is derived from a CEL expression
+func (p *Parameter) IsExpression() bool {
+	return p != nil && p.Expression != ""
+}
+
+// ExpressionEnvVars returns the sorted environment variables that expression params read
+// as "env.NAME". Only these variables are exposed to expressions through "env".
+func ExpressionEnvVars(params []Parameter) []string {
+	names := make(map[string]bool)
+	for _, p := range params {
+		if !p.IsExpression() {
+			continue
+		}
+		fields, _, err := criteria.SelectedFields(p.Expression, "env")
+		if err != nil {
+			continue
+		}
+		for _, f := range fields {
+			names[f] = true
+		}
+	}
+
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
+
+// OrderExpressionParams returns the expression params in dependency order, so that
+// every expression param comes after the expression params it references.
+// Params that do not depend on each other keep their declaration order.
+// Returns an error if an expression cannot be parsed or if params reference each other in a cycle.
+func OrderExpressionParams(params []Parameter) ([]Parameter, error) {
+	exprParams := make(map[string]Parameter)
+	var names []string
+	for _, p := range params {
+		if p.IsExpression() {
+			exprParams[p.Name] = p
+			names = append(names, p.Name)
+		}
+	}
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	// Collect dependencies on other expression params
+	deps := make(map[string][]string, len(names))
+	for _, name := range names {
+		refs, err := criteria.ReferencedVariables(exprParams[name].Expression)
+		if err != nil {
+			return nil, fmt.Errorf("param %q: %w", name, err)
+		}
+		for _, ref := range refs {
+			if _, ok := exprParams[ref]; ok {
+				deps[name] = append(deps[name], ref)
+			}
+		}
+	}
+
+	// Depth-first topological sort in declaration order
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int, len(names))
+	ordered := make([]Parameter, 0, len(names))
+	var visit func(name string, path []string) error
+	visit = func(name string, path []string) error {
+		switch state[name] {
+		case visited:
+			return nil
+		case visiting:
+			return fmt.Errorf("param %q has a circular dependency: %s", name, strings.Join(append(path, name), " -> "))
+		}
+		state[name] = visiting
+		for _, dep := range deps[name] {
+			if err := visit(dep, append(path, name)); err != nil {
+				return err
+			}
+		}
+		state[name] = visited
+		ordered = append(ordered, exprParams[name])
+		return nil
+	}
+
+	for _, name := range names {
+		if err := visit(name, nil); err != nil {
+			return nil, err
+		}
+	}
+	return ordered, nil
+}
+
 // -----------------------------------------------------------------------------
 // Resource Accessors
 // -----------------------------------------------------------------------------
@@ -185,6 +303,80 @@ func (r *Resource) IsMaestroTransport() bool {
 	return r.GetTransportClient() == TransportClientMaestro
 }
 
+// GetTargetCluster returns the kubernetes transport target cluster template of this resource.
+// Empty means the adapter's own cluster.
+func (r *Resource) GetTargetCluster() string {
+	if r == nil || r.Transport == nil || r.Transport.Kubernetes == nil {
+		return ""
+	}
+	return r.Transport.Kubernetes.Cluster
+}
+
+// GetDeleteOption returns the maestro transport delete option of this resource, or nil
+func (r *Resource) GetDeleteOption() *MaestroDeleteOption {
+	if r == nil || r.Transport == nil || r.Transport.Maestro == nil {
+		return nil
+	}
+	return r.Transport.Maestro.DeleteOption
+}
+
+// UsesClusterTargets reports whether any resource or precondition resource lookup of the config
+// targets a remote cluster through transport.kubernetes.cluster
+func (c *Config) UsesClusterTargets() bool {
+	if c == nil {
+		return false
+	}
+	for _, resource := range c.transportResources() {
+		if resource.GetTransportClient() == TransportClientKubernetes && resource.GetTargetCluster() != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// UsesTransportClient reports whether any resource or precondition resource lookup of the config
+// uses the given transport client type
+func (c *Config) UsesTransportClient(client string) bool {
+	if c == nil {
+		return false
+	}
+	for _, resource := range c.transportResources() {
+		if resource.GetTransportClient() == client {
+			return true
+		}
+	}
+	return false
+}
+
+// transportResources returns the resources of the config followed by the resources read by
+// precondition resource lookups
+func (c *Config) transportResources() []*Resource {
+	resources := make([]*Resource, 0, len(c.Spec.Resources))
+	for i := range c.Spec.Resources {
+		resources = append(resources, &c.Spec.Resources[i])
+	}
+	for i := range c.Spec.Preconditions {
+		if resource := c.Spec.Preconditions[i].LookupResource(); resource != nil {
+			resources = append(resources, resource)
+		}
+	}
+	return resources
+}
+
+// LookupResource returns the resource read by the resource lookup of this precondition, named
+// after the precondition and carrying the transport and discovery of the lookup.
+// Returns nil when the precondition has no resource lookup.
+func (p *Precondition) LookupResource() *Resource {
+	if p == nil || p.ResourceLookup == nil {
+		return nil
+	}
+	return &Resource{
+		Name:      p.Name,
+		Transport: p.ResourceLookup.Transport,
+		Discovery: p.ResourceLookup.Discovery,
+	}
+}
+
 // HasManifestRef returns true if the manifest uses a ref (single file reference)
 func (r *Resource) HasManifestRef() bool {
 	if r == nil || r.Manifest == nil {
@@ -259,3 +451,88 @@ func normalizeToStringKeyMap(v interface{}) map[string]interface{} {
 		return nil
 	}
 }
+
+// CELLimits returns the CEL evaluation limits; a nil config returns the defaults
+func (c *CELConfig) CELLimits() criteria.CELLimits {
+	if c == nil {
+		return criteria.CELLimits{}
+	}
+	return criteria.CELLimits{
+		CostLimit:               c.CostLimit,
+		InterruptCheckFrequency: c.InterruptCheckFrequency,
+		Timeout:                 c.Timeout,
+	}
+}
+
+// CELPrograms returns the program cache of the config set the config was loaded with, or an empty
+// cache with the CEL limits of the config when it was not loaded from files
+func (c *Config) CELPrograms() (*criteria.ProgramCache, error) {
+	if c.Programs != nil {
+		return c.Programs, nil
+	}
+	return criteria.NewProgramCache(c.Spec.CEL.CELLimits())
+}
+
+// DefaultPreconditionRetryInterval is the delay before re-evaluating a precondition that is not met
+const DefaultPreconditionRetryInterval = 5 * time.Second
+
+// DefaultPreconditionRetryMaxInterval caps the delay between evaluations of a precondition
+const DefaultPreconditionRetryMaxInterval = 5 * time.Minute
+
+// DefaultPreconditionRetryMaxElapsed bounds the time spent retrying a precondition. Events of the
+// same resource are executed one at a time, so retries must not keep the other events waiting long.
+const DefaultPreconditionRetryMaxElapsed = 2 * time.Minute
+
+// MaxAttempts returns the maximum number of evaluations of the precondition (1 without retry)
+func (p *Precondition) MaxAttempts() int {
+	if p.Retry == nil || p.Retry.Attempts < 1 {
+		return 1
+	}
+	return p.Retry.Attempts
+}
+
+// Delay returns the delay before the evaluation following the given attempt (1-based),
+// capped at the max interval. Invalid intervals, rejected by the validator, fall back to the defaults.
+func (r *PreconditionRetry) Delay(attempt int) time.Duration {
+	interval := positiveDuration(r.Interval, DefaultPreconditionRetryInterval)
+	maxInterval := positiveDuration(r.MaxInterval, max(DefaultPreconditionRetryMaxInterval, interval))
+
+	// Computed as float64 so that a large attempt cannot overflow before the cap
+	var delay float64
+	switch hyperfleet_api.BackoffStrategy(r.Backoff) {
+	case hyperfleet_api.BackoffExponential:
+		delay = float64(interval) * math.Pow(2, float64(attempt-1))
+	case hyperfleet_api.BackoffLinear:
+		delay = float64(interval) * float64(attempt)
+	default:
+		delay = float64(interval)
+	}
+	if delay > float64(maxInterval) {
+		return maxInterval
+	}
+	return time.Duration(delay)
+}
+
+// MaxElapsedTime returns the time budget of the retries, from the first evaluation to the last one.
+// An invalid budget, rejected by the validator, falls back to the default.
+func (r *PreconditionRetry) MaxElapsedTime() time.Duration {
+	return positiveDuration(r.MaxElapsed, DefaultPreconditionRetryMaxElapsed)
+}
+
+// TotalDelay returns the sum of the delays between the given number of attempts, i.e. the
+// worst-case time the retries wait
+func (r *PreconditionRetry) TotalDelay(attempts int) time.Duration {
+	var total time.Duration
+	for attempt := 1; attempt < attempts; attempt++ {
+		total += r.Delay(attempt)
+	}
+	return total
+}
+
+// positiveDuration parses a positive Go duration, returning fallback when unset or invalid
+func positiveDuration(value string, fallback time.Duration) time.Duration {
+	if d, err := time.ParseDuration(value); err == nil && d > 0 {
+		return d
+	}
+	return fallback
+}
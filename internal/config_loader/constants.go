@@ -19,6 +19,17 @@ const (
 	FieldPreconditions = "preconditions"
 	FieldResources     = "resources"
 	FieldPost          = "post"
+	FieldMatch         = "match"
+)
+
+// CEL variables set by the executor next to the variables of the task config
+const (
+	// VariableEvent is the event data, available to match and param expressions
+	VariableEvent = "event"
+	// VariableEventType is the CloudEvent type, available to match expressions
+	VariableEventType = "eventType"
+	// VariableEnv holds the environment variables read by param expressions
+	VariableEnv = "env"
 )
 
 // Adapter field names
@@ -34,6 +45,7 @@ const (
 	FieldDescription = "description"
 	FieldRequired    = "required"
 	FieldDefault     = "default"
+	FieldSchema      = "schema"
 )
 
 // Payload field names (for post.payloads)
@@ -45,10 +57,13 @@ const (
 
 // Precondition field names
 const (
-	FieldAPICall    = "apiCall"
-	FieldCapture    = "capture"
-	FieldConditions = "conditions"
-	FieldExpression = "expression"
+	FieldAPICall        = "apiCall"
+	FieldCapture        = "capture"
+	FieldConditions     = "conditions"
+	FieldExpression     = "expression"
+	FieldRetry          = "retry"
+	FieldParallelGroup  = "parallelGroup"
+	FieldResourceLookup = "resourceLookup"
 )
 
 // API call field names
@@ -65,12 +80,24 @@ const (
 	FieldHeaderValue = "value"
 )
 
+// Precondition retry field names
+const (
+	FieldAttempts    = "attempts"
+	FieldInterval    = "interval"
+	FieldMaxInterval = "maxInterval"
+	FieldBackoff     = "backoff"
+	FieldMaxElapsed  = "maxElapsed"
+)
+
 // Condition field names
 const (
 	FieldField    = "field"
 	FieldOperator = "operator"
 	FieldValue    = "value"  // Supports any type including lists for operators like "in", "notIn"
 	FieldValues   = "values" // YAML alias for Value - both "value" and "values" are accepted in YAML
+	FieldAnyOf    = "anyOf"
+	FieldAllOf    = "allOf"
+	FieldNot      = "not"
 )
 
 // Transport field names
@@ -79,6 +106,8 @@ const (
 	FieldClient        = "client"
 	FieldMaestro       = "maestro"
 	FieldTargetCluster = "targetCluster"
+	FieldCluster       = "cluster"
+	FieldDeleteOption  = "deleteOption"
 )
 
 // Transport client types
@@ -87,12 +116,30 @@ const (
 	TransportClientMaestro    = "maestro"
 )
 
+// ManifestWork delete propagation policies (transport.maestro.deleteOption.propagationPolicy)
+const (
+	DeletePropagationForeground        = "Foreground"
+	DeletePropagationOrphan            = "Orphan"
+	DeletePropagationSelectivelyOrphan = "SelectivelyOrphan"
+)
+
 // Resource field names
 const (
 	FieldManifest          = "manifest"
 	FieldRecreateOnChange  = "recreateOnChange"
+	FieldDriftDetection    = "driftDetection"
 	FieldDiscovery         = "discovery"
 	FieldNestedDiscoveries = "nestedDiscoveries"
+	FieldManifestFrom      = "manifestFrom"
+	FieldPatches           = "patches"
+	FieldDelete            = "delete"
+)
+
+// Patch field names
+const (
+	FieldPatch  = "patch"
+	FieldWhen   = "when"
+	FieldTarget = "target"
 )
 
 // Manifest reference field names
@@ -100,6 +147,19 @@ const (
 	FieldRef = "ref"
 )
 
+// ManifestFrom field names
+const (
+	FieldPath  = "path"
+	FieldChart = "chart"
+)
+
+// Nested discovery feedback field names
+const (
+	FieldFeedback  = "feedback"
+	FieldJSONPaths = "jsonPaths"
+	FieldOrphan    = "orphan"
+)
+
 // Discovery field names
 const (
 	FieldNamespace   = "namespace"
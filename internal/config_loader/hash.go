@@ -0,0 +1,54 @@
+package config_loader
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Hash returns a hex-encoded SHA-256 identifying the loaded configs.
+// It covers the merged configs (including env and flag overrides) and the content
+// of their source files, so a change to a referenced manifest changes the hash too.
+func Hash(configs []*Config) (string, error) {
+	h := sha256.New()
+	for _, config := range configs {
+		data, err := yaml.Marshal(config)
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal config %q: %w", config.GetTaskName(), err)
+		}
+		_, _ = h.Write(data)
+
+		for _, path := range config.SourceFiles {
+			if err := hashPath(h, path); err != nil {
+				return "", err
+			}
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// hashPath writes the name and content of a file, or of every file under a directory
+// (in lexical order), to the hash
+func hashPath(w io.Writer, root string) error {
+	return filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return fmt.Errorf("failed to read %q: %w", path, err)
+		}
+		if entry.IsDir() {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read %q: %w", path, err)
+		}
+		_, _ = fmt.Fprintf(w, "%s\x00%d\x00", path, len(data))
+		_, _ = w.Write(data)
+		return nil
+	})
+}
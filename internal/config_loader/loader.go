@@ -1,9 +1,16 @@
 package config_loader
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
+	"strings"
 
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/criteria"
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/manifest"
 	"github.com/openshift-hyperfleet/hyperfleet-adapter/pkg/utils"
 	"gopkg.in/yaml.v3"
 )
@@ -94,8 +101,24 @@ func WithSkipSemanticValidation() LoadOption {
 
 // LoadConfig loads both deployment and task configurations, validates them,
 // and returns a unified Config struct.
+// The task config path must resolve to a single task config; use LoadConfigs for several.
 // Priority for deployment config values: CLI flags > Environment variables > Config file > Defaults
 func LoadConfig(opts ...LoadOption) (*Config, error) {
+	configs, err := LoadConfigs(opts...)
+	if err != nil {
+		return nil, err
+	}
+	if len(configs) != 1 {
+		return nil, fmt.Errorf("expected a single task config, found %d", len(configs))
+	}
+	return configs[0], nil
+}
+
+// LoadConfigs loads the deployment configuration and every task configuration, validates them,
+// and returns one unified Config per task config.
+// The task config path may be a file, a directory of YAML files (read in name order),
+// or a comma-separated list of files and directories. Task config names must be unique.
+func LoadConfigs(opts ...LoadOption) ([]*Config, error) {
 	o := &loadOptions{}
 	for _, opt := range opts {
 		opt(o)
@@ -134,57 +157,197 @@ func LoadConfig(opts ...LoadOption) (*Config, error) {
 		}
 	}
 
-	// 2. Load AdapterTaskConfig from YAML (no env binding)
-	taskCfg, err := loadTaskConfig(o.taskConfigPath)
+	// 2. Load each AdapterTaskConfig from YAML (no env binding)
+	taskConfigPath := o.taskConfigPath
+	if taskConfigPath == "" {
+		taskConfigPath = os.Getenv(EnvTaskConfigPath)
+	}
+	taskPaths, err := resolveTaskConfigPaths(taskConfigPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load task config: %w", err)
 	}
 
-	// Get base directory from task config path
-	taskConfigPath := o.taskConfigPath
-	if taskConfigPath == "" {
-		taskConfigPath = os.Getenv(EnvTaskConfigPath)
+	// The CEL programs of the config set are compiled during validation with the set's limits
+	programs, err := criteria.NewProgramCache(adapterCfg.Spec.CEL.CELLimits())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CEL program cache: %w", err)
+	}
+
+	configs := make([]*Config, 0, len(taskPaths))
+	taskNames := make(map[string]string, len(taskPaths))
+	for _, taskPath := range taskPaths {
+		taskCfg, warnings, err := loadValidatedTaskConfig(taskPath, programs, o)
+		if err != nil {
+			if len(taskPaths) > 1 {
+				return nil, fmt.Errorf("task config %q: %w", taskPath, err)
+			}
+			return nil, err
+		}
+
+		if other, exists := taskNames[taskCfg.Metadata.Name]; exists {
+			return nil, fmt.Errorf("task config %q: metadata.name %q is already used by %q",
+				taskPath, taskCfg.Metadata.Name, other)
+		}
+		taskNames[taskCfg.Metadata.Name] = taskPath
+
+		// 3. Merge into unified Config
+		config := Merge(adapterCfg, taskCfg)
+		if config == nil {
+			return nil, fmt.Errorf("failed to merge configurations")
+		}
+		config.Warnings = warnings
+		config.Programs = programs
+		config.SourceFiles, err = taskConfigSourceFiles(taskCfg, taskPath)
+		if err != nil {
+			return nil, fmt.Errorf("task config %q: %w", taskPath, err)
+		}
+		configs = append(configs, config)
+	}
+
+	return configs, nil
+}
+
+// loadValidatedTaskConfig loads a task config file, validates it and loads its file references.
+// The semantic validation compiles the CEL expressions of the task config into programs.
+// It returns the validation warnings along with the config.
+func loadValidatedTaskConfig(taskConfigPath string, programs *criteria.ProgramCache, o *loadOptions) (*AdapterTaskConfig, []string, error) {
+	taskCfg, err := loadTaskConfig(taskConfigPath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load task config: %w", err)
 	}
+
+	// Get base directory from task config path
 	taskBaseDir := ""
 	if taskConfigPath != "" {
 		var errBaseDir error
 		taskBaseDir, errBaseDir = getBaseDir(taskConfigPath)
 		if errBaseDir != nil {
-			return nil, fmt.Errorf("failed to get base directory for task config: %w", errBaseDir)
+			return nil, nil, fmt.Errorf("failed to get base directory for task config: %w", errBaseDir)
 		}
 	}
 
 	// Validate AdapterTaskConfig structure
 	taskValidator := NewTaskConfigValidator(taskCfg, taskBaseDir)
+	taskValidator.programs = programs
 	if err := taskValidator.ValidateStructure(); err != nil {
-		return nil, fmt.Errorf("task config validation failed: %w", err)
+		return nil, nil, fmt.Errorf("task config validation failed: %w", err)
 	}
 
 	// Validate and load file references in task config
 	if taskBaseDir != "" {
 		if err := taskValidator.ValidateFileReferences(); err != nil {
-			return nil, fmt.Errorf("task config file reference validation failed: %w", err)
+			return nil, nil, fmt.Errorf("task config file reference validation failed: %w", err)
 		}
 
 		if err := loadTaskConfigFileReferences(taskCfg, taskBaseDir); err != nil {
-			return nil, fmt.Errorf("failed to load task config file references: %w", err)
+			return nil, nil, fmt.Errorf("failed to load task config file references: %w", err)
 		}
 	}
 
 	// Semantic validation for task config (optional)
 	if !o.skipSemanticValidation {
 		if err := taskValidator.ValidateSemantic(); err != nil {
-			return nil, fmt.Errorf("task config semantic validation failed: %w", err)
+			return nil, nil, fmt.Errorf("task config semantic validation failed: %w", err)
+		}
+	}
+
+	return taskCfg, taskValidator.Warnings(), nil
+}
+
+// resolveTaskConfigPaths expands a task config path into task config files.
+// The path may be a file, a directory or a comma-separated list of both.
+// An empty path resolves to a single empty entry so loadTaskConfig reports the missing path.
+func resolveTaskConfigPaths(path string) ([]string, error) {
+	if path == "" {
+		return []string{""}, nil
+	}
+
+	var paths []string
+	for _, entry := range strings.Split(path, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		info, err := os.Stat(entry)
+		if err != nil || !info.IsDir() {
+			// Files (and missing paths) are reported by loadTaskConfig
+			paths = append(paths, entry)
+			continue
+		}
+
+		// Symlinks are followed so a mounted ConfigMap directory can be used
+		names, err := dirFiles(entry, isTaskConfigFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read task config directory %q: %w", entry, err)
+		}
+		if len(names) == 0 {
+			return nil, fmt.Errorf("task config directory %q contains no YAML files", entry)
+		}
+		for _, name := range names {
+			paths = append(paths, filepath.Join(entry, name))
+		}
+	}
+
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("task config path %q contains no task configs", path)
+	}
+	return paths, nil
+}
+
+// taskConfigSourceFiles returns the task config file followed by the files and
+// directories referenced by manifest.ref, manifestFrom and buildRef
+func taskConfigSourceFiles(config *AdapterTaskConfig, taskConfigPath string) ([]string, error) {
+	if taskConfigPath == "" {
+		return nil, nil
+	}
+	baseDir, err := getBaseDir(taskConfigPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get base directory for task config: %w", err)
+	}
+
+	var refs []string
+	for i := range config.Spec.Resources {
+		resource := &config.Spec.Resources[i]
+		if ref := resource.GetManifestRef(); ref != "" {
+			refs = append(refs, ref)
+		}
+		if from := resource.ManifestFrom; from != nil {
+			if from.Path != "" {
+				refs = append(refs, from.Path)
+			}
+			if from.Chart != nil {
+				refs = append(refs, from.Chart.Path)
+			}
+		}
+	}
+	if config.Spec.Post != nil {
+		for _, payload := range config.Spec.Post.Payloads {
+			if payload.BuildRef != "" {
+				refs = append(refs, payload.BuildRef)
+			}
 		}
 	}
 
-	// 3. Merge into unified Config
-	config := Merge(adapterCfg, taskCfg)
-	if config == nil {
-		return nil, fmt.Errorf("failed to merge configurations")
+	files := []string{filepath.Join(baseDir, filepath.Base(taskConfigPath))}
+	for _, ref := range refs {
+		path, err := resolvePath(baseDir, ref)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, path)
 	}
+	return files, nil
+}
 
-	return config, nil
+// isTaskConfigFile reports whether a file name has a YAML extension
+func isTaskConfigFile(name string) bool {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".yaml", ".yml":
+		return true
+	default:
+		return false
+	}
 }
 
 // -----------------------------------------------------------------------------
@@ -201,7 +364,7 @@ func loadTaskConfigFileReferences(config *AdapterTaskConfig, baseDir string) err
 			continue
 		}
 
-		content, err := loadYAMLFile(baseDir, ref)
+		content, err := loadManifestFile(baseDir, ref)
 		if err != nil {
 			return fmt.Errorf("%s.%s[%d].%s.%s: %w", FieldSpec, FieldResources, i, FieldManifest, FieldRef, err)
 		}
@@ -210,6 +373,34 @@ func loadTaskConfigFileReferences(config *AdapterTaskConfig, baseDir string) err
 		resource.Manifest = content
 	}
 
+	// Load manifestFrom in spec.resources
+	for i := range config.Spec.Resources {
+		from := config.Spec.Resources[i].ManifestFrom
+		if from == nil {
+			continue
+		}
+
+		path := fmt.Sprintf("%s.%s[%d].%s", FieldSpec, FieldResources, i, FieldManifestFrom)
+		if from.Path != "" {
+			manifests, err := loadManifestPath(baseDir, from.Path)
+			if err != nil {
+				return fmt.Errorf("%s.%s: %w", path, FieldPath, err)
+			}
+			from.Manifests = manifests
+		}
+		if from.Chart != nil {
+			chartDir, err := resolvePath(baseDir, from.Chart.Path)
+			if err != nil {
+				return fmt.Errorf("%s.%s.%s: %w", path, FieldChart, FieldPath, err)
+			}
+			chart, err := manifest.LoadChart(chartDir)
+			if err != nil {
+				return fmt.Errorf("%s.%s.%s: %w", path, FieldChart, FieldPath, err)
+			}
+			from.ChartContent = chart
+		}
+	}
+
 	// Load buildRef in spec.post.payloads
 	if config.Spec.Post != nil {
 		for i := range config.Spec.Post.Payloads {
@@ -229,24 +420,168 @@ func loadTaskConfigFileReferences(config *AdapterTaskConfig, baseDir string) err
 
 // loadYAMLFile loads and parses a YAML file
 func loadYAMLFile(baseDir, refPath string) (map[string]interface{}, error) {
-	fullPath, err := resolvePath(baseDir, refPath)
+	fullPath, data, err := readRefFile(baseDir, refPath)
 	if err != nil {
 		return nil, err
 	}
 
-	data, err := os.ReadFile(fullPath)
+	var content map[string]interface{}
+	if err := yaml.Unmarshal(data, &content); err != nil {
+		return nil, fmt.Errorf("failed to parse YAML file %q: %w", fullPath, err)
+	}
+
+	return content, nil
+}
+
+// loadManifestFile loads and parses a manifest YAML file, preserving !expr tags
+// as expression markers like inline manifests.
+func loadManifestFile(baseDir, refPath string) (map[string]interface{}, error) {
+	fullPath, data, err := readRefFile(baseDir, refPath)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read file %q: %w", fullPath, err)
+		return nil, err
+	}
+
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return nil, fmt.Errorf("failed to parse YAML file %q: %w", fullPath, err)
+	}
+	if node.Kind == 0 {
+		return nil, nil // empty file
+	}
+	if err := markManifestExpressions(&node); err != nil {
+		return nil, fmt.Errorf("failed to parse YAML file %q: %w", fullPath, err)
 	}
 
 	var content map[string]interface{}
-	if err := yaml.Unmarshal(data, &content); err != nil {
+	if err := node.Decode(&content); err != nil {
 		return nil, fmt.Errorf("failed to parse YAML file %q: %w", fullPath, err)
 	}
 
 	return content, nil
 }
 
+// loadManifestPath loads all manifests from a YAML file or a directory of YAML/JSON files.
+// Files may contain several YAML documents. Directory entries are read in name order and
+// symlinks are followed (see dirFiles). A directory without manifests is an error.
+func loadManifestPath(baseDir, refPath string) ([]map[string]interface{}, error) {
+	fullPath, err := resolvePath(baseDir, refPath)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := os.Stat(fullPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %q: %w", fullPath, err)
+	}
+	if !info.IsDir() {
+		return loadManifestDocuments(baseDir, refPath)
+	}
+
+	names, err := dirFiles(fullPath, isManifestFile)
+	if err != nil {
+		return nil, err
+	}
+
+	var manifests []map[string]interface{}
+	for _, name := range names {
+		docs, err := loadManifestDocuments(baseDir, filepath.Join(refPath, name))
+		if err != nil {
+			return nil, err
+		}
+		manifests = append(manifests, docs...)
+	}
+	if len(manifests) == 0 {
+		return nil, fmt.Errorf("directory %q contains no manifests", fullPath)
+	}
+	return manifests, nil
+}
+
+// dirFiles returns the names, in name order, of the directory entries accepted by match whose
+// target is a regular file. Symlinks are followed, so the files of a mounted ConfigMap (symlinks
+// to ..data/<key>) are found; entries starting with ".." (..data and the timestamped directories
+// of the ConfigMap volume) are skipped.
+func dirFiles(dir string, match func(name string) bool) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read directory %q: %w", dir, err)
+	}
+
+	var names []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if strings.HasPrefix(name, "..") || !match(name) {
+			continue
+		}
+		info, err := os.Stat(filepath.Join(dir, name))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %q: %w", filepath.Join(dir, name), err)
+		}
+		if info.Mode().IsRegular() {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+// loadManifestDocuments loads every document of a multi-document manifest file,
+// preserving !expr tags as expression markers. Empty documents are skipped.
+func loadManifestDocuments(baseDir, refPath string) ([]map[string]interface{}, error) {
+	fullPath, data, err := readRefFile(baseDir, refPath)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	var manifests []map[string]interface{}
+	for i := 0; ; i++ {
+		var node yaml.Node
+		if err := decoder.Decode(&node); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("failed to parse YAML file %q: %w", fullPath, err)
+		}
+		if err := markManifestExpressions(&node); err != nil {
+			return nil, fmt.Errorf("failed to parse YAML file %q: %w", fullPath, err)
+		}
+
+		var doc map[string]interface{}
+		if err := node.Decode(&doc); err != nil {
+			return nil, fmt.Errorf("failed to parse YAML file %q (document %d): %w", fullPath, i, err)
+		}
+		if len(doc) == 0 {
+			continue
+		}
+		manifests = append(manifests, doc)
+	}
+	return manifests, nil
+}
+
+// isManifestFile reports whether a file name has a manifest extension
+func isManifestFile(name string) bool {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".yaml", ".yml", ".json":
+		return true
+	default:
+		return false
+	}
+}
+
+// readRefFile resolves a referenced file path against the base directory and reads it
+func readRefFile(baseDir, refPath string) (string, []byte, error) {
+	fullPath, err := resolvePath(baseDir, refPath)
+	if err != nil {
+		return "", nil, err
+	}
+
+	data, err := os.ReadFile(fullPath)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to read file %q: %w", fullPath, err)
+	}
+
+	return fullPath, data, nil
+}
+
 // resolvePath resolves a relative path against the base directory and validates
 // that the resolved path does not escape the base directory.
 // This delegates to utils.ResolveSecurePath.
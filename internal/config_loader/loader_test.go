@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/criteria"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"gopkg.in/yaml.v3"
@@ -159,6 +160,134 @@ spec:
 	assert.Contains(t, err.Error(), "failed to load task config")
 }
 
+func TestLoadConfigWithResync(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	adapterYAML := `
+apiVersion: hyperfleet.redhat.com/v1alpha1
+kind: AdapterConfig
+metadata:
+  name: test-adapter
+spec:
+  adapter:
+    version: "0.1.0"
+  clients:
+    hyperfleetApi:
+      baseUrl: "https://test.example.com"
+    kubernetes:
+      apiVersion: "v1"
+  resync:
+    enabled: false
+    interval: 15m
+    jitter: 0.2
+    rateLimit: 5
+    kubernetes:
+      eventKind: Cluster
+      resources:
+        - apiVersion: v1
+          kind: Namespace
+        - apiVersion: batch/v1
+          kind: Job
+          namespace: jobs
+`
+
+	taskYAML := `
+apiVersion: hyperfleet.redhat.com/v1alpha1
+kind: AdapterTaskConfig
+metadata:
+  name: test-adapter
+spec:
+  params:
+    - name: "clusterId"
+      source: "event.id"
+`
+
+	adapterPath, taskPath := createTestConfigFiles(t, tmpDir, adapterYAML, taskYAML)
+	t.Setenv(EnvPrefix+"_RESYNC_ENABLED", "true")
+	t.Setenv(EnvPrefix+"_RESYNC_INTERVAL", "30m")
+
+	config, err := LoadConfig(
+		WithAdapterConfigPath(adapterPath),
+		WithTaskConfigPath(taskPath),
+		WithSkipSemanticValidation(),
+	)
+	require.NoError(t, err)
+
+	resync := config.Spec.Resync
+	require.NotNil(t, resync)
+	assert.True(t, resync.Enabled, "enabled is overridden by env")
+	assert.Equal(t, 30*time.Minute, resync.Interval, "interval is overridden by env")
+	assert.InDelta(t, 0.2, resync.Jitter, 0.0001)
+	assert.InDelta(t, 5.0, resync.RateLimit, 0.0001)
+	require.NotNil(t, resync.Kubernetes)
+	require.Len(t, resync.Kubernetes.Resources, 2)
+	assert.Equal(t, ResyncResource{APIVersion: "batch/v1", Kind: "Job", Namespace: "jobs"}, resync.Kubernetes.Resources[1])
+}
+
+func TestLoadConfigWithCELLimits(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	adapterYAML := `
+apiVersion: hyperfleet.redhat.com/v1alpha1
+kind: AdapterConfig
+metadata:
+  name: test-adapter
+spec:
+  adapter:
+    version: "0.1.0"
+  clients:
+    hyperfleetApi:
+      baseUrl: "https://test.example.com"
+    kubernetes:
+      apiVersion: "v1"
+  cel:
+    costLimit: 5000
+    interruptCheckFrequency: 10
+    timeout: 250ms
+`
+
+	taskYAML := `
+apiVersion: hyperfleet.redhat.com/v1alpha1
+kind: AdapterTaskConfig
+metadata:
+  name: test-adapter
+spec:
+  params:
+    - name: "clusterId"
+      source: "event.id"
+  preconditions:
+    - name: "check"
+      expression: "clusterId.size() > 0"
+    - name: "scan"
+      expression: "clusterId.split('-').all(part, part.size() > 0)"
+`
+
+	adapterPath, taskPath := createTestConfigFiles(t, tmpDir, adapterYAML, taskYAML)
+
+	config, err := LoadConfig(
+		WithAdapterConfigPath(adapterPath),
+		WithTaskConfigPath(taskPath),
+	)
+	require.NoError(t, err)
+
+	require.NotNil(t, config.Spec.CEL)
+	assert.Equal(t, criteria.CELLimits{
+		CostLimit:               5000,
+		InterruptCheckFrequency: 10,
+		Timeout:                 250 * time.Millisecond,
+	}, config.Spec.CEL.CELLimits())
+
+	// Only the comprehension has an unbounded worst-case cost
+	require.Len(t, config.Warnings, 1)
+	assert.Contains(t, config.Warnings[0], "spec.preconditions[1].expression")
+	assert.Contains(t, config.Warnings[0], "unbounded worst-case cost")
+
+	// The programs of the config set are compiled at load time with its limits
+	programs, err := config.CELPrograms()
+	require.NoError(t, err)
+	assert.Same(t, config.Programs, programs)
+}
+
 func TestAdapterConfigValidation(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -251,6 +380,135 @@ spec:
 			wantError: true,
 			errorMsg:  "unsupported apiVersion",
 		},
+		{
+			name: "valid resync with api source",
+			yaml: `
+apiVersion: hyperfleet.redhat.com/v1alpha1
+kind: AdapterConfig
+metadata:
+  name: test-adapter
+spec:
+  adapter:
+    version: "1.0.0"
+  resync:
+    enabled: true
+    interval: 5m
+    source: api
+    api:
+      path: /api/hyperfleet/v1/clusters
+`,
+			wantError: false,
+		},
+		{
+			name: "resync with unknown source",
+			yaml: `
+apiVersion: hyperfleet.redhat.com/v1alpha1
+kind: AdapterConfig
+metadata:
+  name: test-adapter
+spec:
+  adapter:
+    version: "1.0.0"
+  resync:
+    enabled: true
+    source: database
+`,
+			wantError: true,
+			errorMsg:  "spec.resync.source \"database\" is invalid",
+		},
+		{
+			name: "resync jitter out of range",
+			yaml: `
+apiVersion: hyperfleet.redhat.com/v1alpha1
+kind: AdapterConfig
+metadata:
+  name: test-adapter
+spec:
+  adapter:
+    version: "1.0.0"
+  resync:
+    enabled: true
+    jitter: 2
+    source: api
+    api:
+      path: /clusters
+`,
+			wantError: true,
+			errorMsg:  "spec.resync.jitter: must be less than or equal to 1",
+		},
+		{
+			name: "enabled resync without kubernetes source config",
+			yaml: `
+apiVersion: hyperfleet.redhat.com/v1alpha1
+kind: AdapterConfig
+metadata:
+  name: test-adapter
+spec:
+  adapter:
+    version: "1.0.0"
+  resync:
+    enabled: true
+`,
+			wantError: true,
+			errorMsg:  "spec.resync.kubernetes is required",
+		},
+		{
+			name: "kubernetes resync source without resources",
+			yaml: `
+apiVersion: hyperfleet.redhat.com/v1alpha1
+kind: AdapterConfig
+metadata:
+  name: test-adapter
+spec:
+  adapter:
+    version: "1.0.0"
+  resync:
+    enabled: true
+    kubernetes:
+      eventKind: Cluster
+`,
+			wantError: true,
+			errorMsg:  "spec.resync.kubernetes.resources is required",
+		},
+		{
+			name: "valid maestro status watch",
+			yaml: `
+apiVersion: hyperfleet.redhat.com/v1alpha1
+kind: AdapterConfig
+metadata:
+  name: test-adapter
+spec:
+  adapter:
+    version: "1.0.0"
+  clients:
+    maestro:
+      grpcServerAddress: "maestro-grpc:8090"
+      statusWatch:
+        enabled: true
+        reconcileWhen: 'work.status.conditions.exists(c, c.type == "Available" && c.status == "True")'
+`,
+			wantError: false,
+		},
+		{
+			name: "maestro status watch with invalid condition",
+			yaml: `
+apiVersion: hyperfleet.redhat.com/v1alpha1
+kind: AdapterConfig
+metadata:
+  name: test-adapter
+spec:
+  adapter:
+    version: "1.0.0"
+  clients:
+    maestro:
+      grpcServerAddress: "maestro-grpc:8090"
+      statusWatch:
+        enabled: true
+        reconcileWhen: 'work.status.conditions.exists('
+`,
+			wantError: true,
+			errorMsg:  "spec.clients.maestro.statusWatch.reconcileWhen",
+		},
 	}
 
 	for _, tt := range tests {
@@ -356,7 +614,7 @@ spec:
       required: true
 `,
 			wantError: true,
-			errorMsg:  "source is required",
+			errorMsg:  "must have either 'source' or 'expression' set",
 		},
 	}
 
@@ -432,7 +690,7 @@ spec:
     - name: "checkCluster"
 `,
 			wantError: true,
-			errorMsg:  "spec.preconditions[0]: must specify apiCall, conditions",
+			errorMsg:  "spec.preconditions[0]: must specify apiCall, resourceLookup, conditions",
 		},
 		{
 			name: "API call without method",
@@ -991,6 +1249,17 @@ spec:
 	require.Len(t, config.Spec.Post.Payloads, 1)
 	assert.NotNil(t, config.Spec.Post.Payloads[0].BuildRefContent)
 
+	// Verify the task config and its buildRef are recorded as source files
+	assert.Equal(t, []string{taskPath, templateFile}, config.SourceFiles)
+
+	// The hash changes with the content of referenced files
+	hash, err := Hash([]*Config{config})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(templateFile, []byte(`status: "{{ .phase }}"`), 0644))
+	changedHash, err := Hash([]*Config{config})
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, changedHash)
+
 	// Now test with non-existent buildRef
 	taskYAMLBad := `
 apiVersion: hyperfleet.redhat.com/v1alpha1
@@ -1205,11 +1474,11 @@ func TestValidateResourceDiscoveryInTaskConfig(t *testing.T) {
 						"apiVersion": "v1",
 						"kind":       "ConfigMap",
 					},
-					// Missing discovery - required for all resources
+					// Missing discovery - required unless manifestFrom is used
 				},
 			},
 			wantErr: true,
-			errMsg:  "discovery is required",
+			errMsg:  "must have either 'discovery' or 'manifestFrom' set",
 		},
 		{
 			name: "invalid - manifest.ref missing discovery",
@@ -1221,7 +1490,7 @@ func TestValidateResourceDiscoveryInTaskConfig(t *testing.T) {
 				},
 			},
 			wantErr: true,
-			errMsg:  "discovery is required",
+			errMsg:  "must have either 'discovery' or 'manifestFrom' set",
 		},
 		{
 			name: "valid - manifest.ref with discovery missing namespace (all namespaces)",
@@ -1350,6 +1619,207 @@ values:
 	assert.Contains(t, err.Error(), "condition has both 'value' and 'values' keys")
 }
 
+// TestConditionGroupsUnmarshal verifies that anyOf, allOf and not groups are unmarshaled recursively
+func TestConditionGroupsUnmarshal(t *testing.T) {
+	yamlContent := `
+anyOf:
+  - field: provider
+    operator: equals
+    value: aws
+  - allOf:
+      - field: provider
+        operator: in
+        values: [gcp, azure]
+      - not:
+          field: region
+          operator: startsWith
+          value: us-gov-
+`
+	var cond Condition
+	require.NoError(t, yaml.Unmarshal([]byte(yamlContent), &cond))
+
+	assert.True(t, cond.IsGroup())
+	require.Len(t, cond.AnyOf, 2)
+	assert.Equal(t, "aws", cond.AnyOf[0].Value)
+	require.Len(t, cond.AnyOf[1].AllOf, 2)
+	assert.Equal(t, []interface{}{"gcp", "azure"}, cond.AnyOf[1].AllOf[0].Value)
+	require.NotNil(t, cond.AnyOf[1].AllOf[1].Not)
+	assert.Equal(t, "region", cond.AnyOf[1].AllOf[1].Not.Field)
+	assert.Equal(t, "us-gov-", cond.AnyOf[1].AllOf[1].Not.Value)
+}
+
+// =============================================================================
+// Manifest Expression Tests
+// =============================================================================
+
+func TestResourceManifestExpressionTag(t *testing.T) {
+	yamlContent := `
+name: deployment
+manifest:
+  apiVersion: apps/v1
+  kind: Deployment
+  metadata:
+    name: "app-{{ .clusterId }}"
+  spec:
+    replicas: !expr "nodeCount"
+    paused: !expr 'mode == "maintenance"'
+    template:
+      spec:
+        containers:
+          - name: app
+            args: !expr "args"
+discovery:
+  byName: "app-{{ .clusterId }}"
+`
+	var resource Resource
+	require.NoError(t, yaml.Unmarshal([]byte(yamlContent), &resource))
+
+	manifest, ok := resource.Manifest.(map[string]interface{})
+	require.True(t, ok)
+	spec := manifest["spec"].(map[string]interface{})
+
+	expr, ok := ParseManifestExpression(spec["replicas"])
+	require.True(t, ok, "replicas should be an expression marker")
+	assert.Equal(t, "nodeCount", expr)
+
+	expr, ok = ParseManifestExpression(spec["paused"])
+	require.True(t, ok)
+	assert.Equal(t, `mode == "maintenance"`, expr)
+
+	containers := spec["template"].(map[string]interface{})["spec"].(map[string]interface{})["containers"].([]interface{})
+	expr, ok = ParseManifestExpression(containers[0].(map[string]interface{})["args"])
+	require.True(t, ok)
+	assert.Equal(t, "args", expr)
+
+	// Untagged values are unchanged
+	_, ok = ParseManifestExpression(manifest["metadata"])
+	assert.False(t, ok)
+	assert.Equal(t, "app-{{ .clusterId }}", resource.Discovery.ByName)
+}
+
+func TestResourcePatches(t *testing.T) {
+	yamlContent := `
+name: deployment
+manifest:
+  apiVersion: apps/v1
+  kind: Deployment
+  metadata:
+    name: app
+patches:
+  - name: "aws"
+    type: "strategic"
+    when: 'platform == "aws"'
+    target:
+      kind: "Deployment"
+      name: "app"
+    patch:
+      spec:
+        replicas: !expr "nodeCount"
+  - type: "json"
+    patch:
+      - op: "add"
+        path: "/metadata/labels"
+        value:
+          team: core
+discovery:
+  byName: app
+`
+	var resource Resource
+	require.NoError(t, yaml.Unmarshal([]byte(yamlContent), &resource))
+	require.Len(t, resource.Patches, 2)
+
+	patch := resource.Patches[0]
+	assert.Equal(t, "aws", patch.Name)
+	assert.Equal(t, "strategic", patch.Type)
+	assert.Equal(t, `platform == "aws"`, patch.When)
+	require.NotNil(t, patch.Target)
+	assert.Equal(t, "Deployment", patch.Target.Kind)
+	assert.Equal(t, "app", patch.Target.Name)
+	expr, ok := ParseManifestExpression(patch.Patch.(map[string]interface{})["spec"].(map[string]interface{})["replicas"])
+	require.True(t, ok, "!expr tags in patches should be preserved")
+	assert.Equal(t, "nodeCount", expr)
+
+	ops, ok := resource.Patches[1].Patch.([]interface{})
+	require.True(t, ok)
+	assert.Equal(t, "add", ops[0].(map[string]interface{})["op"])
+}
+
+func TestResourceManifestExpressionTagOnNonScalar(t *testing.T) {
+	yamlContent := `
+name: deployment
+manifest:
+  spec:
+    replicas: !expr
+      - nodeCount
+`
+	var resource Resource
+	err := yaml.Unmarshal([]byte(yamlContent), &resource)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "!expr tag must be applied to a string expression")
+}
+
+func TestLoadConfigWithManifestRefExpressions(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "deployment.yaml"), []byte(`
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: "app-{{ .clusterId }}"
+spec:
+  replicas: !expr "nodeCount"
+`), 0644))
+
+	adapterYAML := `
+apiVersion: hyperfleet.redhat.com/v1alpha1
+kind: AdapterConfig
+metadata:
+  name: test-adapter
+spec:
+  adapter:
+    version: "0.1.0"
+  clients:
+    hyperfleetApi:
+      baseUrl: "https://test.example.com"
+      timeout: 2s
+    kubernetes:
+      apiVersion: "v1"
+`
+
+	taskYAML := `
+apiVersion: hyperfleet.redhat.com/v1alpha1
+kind: AdapterTaskConfig
+metadata:
+  name: test-adapter
+spec:
+  params:
+    - name: "clusterId"
+      source: "event.id"
+    - name: "nodeCount"
+      source: "event.spec.nodes"
+      type: "int"
+  resources:
+    - name: "deployment"
+      manifest:
+        ref: "deployment.yaml"
+      discovery:
+        byName: "app-{{ .clusterId }}"
+`
+
+	adapterPath, taskPath := createTestConfigFiles(t, tmpDir, adapterYAML, taskYAML)
+
+	config, err := LoadConfig(
+		WithAdapterConfigPath(adapterPath),
+		WithTaskConfigPath(taskPath),
+	)
+	require.NoError(t, err)
+
+	manifest := config.Spec.Resources[0].Manifest.(map[string]interface{})
+	expr, ok := ParseManifestExpression(manifest["spec"].(map[string]interface{})["replicas"])
+	require.True(t, ok, "!expr tag in referenced manifest should be preserved")
+	assert.Equal(t, "nodeCount", expr)
+}
+
 // =============================================================================
 // Transport Config Tests
 // =============================================================================
@@ -1525,6 +1995,21 @@ func TestGetTransportClient(t *testing.T) {
 	}
 }
 
+func TestUsesTransportClient(t *testing.T) {
+	config := &Config{Spec: ConfigSpec{Resources: []Resource{
+		{Name: "namespace"},
+		{Name: "work", Transport: &TransportConfig{Client: TransportClientMaestro}},
+	}}}
+	assert.True(t, config.UsesTransportClient(TransportClientKubernetes))
+	assert.True(t, config.UsesTransportClient(TransportClientMaestro))
+
+	config.Spec.Resources = config.Spec.Resources[1:]
+	assert.False(t, config.UsesTransportClient(TransportClientKubernetes))
+
+	var nilConfig *Config
+	assert.False(t, nilConfig.UsesTransportClient(TransportClientKubernetes))
+}
+
 func TestIsMaestroTransport(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -1751,3 +2236,366 @@ spec:
 	assert.Equal(t, "work.open-cluster-management.io/v1", mw["apiVersion"])
 	assert.Equal(t, "ManifestWork", mw["kind"])
 }
+
+// writeConfigMapVolume lays out files like a mounted ConfigMap volume: the files live in a
+// timestamped directory reached through the ..data symlink, and each key is a symlink to ..data/<key>
+func writeConfigMapVolume(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	dataDir := filepath.Join(dir, "..2026_10_18_12_00_00.000000001")
+	require.NoError(t, os.MkdirAll(dataDir, 0755))
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dataDir, name), []byte(content), 0644))
+	}
+	require.NoError(t, os.Symlink(filepath.Base(dataDir), filepath.Join(dir, "..data")))
+	for name := range files {
+		require.NoError(t, os.Symlink(filepath.Join("..data", name), filepath.Join(dir, name)))
+	}
+}
+
+func TestLoadConfigWithManifestFrom(t *testing.T) {
+	adapterYAML := `
+apiVersion: hyperfleet.redhat.com/v1alpha1
+kind: AdapterConfig
+metadata:
+  name: test-adapter
+spec:
+  adapter:
+    version: "0.1.0"
+  clients:
+    hyperfleetApi:
+      baseUrl: "https://test.example.com"
+      timeout: 2s
+    kubernetes:
+      apiVersion: "v1"
+`
+	taskYAML := func(manifestFrom string) string {
+		return `
+apiVersion: hyperfleet.redhat.com/v1alpha1
+kind: AdapterTaskConfig
+metadata:
+  name: test-adapter
+spec:
+  params:
+    - name: "clusterId"
+      source: "event.id"
+  resources:
+    - name: "addon"
+      manifestFrom:
+` + manifestFrom
+	}
+
+	t.Run("directory of manifests", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		manifestsDir := filepath.Join(tmpDir, "manifests")
+		require.NoError(t, os.MkdirAll(manifestsDir, 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(manifestsDir, "b-sa.yaml"), []byte(`
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: "sa-{{ .clusterId }}"
+`), 0644))
+		require.NoError(t, os.WriteFile(filepath.Join(manifestsDir, "a-cm.json"),
+			[]byte(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"cm"}}`), 0644))
+		require.NoError(t, os.WriteFile(filepath.Join(manifestsDir, "README.md"), []byte("ignored"), 0644))
+
+		adapterPath, taskPath := createTestConfigFiles(t, tmpDir, adapterYAML, taskYAML(`        path: "manifests"
+`))
+		config, err := LoadConfig(WithAdapterConfigPath(adapterPath), WithTaskConfigPath(taskPath))
+		require.NoError(t, err)
+
+		manifests := config.Spec.Resources[0].ManifestFrom.Manifests
+		require.Len(t, manifests, 2)
+		assert.Equal(t, "ConfigMap", manifests[0]["kind"], "files are loaded in name order")
+		assert.Equal(t, "ServiceAccount", manifests[1]["kind"])
+	})
+
+	t.Run("ConfigMap-mounted directory of manifests", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		writeConfigMapVolume(t, filepath.Join(tmpDir, "manifests"), map[string]string{
+			"cm.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: cm\n",
+		})
+
+		adapterPath, taskPath := createTestConfigFiles(t, tmpDir, adapterYAML, taskYAML(`        path: "manifests"
+`))
+		config, err := LoadConfig(WithAdapterConfigPath(adapterPath), WithTaskConfigPath(taskPath))
+		require.NoError(t, err)
+
+		manifests := config.Spec.Resources[0].ManifestFrom.Manifests
+		require.Len(t, manifests, 1, "symlinked files are loaded, ..data is skipped")
+		assert.Equal(t, "ConfigMap", manifests[0]["kind"])
+	})
+
+	t.Run("directory without manifests", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, "manifests"), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "manifests", "README.md"), []byte("ignored"), 0644))
+
+		adapterPath, taskPath := createTestConfigFiles(t, tmpDir, adapterYAML, taskYAML(`        path: "manifests"
+`))
+		_, err := LoadConfig(WithAdapterConfigPath(adapterPath), WithTaskConfigPath(taskPath))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "contains no manifests")
+	})
+
+	t.Run("multi-document file with expressions", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "addon.yaml"), []byte(`
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm
+data:
+  count: !expr "string(1 + 1)"
+---
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: secret
+`), 0644))
+
+		adapterPath, taskPath := createTestConfigFiles(t, tmpDir, adapterYAML, taskYAML(`        path: "addon.yaml"
+`))
+		config, err := LoadConfig(WithAdapterConfigPath(adapterPath), WithTaskConfigPath(taskPath))
+		require.NoError(t, err)
+
+		manifests := config.Spec.Resources[0].ManifestFrom.Manifests
+		require.Len(t, manifests, 2, "empty documents are skipped")
+		expr, ok := ParseManifestExpression(manifests[0]["data"].(map[string]interface{})["count"])
+		require.True(t, ok)
+		assert.Equal(t, "string(1 + 1)", expr)
+		assert.Equal(t, "Secret", manifests[1]["kind"])
+	})
+
+	t.Run("local chart", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		chartDir := filepath.Join(tmpDir, "charts", "addon")
+		require.NoError(t, os.MkdirAll(filepath.Join(chartDir, "templates"), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(chartDir, "Chart.yaml"), []byte("name: addon\nversion: 0.1.0\n"), 0644))
+		require.NoError(t, os.WriteFile(filepath.Join(chartDir, "templates", "cm.yaml"),
+			[]byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: {{ .Release.Name }}\n"), 0644))
+
+		adapterPath, taskPath := createTestConfigFiles(t, tmpDir, adapterYAML, taskYAML(`        chart:
+          path: "charts/addon"
+          releaseName: "addon-{{ .clusterId }}"
+          values:
+            clusterId: "{{ .clusterId }}"
+`))
+		config, err := LoadConfig(WithAdapterConfigPath(adapterPath), WithTaskConfigPath(taskPath))
+		require.NoError(t, err)
+
+		from := config.Spec.Resources[0].ManifestFrom
+		require.NotNil(t, from.ChartContent)
+		assert.Equal(t, "addon", from.ChartContent.Name)
+		assert.Contains(t, from.ChartContent.Templates, "templates/cm.yaml")
+	})
+
+	t.Run("path escaping the config directory", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		adapterPath, taskPath := createTestConfigFiles(t, tmpDir, adapterYAML, taskYAML(`        path: "../outside"
+`))
+		_, err := LoadConfig(WithAdapterConfigPath(adapterPath), WithTaskConfigPath(taskPath))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "manifestFrom.path")
+	})
+
+	t.Run("path and chart are mutually exclusive", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		adapterPath, taskPath := createTestConfigFiles(t, tmpDir, adapterYAML, taskYAML(`        path: "addon.yaml"
+        chart:
+          path: "charts/addon"
+`))
+		_, err := LoadConfig(WithAdapterConfigPath(adapterPath), WithTaskConfigPath(taskPath))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "mutually exclusive")
+	})
+
+	t.Run("manifest and manifestFrom are mutually exclusive", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "addon.yaml"), []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: cm\n"), 0644))
+		adapterPath, taskPath := createTestConfigFiles(t, tmpDir, adapterYAML, taskYAML(`        path: "addon.yaml"
+      manifest:
+        apiVersion: v1
+        kind: ConfigMap
+        metadata:
+          name: cm
+`))
+		_, err := LoadConfig(WithAdapterConfigPath(adapterPath), WithTaskConfigPath(taskPath))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "mutually exclusive")
+	})
+
+	t.Run("not supported for maestro transport", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "addon.yaml"), []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: cm\n"), 0644))
+		adapterPath, taskPath := createTestConfigFiles(t, tmpDir, adapterYAML, taskYAML(`        path: "addon.yaml"
+      transport:
+        client: "maestro"
+        maestro:
+          targetCluster: "{{ .clusterId }}"
+`))
+		_, err := LoadConfig(WithAdapterConfigPath(adapterPath), WithTaskConfigPath(taskPath))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "manifestFrom is not supported for maestro transport")
+	})
+}
+
+func TestLoadConfigs(t *testing.T) {
+	adapterYAML := `
+apiVersion: hyperfleet.redhat.com/v1alpha1
+kind: AdapterConfig
+metadata:
+  name: test-adapter
+spec:
+  adapter:
+    version: "0.1.0"
+  clients:
+    hyperfleetApi:
+      baseUrl: "https://test.example.com"
+    kubernetes:
+      apiVersion: "v1"
+`
+	taskYAML := func(name, match string) string {
+		return `
+apiVersion: hyperfleet.redhat.com/v1alpha1
+kind: AdapterTaskConfig
+metadata:
+  name: ` + name + `
+spec:
+` + match + `
+  params:
+    - name: "clusterId"
+      source: "event.id"
+`
+	}
+
+	writeFile := func(t *testing.T, path, content string) {
+		t.Helper()
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+
+	t.Run("directory of task configs", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		adapterPath := filepath.Join(tmpDir, "adapter-config.yaml")
+		writeFile(t, adapterPath, adapterYAML)
+		writeFile(t, filepath.Join(tmpDir, "tasks", "b-nodepool.yaml"), taskYAML("nodepool-created", `
+  match:
+    eventTypes: ["nodepool.created"]
+    kinds: ["NodePool"]`))
+		writeFile(t, filepath.Join(tmpDir, "tasks", "a-cluster.yml"), taskYAML("cluster-created", `
+  match:
+    expression: "eventType == 'cluster.created'"`))
+		writeFile(t, filepath.Join(tmpDir, "tasks", "README.md"), "not a task config")
+
+		configs, err := LoadConfigs(
+			WithAdapterConfigPath(adapterPath),
+			WithTaskConfigPath(filepath.Join(tmpDir, "tasks")),
+		)
+		require.NoError(t, err)
+		require.Len(t, configs, 2)
+
+		assert.Equal(t, "test-adapter", configs[0].Metadata.Name)
+		assert.Equal(t, "cluster-created", configs[0].GetTaskName())
+		require.NotNil(t, configs[0].Spec.Match)
+		assert.Equal(t, "eventType == 'cluster.created'", configs[0].Spec.Match.Expression)
+
+		assert.Equal(t, "nodepool-created", configs[1].GetTaskName())
+		require.NotNil(t, configs[1].Spec.Match)
+		assert.Equal(t, []string{"nodepool.created"}, configs[1].Spec.Match.EventTypes)
+		assert.Equal(t, []string{"NodePool"}, configs[1].Spec.Match.Kinds)
+
+		_, err = LoadConfig(
+			WithAdapterConfigPath(adapterPath),
+			WithTaskConfigPath(filepath.Join(tmpDir, "tasks")),
+		)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "expected a single task config, found 2")
+	})
+
+	t.Run("ConfigMap-mounted directory of task configs", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		adapterPath := filepath.Join(tmpDir, "adapter-config.yaml")
+		writeFile(t, adapterPath, adapterYAML)
+		writeConfigMapVolume(t, filepath.Join(tmpDir, "tasks"), map[string]string{
+			"cluster.yaml":  taskYAML("cluster-created", ""),
+			"nodepool.yaml": taskYAML("nodepool-created", ""),
+		})
+
+		configs, err := LoadConfigs(
+			WithAdapterConfigPath(adapterPath),
+			WithTaskConfigPath(filepath.Join(tmpDir, "tasks")),
+		)
+		require.NoError(t, err)
+		require.Len(t, configs, 2, "symlinked keys are loaded, ..data is skipped")
+		assert.Equal(t, "cluster-created", configs[0].GetTaskName())
+		assert.Equal(t, "nodepool-created", configs[1].GetTaskName())
+	})
+
+	t.Run("comma-separated list", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		adapterPath := filepath.Join(tmpDir, "adapter-config.yaml")
+		writeFile(t, adapterPath, adapterYAML)
+		first := filepath.Join(tmpDir, "first.yaml")
+		second := filepath.Join(tmpDir, "second.yaml")
+		writeFile(t, first, taskYAML("first", ""))
+		writeFile(t, second, taskYAML("second", ""))
+
+		configs, err := LoadConfigs(
+			WithAdapterConfigPath(adapterPath),
+			WithTaskConfigPath(second+", "+first),
+		)
+		require.NoError(t, err)
+		require.Len(t, configs, 2)
+		assert.Equal(t, "second", configs[0].GetTaskName())
+		assert.Equal(t, "first", configs[1].GetTaskName())
+		assert.Nil(t, configs[0].Spec.Match)
+	})
+
+	t.Run("duplicate task names", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		adapterPath := filepath.Join(tmpDir, "adapter-config.yaml")
+		writeFile(t, adapterPath, adapterYAML)
+		writeFile(t, filepath.Join(tmpDir, "tasks", "a.yaml"), taskYAML("same", ""))
+		writeFile(t, filepath.Join(tmpDir, "tasks", "b.yaml"), taskYAML("same", ""))
+
+		_, err := LoadConfigs(
+			WithAdapterConfigPath(adapterPath),
+			WithTaskConfigPath(filepath.Join(tmpDir, "tasks")),
+		)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `metadata.name "same" is already used`)
+	})
+
+	t.Run("errors name the failing task config", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		adapterPath := filepath.Join(tmpDir, "adapter-config.yaml")
+		writeFile(t, adapterPath, adapterYAML)
+		writeFile(t, filepath.Join(tmpDir, "tasks", "a.yaml"), taskYAML("good", ""))
+		writeFile(t, filepath.Join(tmpDir, "tasks", "b.yaml"), taskYAML("bad", `
+  match:
+    expression: "eventType =="`))
+
+		_, err := LoadConfigs(
+			WithAdapterConfigPath(adapterPath),
+			WithTaskConfigPath(filepath.Join(tmpDir, "tasks")),
+		)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "b.yaml")
+		assert.Contains(t, err.Error(), "spec.match.expression")
+	})
+
+	t.Run("empty directory", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		adapterPath := filepath.Join(tmpDir, "adapter-config.yaml")
+		writeFile(t, adapterPath, adapterYAML)
+		require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, "tasks"), 0755))
+
+		_, err := LoadConfigs(
+			WithAdapterConfigPath(adapterPath),
+			WithTaskConfigPath(filepath.Join(tmpDir, "tasks")),
+		)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "contains no YAML files")
+	})
+}
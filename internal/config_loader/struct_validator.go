@@ -54,6 +54,10 @@ func buildFieldNameCache(t reflect.Type, visited map[reflect.Type]bool) {
 
 		for i := 0; i < t.NumField(); i++ {
 			field := t.Field(i)
+			// Fields excluded from yaml (e.g. loader state) are not part of the config
+			if field.Tag.Get("yaml") == "-" {
+				continue
+			}
 			fieldNameCache[field.Name] = extractYamlTagName(field)
 			buildFieldNameCache(field.Type, visited)
 		}
@@ -73,6 +77,7 @@ func getStructValidator() *validator.Validate {
 
 		// Register custom struct-level validations
 		structValidator.RegisterStructValidation(validateParameterEnvRequired, Parameter{})
+		structValidator.RegisterStructValidation(validateConditionShape, Condition{})
 
 		// Use yaml tag names for field names in errors
 		structValidator.RegisterTagNameFunc(extractYamlTagName)
@@ -115,6 +120,30 @@ func validateParameterEnvRequired(sl validator.StructLevel) {
 	}
 }
 
+// validateConditionShape is a struct-level validator for Condition.
+// Checks that a condition is either a leaf (field and operator) or exactly one of anyOf, allOf or not.
+func validateConditionShape(sl validator.StructLevel) {
+	cond := sl.Current().Interface().(Condition) //nolint:errcheck // type is guaranteed by RegisterStructValidation
+
+	groups := 0
+	if len(cond.AnyOf) > 0 {
+		groups++
+	}
+	if len(cond.AllOf) > 0 {
+		groups++
+	}
+	if cond.Not != nil {
+		groups++
+	}
+
+	switch {
+	case groups == 0 && cond.Operator == "":
+		sl.ReportError(cond.Operator, "operator", "Operator", "required", "")
+	case groups > 1 || (groups == 1 && (cond.Field != "" || cond.Operator != "" || cond.Value != nil)):
+		sl.ReportError(cond.Operator, "operator", "Operator", "conditiongroup", "")
+	}
+}
+
 // ValidateStruct validates a struct using go-playground/validator tags.
 // Returns a ValidationErrors with all validation failures.
 func ValidateStruct(s interface{}) *ValidationErrors {
@@ -179,9 +208,17 @@ func formatFullErrorMessage(e validator.FieldError) string {
 		return fmt.Sprintf("%s: must specify %s", parentPath(path), strings.Join(cleanParams, ", "))
 	case "min":
 		return fmt.Sprintf("%s: must have at least %s element(s)", path, e.Param())
+	case "gte":
+		return fmt.Sprintf("%s: must be greater than or equal to %s", path, e.Param())
+	case "lte":
+		return fmt.Sprintf("%s: must be less than or equal to %s", path, e.Param())
 	case "unique":
 		// e.g., "spec.resources: contains duplicate name values"
 		return fmt.Sprintf("%s: contains duplicate %s values", path, yamlFieldName(e.Param()))
+	case "conditiongroup":
+		// e.g., "spec.preconditions[0].conditions[1]: must specify exactly one of operator, anyOf, allOf or not"
+		return fmt.Sprintf("%s: must specify exactly one of %s, %s, %s or %s",
+			parentPath(path), FieldOperator, FieldAnyOf, FieldAllOf, FieldNot)
 	case "envrequired":
 		// e.g., "spec.params[0]: required environment variable MY_VAR is not set"
 		return fmt.Sprintf("%s: required environment variable %s is not set", parentPath(path), e.Param())
@@ -245,9 +282,10 @@ func formatFieldPath(namespace string) string {
 		if embeddedStructNames[part] {
 			continue
 		}
-		// Convert array-indexed parts to lowercase (e.g., "Preconditions[0]" -> "preconditions[0]")
+		// Lowercase the first letter of array-indexed parts, keeping camelCase yaml names
+		// (e.g., "Preconditions[0]" -> "preconditions[0]", "anyOf[1]" -> "anyOf[1]")
 		if idx := strings.Index(part, "["); idx > 0 {
-			part = strings.ToLower(part[:idx]) + part[idx:]
+			part = strings.ToLower(part[:1]) + part[1:]
 		}
 		cleanParts = append(cleanParts, part)
 	}
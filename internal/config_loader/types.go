@@ -3,18 +3,31 @@ package config_loader
 import (
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/criteria"
 	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/hyperfleet_api"
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/manifest"
 	"gopkg.in/yaml.v3"
 )
 
 // Config is the unified configuration passed throughout the application.
 // Created by merging AdapterConfig (deployment) and AdapterTaskConfig (task).
 type Config struct {
-	APIVersion string     `yaml:"apiVersion"`
-	Kind       string     `yaml:"kind"`
-	Metadata   Metadata   `yaml:"metadata"`
-	Spec       ConfigSpec `yaml:"spec"`
+	APIVersion string   `yaml:"apiVersion"`
+	Kind       string   `yaml:"kind"`
+	Metadata   Metadata `yaml:"metadata"`
+	// TaskMetadata is the metadata of the task config this config was merged from
+	TaskMetadata Metadata   `yaml:"taskMetadata"`
+	Spec         ConfigSpec `yaml:"spec"`
+	// SourceFiles lists the task config file and the files and directories it references
+	// (populated by loader, used to watch for changes)
+	SourceFiles []string `yaml:"-"`
+	// Warnings are the non-fatal findings of the task config validation (populated by loader)
+	Warnings []string `yaml:"-"`
+	// Programs holds the compiled CEL programs of the config set this config was loaded with,
+	// shared by the configs of the set (populated by loader)
+	Programs *criteria.ProgramCache `yaml:"-"`
 }
 
 // ConfigSpec contains the merged specification from both deployment and task configs
@@ -23,12 +36,16 @@ type ConfigSpec struct {
 	Adapter     AdapterInfo   `yaml:"adapter"`
 	Clients     ClientsConfig `yaml:"clients"`
 	DebugConfig bool          `yaml:"debugConfig,omitempty"`
+	Resync      *ResyncConfig `yaml:"resync,omitempty"`
+	CEL         *CELConfig    `yaml:"cel,omitempty"`
 
 	// From AdapterTaskConfig (business logic)
+	Match         *TaskMatch     `yaml:"match,omitempty"`
 	Params        []Parameter    `yaml:"params,omitempty"`
 	Preconditions []Precondition `yaml:"preconditions,omitempty"`
 	Resources     []Resource     `yaml:"resources,omitempty"`
 	Post          *PostConfig    `yaml:"post,omitempty"`
+	Templates     TemplateConfig `yaml:"templates,omitempty"`
 }
 
 // GetParams returns the parameters from the config spec
@@ -47,6 +64,14 @@ func (c *Config) GetMetadata() Metadata {
 	return c.Metadata
 }
 
+// GetTaskName returns the name of the task config, used to label logs and metrics
+func (c *Config) GetTaskName() string {
+	if c == nil {
+		return ""
+	}
+	return c.TaskMetadata.Name
+}
+
 // Merge combines AdapterConfig (deployment) and AdapterTaskConfig (task) into a unified Config.
 // The metadata is taken from the adapter config since it takes precedence.
 // The adapter info and clients come from the deployment config.
@@ -57,19 +82,24 @@ func Merge(adapterCfg *AdapterConfig, taskCfg *AdapterTaskConfig) *Config {
 	}
 
 	return &Config{
-		APIVersion: adapterCfg.APIVersion,
-		Kind:       ExpectedKindConfig,
-		Metadata:   adapterCfg.Metadata, // Adapter config takes precedence
+		APIVersion:   adapterCfg.APIVersion,
+		Kind:         ExpectedKindConfig,
+		Metadata:     adapterCfg.Metadata, // Adapter config takes precedence
+		TaskMetadata: taskCfg.Metadata,
 		Spec: ConfigSpec{
 			// From deployment config
 			Adapter:     adapterCfg.Spec.Adapter,
 			Clients:     adapterCfg.Spec.Clients,
 			DebugConfig: adapterCfg.Spec.DebugConfig,
+			Resync:      adapterCfg.Spec.Resync,
+			CEL:         adapterCfg.Spec.CEL,
 			// From task config
+			Match:         taskCfg.Spec.Match,
 			Params:        taskCfg.Spec.Params,
 			Preconditions: taskCfg.Spec.Preconditions,
 			Resources:     taskCfg.Spec.Resources,
 			Post:          taskCfg.Spec.Post,
+			Templates:     taskCfg.Spec.Templates,
 		},
 	}
 }
@@ -162,17 +192,45 @@ type KubernetesConfig struct {
 	QPS float32 `yaml:"qps,omitempty" mapstructure:"qps"`
 	// Burst is the client-side burst rate. Zero uses defaults.
 	Burst int `yaml:"burst,omitempty" mapstructure:"burst"`
+	// ClusterKubeconfig locates the kubeconfig Secrets of the clusters targeted by
+	// resources with transport.kubernetes.cluster. Nil disables remote cluster targets.
+	ClusterKubeconfig *ClusterKubeconfigConfig `yaml:"clusterKubeconfig,omitempty" mapstructure:"clusterKubeconfig" validate:"omitempty"`
+}
+
+// ClusterKubeconfigConfig locates the kubeconfig Secret of a target cluster, e.g. a CAPI
+// "<cluster>-kubeconfig" or a HyperShift "admin-kubeconfig" Secret.
+// SecretNamespace and SecretName are Go templates over {{ .cluster }}, the rendered
+// transport.kubernetes.cluster value. Clients built from the Secrets are cached.
+type ClusterKubeconfigConfig struct {
+	// SecretNamespace is the namespace of the kubeconfig Secret
+	SecretNamespace string `yaml:"secretNamespace" mapstructure:"secretNamespace" validate:"required"`
+	// SecretName is the name of the kubeconfig Secret (default "{{ .cluster }}-kubeconfig")
+	SecretName string `yaml:"secretName,omitempty" mapstructure:"secretName"`
+	// SecretKey is the Secret data key holding the kubeconfig (default "value")
+	SecretKey string `yaml:"secretKey,omitempty" mapstructure:"secretKey"`
+	// CacheSize is the maximum number of cached cluster clients (default 50)
+	CacheSize int `yaml:"cacheSize,omitempty" mapstructure:"cacheSize" validate:"gte=0"`
+	// IdleTimeout evicts cluster clients unused for this long (default 30m)
+	IdleTimeout time.Duration `yaml:"idleTimeout,omitempty" mapstructure:"idleTimeout"`
 }
 
 // Parameter represents a parameter extraction configuration.
-// Parameters are extracted from external sources (event data, env vars) using Source.
+// Parameters are extracted from external sources (event data, env vars) using Source,
+// or derived with a CEL Expression evaluated after all source params are extracted.
+// Expressions can reference earlier params, "event" (raw event data) and environment variables as "env.NAME";
+// only the variables read this way are exposed.
+// Only one of Source or Expression should be set.
 type Parameter struct {
 	Name        string      `yaml:"name" validate:"required"`
-	Source      string      `yaml:"source,omitempty" validate:"required"`
+	Source      string      `yaml:"source,omitempty" validate:"required_without=Expression,excluded_with=Expression"`
+	Expression  string      `yaml:"expression,omitempty" validate:"required_without=Source,excluded_with=Source"`
 	Type        string      `yaml:"type,omitempty"`
 	Description string      `yaml:"description,omitempty"`
 	Required    bool        `yaml:"required,omitempty"`
 	Default     interface{} `yaml:"default,omitempty"`
+	// Schema is an optional JSON Schema fragment the value must match after type conversion.
+	// On mismatch an optional param with a Default falls back to it; otherwise extraction fails.
+	Schema map[string]interface{} `yaml:"schema,omitempty"`
 }
 
 // Payload represents a dynamically built payload for post-processing.
@@ -221,9 +279,62 @@ type ActionBase struct {
 // Must have at least one of: APICall (from ActionBase), Expression, or Conditions.
 type Precondition struct {
 	ActionBase `yaml:",inline"`
-	Capture    []CaptureField `yaml:"capture,omitempty" validate:"dive"`
-	Conditions []Condition    `yaml:"conditions,omitempty" validate:"dive,required_without_all=ActionBase.APICall Expression"`
-	Expression string         `yaml:"expression,omitempty" validate:"required_without_all=ActionBase.APICall Conditions"`
+	// ResourceLookup reads an existing object instead of calling the API (mutually exclusive with APICall)
+	ResourceLookup *ResourceLookup `yaml:"resourceLookup,omitempty" validate:"omitempty,excluded_with=ActionBase.APICall"`
+	Capture        []CaptureField  `yaml:"capture,omitempty" validate:"dive"`
+	Conditions     []Condition     `yaml:"conditions,omitempty" validate:"dive,required_without_all=ActionBase.APICall ResourceLookup Expression"`
+	Expression     string          `yaml:"expression,omitempty" validate:"required_without_all=ActionBase.APICall ResourceLookup Conditions"`
+	// Retry re-evaluates the precondition while it is not met
+	Retry *PreconditionRetry `yaml:"retry,omitempty" validate:"omitempty"`
+	// ParallelGroup runs consecutive preconditions sharing the same group concurrently.
+	// Members of a group cannot read the response or captures of one another.
+	ParallelGroup string `yaml:"parallelGroup,omitempty"`
+}
+
+// ResourceLookup reads an existing object through a transport client. The object is exposed
+// under the precondition name for captures and conditions, or null when it does not exist.
+//
+// Example YAML:
+//
+//	resourceLookup:
+//	  apiVersion: "v1"
+//	  kind: "Secret"
+//	  discovery:
+//	    namespace: "openshift-config"
+//	    byName: "pull-secret"
+type ResourceLookup struct {
+	APIVersion string `yaml:"apiVersion" validate:"required"`
+	Kind       string `yaml:"kind" validate:"required"`
+	// Transport selects the transport client, as for resources (default: kubernetes).
+	// With maestro, a ManifestWork kind reads the ManifestWork itself, other kinds are
+	// searched within the workloads of the consumer's ManifestWorks.
+	Transport *TransportConfig `yaml:"transport,omitempty" validate:"omitempty"`
+	Discovery *DiscoveryConfig `yaml:"discovery" validate:"required"`
+}
+
+// PreconditionRetry re-runs the API call, captures and conditions of a precondition that is not
+// met, until it is met, the attempts run out or the retries exceed their total time budget.
+// Errors are not retried.
+//
+// Example YAML:
+//
+//	retry:
+//	  attempts: 5
+//	  interval: "10s"
+//	  backoff: "exponential"
+//	  maxElapsed: "3m"
+type PreconditionRetry struct {
+	// Attempts is the maximum number of evaluations, the first one included
+	Attempts int `yaml:"attempts" validate:"required,gte=1,lte=100"`
+	// Interval is the delay before the second evaluation (Go duration, default 5s)
+	Interval string `yaml:"interval,omitempty"`
+	// MaxInterval caps the delay between evaluations (Go duration, default 5m or the interval if longer)
+	MaxInterval string `yaml:"maxInterval,omitempty"`
+	// Backoff grows the delay between evaluations: "constant" (default), "linear" or "exponential"
+	Backoff string `yaml:"backoff,omitempty" validate:"omitempty,oneof=constant linear exponential"`
+	// MaxElapsed bounds the time from the first evaluation to the last one (Go duration, default 2m).
+	// The delays between all attempts must fit in it.
+	MaxElapsed string `yaml:"maxElapsed,omitempty"`
 }
 
 // APICall represents an API call configuration
@@ -248,16 +359,57 @@ type Header struct {
 // Supports two modes (mutually exclusive):
 //   - Field: JSONPath expression for simple field extraction (e.g., "{.items[0].name}")
 //   - Expression: CEL expression for complex transformations (e.g., "response.items.filter(i, i.adapter == 'x')")
+//
+// The values a capture extracts are the matches of Field, or the elements of a list returned
+// by Expression (any other result is a single value).
 type CaptureField struct {
 	Name               string `yaml:"name" validate:"required"`
 	FieldExpressionDef `yaml:",inline"`
-}
-
-// Condition represents a structured condition
+	// Mode selects the captured value: "first" value, "all" values as a list, or their "count".
+	// Default: nothing is null, a single value is captured as is, several values as a list.
+	Mode string `yaml:"mode,omitempty" validate:"omitempty,oneof=first all count"`
+	// Type converts the captured value (same types as params, e.g. int, bool, list, map)
+	Type string `yaml:"type,omitempty"`
+	// Required fails the precondition when the capture extracts no value or its conversion fails.
+	// Otherwise the capture falls back to Default with a warning, or is left unset without one.
+	Required bool `yaml:"required,omitempty"`
+	// Default is the value of an optional capture that extracts no value or fails its conversion,
+	// converted to Type
+	Default interface{} `yaml:"default,omitempty"`
+}
+
+// Condition represents a structured condition: either a comparison of Field with Value using
+// Operator, or a group of nested conditions (exactly one of AnyOf, AllOf or Not).
+//
+// Example YAML:
+//
+//	conditions:
+//	  - field: "status.phase"
+//	    operator: "equals"
+//	    value: "Ready"
+//	  - anyOf:
+//	      - field: "provider"
+//	        operator: "equals"
+//	        value: "aws"
+//	      - not:
+//	          field: "region"
+//	          operator: "startsWith"
+//	          value: "us-gov-"
 type Condition struct {
-	Field    string      `yaml:"field"`
-	Operator string      `yaml:"operator" validate:"required,validoperator"`
+	Field    string      `yaml:"field,omitempty"`
+	Operator string      `yaml:"operator,omitempty" validate:"omitempty,validoperator"`
 	Value    interface{} `yaml:"-"` // Populated by UnmarshalYAML from "value" or "values"
+	// AnyOf matches when at least one nested condition matches
+	AnyOf []Condition `yaml:"anyOf,omitempty" validate:"omitempty,dive"`
+	// AllOf matches when every nested condition matches
+	AllOf []Condition `yaml:"allOf,omitempty" validate:"omitempty,dive"`
+	// Not matches when the nested condition does not match
+	Not *Condition `yaml:"not,omitempty"`
+}
+
+// IsGroup reports whether the condition is an anyOf, allOf or not group
+func (c *Condition) IsGroup() bool {
+	return len(c.AnyOf) > 0 || len(c.AllOf) > 0 || c.Not != nil
 }
 
 // conditionRaw is used for custom unmarshaling to support both "value" and "values" keys
@@ -266,6 +418,9 @@ type conditionRaw struct {
 	Operator string      `yaml:"operator"`
 	Value    interface{} `yaml:"value"`
 	Values   interface{} `yaml:"values"` // Alias for Value
+	AnyOf    []Condition `yaml:"anyOf"`
+	AllOf    []Condition `yaml:"allOf"`
+	Not      *Condition  `yaml:"not"`
 }
 
 // UnmarshalYAML implements custom unmarshaling to support both "value" and "values" keys
@@ -277,6 +432,9 @@ func (c *Condition) UnmarshalYAML(unmarshal func(interface{}) error) error {
 
 	c.Field = raw.Field
 	c.Operator = raw.Operator
+	c.AnyOf = raw.AnyOf
+	c.AllOf = raw.AllOf
+	c.Not = raw.Not
 
 	// Fail if both "value" and "values" are specified
 	if raw.Value != nil && raw.Values != nil {
@@ -299,12 +457,37 @@ type TransportConfig struct {
 	Client string `yaml:"client" validate:"required,oneof=kubernetes maestro"`
 	// Maestro contains maestro-specific transport settings (required when Client is "maestro")
 	Maestro *MaestroTransportConfig `yaml:"maestro,omitempty"`
+	// Kubernetes contains kubernetes-specific transport settings (only when Client is "kubernetes")
+	Kubernetes *KubernetesTransportConfig `yaml:"kubernetes,omitempty"`
+}
+
+// KubernetesTransportConfig contains kubernetes-specific transport settings
+type KubernetesTransportConfig struct {
+	// Cluster is the target cluster (template), resolved to a kubeconfig Secret using
+	// spec.clients.kubernetes.clusterKubeconfig. Empty targets the adapter's own cluster.
+	Cluster string `yaml:"cluster,omitempty"`
 }
 
 // MaestroTransportConfig contains maestro-specific transport settings
 type MaestroTransportConfig struct {
 	// TargetCluster is the name of the target cluster (consumer) for ManifestWork delivery
 	TargetCluster string `yaml:"targetCluster" validate:"required"`
+	// DeleteOption sets the ManifestWork's spec.deleteOption, i.e. what happens to the workload
+	// on the managed cluster when the ManifestWork is deleted
+	DeleteOption *MaestroDeleteOption `yaml:"deleteOption,omitempty" validate:"omitempty"`
+}
+
+// MaestroDeleteOption configures the deletion propagation of a ManifestWork.
+// With SelectivelyOrphan, the manifests matched by nested discoveries marked orphan are kept
+// on the managed cluster and the others are deleted.
+//
+// Example YAML:
+//
+//	deleteOption:
+//	  propagationPolicy: "SelectivelyOrphan"
+type MaestroDeleteOption struct {
+	// PropagationPolicy is "Foreground" (default of the agent), "Orphan" or "SelectivelyOrphan"
+	PropagationPolicy string `yaml:"propagationPolicy" validate:"required,oneof=Foreground Orphan SelectivelyOrphan"`
 }
 
 // Resource represents a resource configuration.
@@ -314,18 +497,217 @@ type MaestroTransportConfig struct {
 type Resource struct {
 	Name             string           `yaml:"name" validate:"required,resourcename"`
 	Transport        *TransportConfig `yaml:"transport,omitempty"`
-	Manifest         interface{}      `yaml:"manifest,omitempty"`
+	Manifest         interface{}      `yaml:"manifest,omitempty" validate:"excluded_with=ManifestFrom"`
 	RecreateOnChange bool             `yaml:"recreateOnChange,omitempty"`
-	Discovery        *DiscoveryConfig `yaml:"discovery,omitempty" validate:"required"`
+	Discovery        *DiscoveryConfig `yaml:"discovery,omitempty" validate:"required_without=ManifestFrom"`
+	// DriftDetection reapplies the resource when the live object drifted from the rendered manifest,
+	// even if the generation annotation is unchanged (kubernetes transport only)
+	DriftDetection bool `yaml:"driftDetection,omitempty"`
+	// ManifestFrom loads several manifests from a file, a directory or a local Helm chart.
+	// Mutually exclusive with Manifest. Each produced object is applied and discovered on its own.
+	ManifestFrom *ManifestFrom `yaml:"manifestFrom,omitempty" validate:"omitempty"`
 	// NestedDiscoveries defines how to discover individual sub-resources within the applied manifest.
 	// For example, discovering resources inside a ManifestWork's workload.
 	NestedDiscoveries []NestedDiscovery `yaml:"nestedDiscoveries,omitempty" validate:"dive"`
+	// Patches are applied in order to the rendered manifest before it is applied
+	Patches []Patch `yaml:"patches,omitempty" validate:"dive"`
+	// Delete deletes the resource instead of applying it when its condition holds (maestro transport only)
+	Delete *DeleteConfig `yaml:"delete,omitempty" validate:"omitempty"`
+}
+
+// DeleteConfig deletes a resource when a CEL condition holds, e.g. when its cluster is being deleted.
+// The resource is identified by the name and namespace of its rendered manifest.
+//
+// Example YAML:
+//
+//	delete:
+//	  when: 'clusterPhase == "Deleting"'
+//	  wait: true
+//	  timeout: "10m"
+type DeleteConfig struct {
+	// When is the CEL condition deleting the resource
+	When string `yaml:"when" validate:"required"`
+	// Wait blocks until the transport reports the resource removed (for maestro: until the
+	// ManifestWork agent removed the workload from the consumer and the ManifestWork is gone)
+	Wait bool `yaml:"wait,omitempty"`
+	// Timeout bounds the wait (Go duration, default 5m)
+	Timeout string `yaml:"timeout,omitempty"`
+}
+
+// UnmarshalYAML implements custom unmarshaling to preserve !expr tags in the manifest and patches.
+// yaml.v3 drops unknown tags when decoding into interface{}, so tagged values are
+// rewritten into expression markers (see ParseManifestExpression) before decoding.
+func (r *Resource) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if key := node.Content[i].Value; key == FieldManifest || key == FieldManifestFrom || key == FieldPatches {
+				if err := markManifestExpressions(node.Content[i+1]); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	type resourceAlias Resource
+	return node.Decode((*resourceAlias)(r))
+}
+
+// Patch modifies the rendered manifest of a resource before it is applied.
+// Patch documents support Go templates and !expr values like manifests.
+//
+// Example YAML:
+//
+//	patches:
+//	  - name: "aws-storage"
+//	    type: "merge"
+//	    when: 'platform == "aws"'
+//	    target:
+//	      kind: "StorageClass"
+//	    patch:
+//	      provisioner: "ebs.csi.aws.com"
+type Patch struct {
+	// Name identifies the patch in logs and errors
+	Name string `yaml:"name,omitempty"`
+	// Type is "json" (RFC 6902 operations), "strategic" (strategic merge patch) or "merge" (RFC 7386)
+	Type string `yaml:"type" validate:"required,oneof=json strategic merge"`
+	// When is an optional CEL condition; the patch is skipped when it evaluates to false
+	When string `yaml:"when,omitempty"`
+	// Target selects the objects to patch by kind and name, including manifests nested in a
+	// ManifestWork workload. Without a target the patch applies to the rendered manifest itself.
+	Target *PatchTarget `yaml:"target,omitempty"`
+	// Patch is the patch document: a list of operations for "json", an object otherwise
+	Patch interface{} `yaml:"patch" validate:"required"`
+}
+
+// PatchTarget selects objects by kind and name. Empty fields match any value.
+type PatchTarget struct {
+	Kind string `yaml:"kind,omitempty"`
+	// Name supports Go templates
+	Name string `yaml:"name,omitempty"`
+}
+
+// ManifestFrom loads a resource's manifests from files instead of an inline manifest.
+// Exactly one of Path or Chart must be set. Paths are relative to the task config directory
+// and cannot escape it.
+//
+// Example YAML:
+//
+//	manifestFrom:
+//	  chart:
+//	    path: "charts/monitoring"
+//	    releaseName: "monitoring-{{ .clusterId }}"
+//	    values:
+//	      replicas: !expr "nodeCount"
+//	  annotations:
+//	    hyperfleet.io/generation: "{{ .generationId }}"
+type ManifestFrom struct {
+	// Path is a YAML file (may hold several documents) or a directory of YAML/JSON files
+	Path string `yaml:"path,omitempty" validate:"required_without=Chart,excluded_with=Chart"`
+	// Chart renders a local Helm chart directory
+	Chart *ChartSource `yaml:"chart,omitempty" validate:"omitempty"`
+	// Labels are added to every produced object (values support Go templates)
+	Labels map[string]string `yaml:"labels,omitempty"`
+	// Annotations are added to every produced object (values support Go templates).
+	// Use it to set the hyperfleet.io/generation annotation for chart objects.
+	Annotations map[string]string `yaml:"annotations,omitempty"`
+
+	// Manifests holds the manifest templates loaded from Path (populated by loader)
+	Manifests []map[string]interface{} `yaml:"-"`
+	// ChartContent holds the chart loaded from Chart.Path (populated by loader)
+	ChartContent *manifest.Chart `yaml:"-"`
+}
+
+// ChartSource describes a local Helm chart to render
+type ChartSource struct {
+	// Path is the chart directory (containing Chart.yaml)
+	Path string `yaml:"path" validate:"required"`
+	// ReleaseName is exposed as .Release.Name (supports Go templates, defaults to the resource name)
+	ReleaseName string `yaml:"releaseName,omitempty"`
+	// Namespace is exposed as .Release.Namespace (supports Go templates)
+	Namespace string `yaml:"namespace,omitempty"`
+	// Values are merged over the chart's values.yaml. Strings support Go templates
+	// and !expr tags produce native values, like manifests.
+	Values map[string]interface{} `yaml:"values,omitempty"`
+}
+
+// ManifestExpressionTag marks a manifest value as a CEL expression.
+// The expression result replaces the whole value with its native type (int, bool, list, map, ...)
+// instead of being rendered to a string like Go templates.
+//
+// Example YAML:
+//
+//	spec:
+//	  replicas: !expr "nodeCount"
+//	  zones: !expr "zones"
+const ManifestExpressionTag = "!expr"
+
+// ParseManifestExpression returns the CEL expression if v is an expression marker
+// produced from a !expr tagged manifest value.
+func ParseManifestExpression(v any) (string, bool) {
+	m, ok := v.(map[string]any)
+	if !ok || len(m) != 1 {
+		return "", false
+	}
+	expr, ok := m[ManifestExpressionTag].(string)
+	return expr, ok
+}
+
+// markManifestExpressions rewrites !expr tagged scalars into a single-key mapping
+// {"!expr": "<expression>"} so the tag survives decoding into interface{}.
+// Kubernetes field names never start with '!', so the marker cannot collide with real manifest keys.
+func markManifestExpressions(node *yaml.Node) error {
+	if node.Tag == ManifestExpressionTag {
+		if node.Kind != yaml.ScalarNode {
+			return fmt.Errorf("line %d: %s tag must be applied to a string expression", node.Line, ManifestExpressionTag)
+		}
+		key := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: ManifestExpressionTag}
+		value := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: node.Value}
+		*node = yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Line: node.Line, Column: node.Column,
+			Content: []*yaml.Node{key, value}}
+		return nil
+	}
+
+	for _, child := range node.Content {
+		if err := markManifestExpressions(child); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // NestedDiscovery defines a named discovery for a sub-resource within the parent manifest.
 type NestedDiscovery struct {
 	Name      string           `yaml:"name" validate:"required,resourcename"`
 	Discovery *DiscoveryConfig `yaml:"discovery" validate:"required"`
+	// Feedback requests status feedback for the matched manifests (maestro transport only).
+	// It is rendered into the ManifestWork's spec.manifestConfigs.
+	Feedback *FeedbackConfig `yaml:"feedback,omitempty" validate:"omitempty"`
+	// Orphan keeps the matched manifests on the managed cluster when the ManifestWork is deleted.
+	// Requires transport.maestro.deleteOption.propagationPolicy "SelectivelyOrphan".
+	Orphan bool `yaml:"orphan,omitempty"`
+}
+
+// FeedbackConfig configures the status the ManifestWork agent reports back for a nested manifest.
+//
+// Example YAML:
+//
+//	feedback:
+//	  wellKnownStatus: true
+//	  jsonPaths:
+//	    - name: "readyReplicas"
+//	      path: ".status.readyReplicas"
+type FeedbackConfig struct {
+	// JSONPaths are reported as statusFeedback values. Values of simple field paths
+	// (e.g. .status.readyReplicas) are also set at that path on the discovered manifest.
+	JSONPaths []FeedbackJSONPath `yaml:"jsonPaths,omitempty" validate:"dive"`
+	// WellKnownStatus requests the agent's built-in feedback for common kinds (Deployments, Jobs, ...)
+	WellKnownStatus bool `yaml:"wellKnownStatus,omitempty"`
+}
+
+// FeedbackJSONPath is a named JSONPath read from the applied manifest on the managed cluster
+type FeedbackJSONPath struct {
+	Name string `yaml:"name" validate:"required"`
+	Path string `yaml:"path" validate:"required"`
 }
 
 // DiscoveryConfig represents resource discovery configuration
@@ -435,6 +817,71 @@ type AdapterConfigSpec struct {
 	Adapter     AdapterInfo   `yaml:"adapter" mapstructure:"adapter"`
 	Clients     ClientsConfig `yaml:"clients" mapstructure:"clients"`
 	DebugConfig bool          `yaml:"debugConfig,omitempty" mapstructure:"debugConfig"`
+	Resync      *ResyncConfig `yaml:"resync,omitempty" mapstructure:"resync" validate:"omitempty"`
+	CEL         *CELConfig    `yaml:"cel,omitempty" mapstructure:"cel" validate:"omitempty"`
+}
+
+// CELConfig bounds the evaluation of CEL expressions, so an expensive expression over a large
+// API response fails instead of starving the adapter. Zero values use the defaults.
+type CELConfig struct {
+	// CostLimit is the maximum runtime cost of one evaluation (default 10000000)
+	CostLimit uint64 `yaml:"costLimit,omitempty" mapstructure:"costLimit"`
+	// InterruptCheckFrequency is the number of comprehension iterations between two checks
+	// of the evaluation timeout (default 100)
+	InterruptCheckFrequency uint `yaml:"interruptCheckFrequency,omitempty" mapstructure:"interruptCheckFrequency"`
+	// Timeout is the deadline of one evaluation (default 1s)
+	Timeout time.Duration `yaml:"timeout,omitempty" mapstructure:"timeout" validate:"gte=0"`
+}
+
+// Resync source types
+const (
+	ResyncSourceKubernetes = "kubernetes"
+	ResyncSourceAPI        = "api"
+)
+
+// ResyncConfig configures the periodic resync loop.
+// The loop lists the resources owned by the adapter and reprocesses each of them as a
+// synthesized event, so a missed broker event does not leave a resource stale.
+type ResyncConfig struct {
+	Enabled bool `yaml:"enabled" mapstructure:"enabled"`
+	// Interval between two resync passes (default 10m)
+	Interval time.Duration `yaml:"interval,omitempty" mapstructure:"interval"`
+	// Jitter is the maximum fraction of the interval added to each wait (default 0.1)
+	Jitter float64 `yaml:"jitter,omitempty" mapstructure:"jitter" validate:"gte=0,lte=1"`
+	// RateLimit is the maximum number of synthesized events per second (default 1)
+	RateLimit float64 `yaml:"rateLimit,omitempty" mapstructure:"rateLimit" validate:"gte=0"`
+	// Source selects how owned resources are listed: "kubernetes" (default) or "api"
+	Source     string                  `yaml:"source,omitempty" mapstructure:"source" validate:"omitempty,oneof=kubernetes api"`
+	Kubernetes *ResyncKubernetesSource `yaml:"kubernetes,omitempty" mapstructure:"kubernetes" validate:"omitempty"`
+	API        *ResyncAPISource        `yaml:"api,omitempty" mapstructure:"api" validate:"omitempty"`
+}
+
+// ResyncKubernetesSource lists resources labeled hyperfleet.io/managed-by=<adapter name>
+// and builds one event per distinct owner id found in IDLabel.
+type ResyncKubernetesSource struct {
+	// Resources are the kinds to list
+	Resources []ResyncResource `yaml:"resources" mapstructure:"resources" validate:"required,min=1,dive"`
+	// EventKind is the kind of the synthesized events (default "Cluster")
+	EventKind string `yaml:"eventKind,omitempty" mapstructure:"eventKind"`
+	// IDLabel is the label holding the event id (default "hyperfleet.io/cluster-id")
+	IDLabel string `yaml:"idLabel,omitempty" mapstructure:"idLabel"`
+}
+
+// ResyncResource identifies a kind listed by the kubernetes resync source
+type ResyncResource struct {
+	APIVersion string `yaml:"apiVersion" mapstructure:"apiVersion" validate:"required"`
+	Kind       string `yaml:"kind" mapstructure:"kind" validate:"required"`
+	// Namespace restricts the list to a namespace (empty lists all namespaces)
+	Namespace string `yaml:"namespace,omitempty" mapstructure:"namespace"`
+}
+
+// ResyncAPISource lists resources from a HyperFleet API list endpoint.
+// Each item must carry at least "id" and "kind"; "href" and "generation" are used when present.
+type ResyncAPISource struct {
+	// Path is the list endpoint, relative to the API base URL (e.g. "/api/hyperfleet/v1/clusters")
+	Path string `yaml:"path" mapstructure:"path" validate:"required"`
+	// PageSize is the number of items requested per page (default 100)
+	PageSize int `yaml:"pageSize,omitempty" mapstructure:"pageSize" validate:"gte=0"`
 }
 
 // ClientsConfig contains configuration for all external clients
@@ -456,6 +903,24 @@ type MaestroClientConfig struct {
 	RetryAttempts     int               `yaml:"retryAttempts" mapstructure:"retryAttempts"`
 	Keepalive         *KeepaliveConfig  `yaml:"keepalive,omitempty" mapstructure:"keepalive"`
 	Insecure          bool              `yaml:"insecure,omitempty" mapstructure:"insecure"`
+	// StatusWatch watches the status of the adapter's ManifestWorks instead of polling it
+	StatusWatch *MaestroStatusWatchConfig `yaml:"statusWatch,omitempty" mapstructure:"statusWatch" validate:"omitempty"`
+}
+
+// MaestroStatusWatchConfig configures the ManifestWork status watch.
+// The watch keeps the ManifestWorks of the adapter's source in a local cache serving resource
+// discovery, and can reconcile a resource as soon as the status of its ManifestWork changes.
+type MaestroStatusWatchConfig struct {
+	Enabled bool `yaml:"enabled" mapstructure:"enabled"`
+	// ReconcileWhen is a CEL expression over the ManifestWork ("work"). A status change flipping
+	// its result synthesizes an event for the resource owning the ManifestWork (optional)
+	ReconcileWhen string `yaml:"reconcileWhen,omitempty" mapstructure:"reconcileWhen"`
+	// EventKind is the kind of the synthesized events (default "Cluster")
+	EventKind string `yaml:"eventKind,omitempty" mapstructure:"eventKind"`
+	// IDLabel is the ManifestWork label holding the event id (default "hyperfleet.io/cluster-id")
+	IDLabel string `yaml:"idLabel,omitempty" mapstructure:"idLabel"`
+	// RateLimit is the maximum number of synthesized events per second (default 1)
+	RateLimit float64 `yaml:"rateLimit,omitempty" mapstructure:"rateLimit" validate:"gte=0"`
 }
 
 // MaestroAuthConfig contains authentication configuration for Maestro
@@ -489,8 +954,35 @@ type AdapterTaskConfig struct {
 
 // AdapterTaskSpec contains the task specification
 type AdapterTaskSpec struct {
+	Match         *TaskMatch     `yaml:"match,omitempty"`
 	Params        []Parameter    `yaml:"params,omitempty" validate:"dive"`
 	Preconditions []Precondition `yaml:"preconditions,omitempty" validate:"dive"`
 	Resources     []Resource     `yaml:"resources,omitempty" validate:"unique=Name,dive"`
 	Post          *PostConfig    `yaml:"post,omitempty" validate:"omitempty"`
+	Templates     TemplateConfig `yaml:"templates,omitempty"`
+}
+
+// TaskMatch selects the events handled by a task config when the adapter runs several of them.
+// All criteria that are set must match; a task config without match handles every event.
+//
+// Example YAML:
+//
+//	match:
+//	  eventTypes: ["com.redhat.hyperfleet.cluster.created"]
+//	  kinds: ["Cluster"]
+//	  expression: "event.generation == 1"
+type TaskMatch struct {
+	// EventTypes are the accepted CloudEvent types
+	EventTypes []string `yaml:"eventTypes,omitempty"`
+	// Kinds are the accepted event data kinds (case-insensitive)
+	Kinds []string `yaml:"kinds,omitempty"`
+	// Expression is a CEL expression over "event" (the event data) and "eventType" that must return true
+	Expression string `yaml:"expression,omitempty"`
+}
+
+// TemplateConfig controls Go template rendering for the task
+type TemplateConfig struct {
+	// Strict makes references to missing keys fail rendering (default true).
+	// Set to false to render missing keys as empty strings, like Helm without --strict.
+	Strict *bool `yaml:"strict,omitempty"`
 }
@@ -2,15 +2,21 @@ package config_loader
 
 import (
 	"fmt"
+	"math"
 	"os"
 	"reflect"
 	"regexp"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/Masterminds/semver/v3"
 	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/checker"
 
 	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/criteria"
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/manifest"
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/pkg/utils"
 )
 
 // templateVarRegex matches Go template variables like {{ .varName }} or {{ .nested.var }}
@@ -53,6 +59,50 @@ func (v *AdapterConfigValidator) ValidateStructure() error {
 			v.config.APIVersion, strings.Join(SupportedAPIVersions, ", "))
 	}
 
+	// Phase 3: Resync source validation
+	if err := validateResync(v.config.Spec.Resync); err != nil {
+		return err
+	}
+
+	// Phase 4: Maestro status watch validation
+	if maestro := v.config.Spec.Clients.Maestro; maestro != nil {
+		return validateStatusWatch(maestro.StatusWatch)
+	}
+	return nil
+}
+
+// validateStatusWatch checks that the reconcile condition of an enabled status watch compiles
+func validateStatusWatch(statusWatch *MaestroStatusWatchConfig) error {
+	if statusWatch == nil || !statusWatch.Enabled || statusWatch.ReconcileWhen == "" {
+		return nil
+	}
+
+	env, err := cel.NewEnv(criteria.CELLibrary(), cel.Variable("work", cel.DynType))
+	if err != nil {
+		return fmt.Errorf("failed to create CEL environment: %w", err)
+	}
+	if _, issues := env.Compile(statusWatch.ReconcileWhen); issues != nil && issues.Err() != nil {
+		return fmt.Errorf("spec.clients.maestro.statusWatch.reconcileWhen: invalid CEL expression: %w", issues.Err())
+	}
+	return nil
+}
+
+// validateResync checks that an enabled resync loop has the configuration of its source
+func validateResync(resync *ResyncConfig) error {
+	if resync == nil || !resync.Enabled {
+		return nil
+	}
+
+	switch resync.Source {
+	case "", ResyncSourceKubernetes:
+		if resync.Kubernetes == nil {
+			return fmt.Errorf("spec.resync.kubernetes is required when resync source is %q", ResyncSourceKubernetes)
+		}
+	case ResyncSourceAPI:
+		if resync.API == nil {
+			return fmt.Errorf("spec.resync.api is required when resync source is %q", ResyncSourceAPI)
+		}
+	}
 	return nil
 }
 
@@ -61,19 +111,37 @@ type TaskConfigValidator struct {
 	config      *AdapterTaskConfig
 	baseDir     string
 	errors      *ValidationErrors
+	warnings    *ValidationErrors
 	definedVars map[string]bool
-	celEnv      *cel.Env
+	// celEnv declares the variables of precondition, resource and post expressions,
+	// matchEnv those of the match expression and paramEnv those of param expressions
+	celEnv   *cel.Env
+	matchEnv *cel.Env
+	paramEnv *cel.Env
+	// programs receives the programs of the checked expressions (nil: not compiled)
+	programs *criteria.ProgramCache
 }
 
 // NewTaskConfigValidator creates a validator for AdapterTaskConfig
 func NewTaskConfigValidator(config *AdapterTaskConfig, baseDir string) *TaskConfigValidator {
 	return &TaskConfigValidator{
-		config:  config,
-		baseDir: baseDir,
-		errors:  &ValidationErrors{},
+		config:   config,
+		baseDir:  baseDir,
+		errors:   &ValidationErrors{},
+		warnings: &ValidationErrors{},
 	}
 }
 
+// Warnings returns the non-fatal findings of the semantic validation, e.g. CEL expressions
+// whose worst-case cost is unbounded
+func (v *TaskConfigValidator) Warnings() []string {
+	warnings := make([]string, 0, len(v.warnings.Errors))
+	for _, w := range v.warnings.Errors {
+		warnings = append(warnings, w.Error())
+	}
+	return warnings
+}
+
 // ValidateStructure validates the structural requirements of AdapterTaskConfig
 func (v *TaskConfigValidator) ValidateStructure() error {
 	if v.config == nil {
@@ -125,6 +193,24 @@ func (v *TaskConfigValidator) ValidateFileReferences() error {
 		}
 	}
 
+	// Validate manifestFrom paths in spec.resources
+	for i, resource := range v.config.Spec.Resources {
+		if resource.ManifestFrom == nil {
+			continue
+		}
+		basePath := fmt.Sprintf("%s.%s[%d].%s", FieldSpec, FieldResources, i, FieldManifestFrom)
+		if resource.ManifestFrom.Path != "" {
+			if err := v.validatePathExists(resource.ManifestFrom.Path, basePath+"."+FieldPath, false); err != nil {
+				errors = append(errors, err.Error())
+			}
+		}
+		if resource.ManifestFrom.Chart != nil {
+			if err := v.validatePathExists(resource.ManifestFrom.Chart.Path, basePath+"."+FieldChart+"."+FieldPath, true); err != nil {
+				errors = append(errors, err.Error())
+			}
+		}
+	}
+
 	if len(errors) > 0 {
 		return fmt.Errorf("file reference errors:\n  - %s", strings.Join(errors, "\n  - "))
 	}
@@ -156,6 +242,33 @@ func (v *TaskConfigValidator) validateFileExists(refPath, configPath string) err
 	return nil
 }
 
+// validatePathExists checks that a referenced file or directory exists within the base directory.
+// When dirOnly is set the path must be a directory.
+func (v *TaskConfigValidator) validatePathExists(refPath, configPath string, dirOnly bool) error {
+	if refPath == "" {
+		return fmt.Errorf("%s: path is empty", configPath)
+	}
+
+	fullPath, err := resolvePath(v.baseDir, refPath)
+	if err != nil {
+		return fmt.Errorf("%s: %w", configPath, err)
+	}
+
+	info, err := os.Stat(fullPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("%s: referenced path %q does not exist (resolved to %q)", configPath, refPath, fullPath)
+		}
+		return fmt.Errorf("%s: error checking path %q: %w", configPath, refPath, err)
+	}
+
+	if dirOnly && !info.IsDir() {
+		return fmt.Errorf("%s: referenced path %q is not a directory", configPath, refPath)
+	}
+
+	return nil
+}
+
 // ValidateSemantic performs semantic validation on the task config
 func (v *TaskConfigValidator) ValidateSemantic() error {
 	if v.config == nil {
@@ -169,12 +282,17 @@ func (v *TaskConfigValidator) ValidateSemantic() error {
 	}
 
 	// Run all semantic validators
+	v.validateParams()
 	v.validateTransportConfig()
+	v.validateResourceLookups()
 	v.validateConditionValues()
+	v.validatePreconditionRetries()
+	v.validateParallelGroups()
 	v.validateCaptureFieldExpressions()
 	v.validateTemplateVariables()
 	v.validateCELExpressions()
 	v.validateK8sManifests()
+	v.validatePatches()
 
 	if v.errors.HasErrors() {
 		return v.errors
@@ -234,42 +352,87 @@ func (c *AdapterTaskConfig) GetDefinedVariables() map[string]bool {
 	return vars
 }
 
+// initCELEnv creates the CEL environments declaring the variables of each kind of expression,
+// so references to undeclared variables are reported at load time
 func (v *TaskConfigValidator) initCELEnv() error {
-	options := make([]cel.EnvOption, 0, len(v.definedVars)+2)
-	options = append(options, cel.OptionalTypes())
-
-	addedRoots := make(map[string]bool)
-
+	roots := make(map[string]bool)
 	for varName := range v.definedVars {
 		root := varName
 		if idx := strings.Index(varName, "."); idx > 0 {
 			root = varName[:idx]
 		}
+		roots[root] = true
+	}
 
-		if addedRoots[root] {
-			continue
+	paramRoots := []string{VariableEvent, VariableEnv}
+	for _, b := range BuiltinVariables() {
+		if !strings.Contains(b, ".") {
+			paramRoots = append(paramRoots, b)
 		}
-		addedRoots[root] = true
-
-		options = append(options, cel.Variable(root, cel.DynType))
 	}
-
-	if !addedRoots[FieldResources] {
-		options = append(options, cel.Variable(FieldResources, cel.MapType(cel.StringType, cel.DynType)))
+	for _, p := range v.config.Spec.Params {
+		if p.Name != "" {
+			paramRoots = append(paramRoots, p.Name)
+		}
 	}
 
-	if !addedRoots[FieldAdapter] {
-		options = append(options, cel.Variable(FieldAdapter, cel.MapType(cel.StringType, cel.DynType)))
+	taskEnv, err := newDeclaredCELEnv(roots, FieldResources, FieldAdapter)
+	if err != nil {
+		return err
 	}
-
-	env, err := cel.NewEnv(options...)
+	matchEnv, err := newDeclaredCELEnv(nil, VariableEvent, VariableEventType)
+	if err != nil {
+		return err
+	}
+	paramEnv, err := newDeclaredCELEnv(nil, paramRoots...)
 	if err != nil {
 		return err
 	}
-	v.celEnv = env
+	v.celEnv, v.matchEnv, v.paramEnv = taskEnv, matchEnv, paramEnv
 	return nil
 }
 
+// newDeclaredCELEnv creates a CEL environment with the HyperFleet library declaring the given
+// variables, all dynamically typed: their values are runtime data
+func newDeclaredCELEnv(roots map[string]bool, more ...string) (*cel.Env, error) {
+	declared := make(map[string]bool, len(roots)+len(more))
+	for root := range roots {
+		declared[root] = true
+	}
+	for _, root := range more {
+		declared[root] = true
+	}
+	names := make([]string, 0, len(declared))
+	for name := range declared {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	// Same functions as the runtime evaluator
+	options := []cel.EnvOption{criteria.CELLibrary()}
+	for _, name := range names {
+		options = append(options, cel.Variable(name, cel.DynType))
+	}
+	return cel.NewEnv(options...)
+}
+
+func (v *TaskConfigValidator) validateParams() {
+	for i, param := range v.config.Spec.Params {
+		path := fmt.Sprintf("%s.%s[%d]", FieldSpec, FieldParams, i)
+
+		if param.Type != "" && !isSupportedParamType(param.Type) {
+			v.errors.Add(path+"."+FieldType, fmt.Sprintf("unsupported type %q (supported: %s)",
+				param.Type, strings.Join(utils.SupportedTypes, ", ")))
+		}
+
+		if param.Schema != nil {
+			if _, err := utils.ParseJSONSchema(param.Schema); err != nil {
+				v.errors.Add(path+"."+FieldSchema, err.Error())
+			}
+		}
+	}
+}
+
 func (v *TaskConfigValidator) validateTransportConfig() {
 	for i, resource := range v.config.Spec.Resources {
 		basePath := fmt.Sprintf("%s.%s[%d]", FieldSpec, FieldResources, i)
@@ -286,6 +449,16 @@ func (v *TaskConfigValidator) validateTransportConfig() {
 				continue
 			}
 
+			if kubernetes := resource.Transport.Kubernetes; kubernetes != nil {
+				kubernetesPath := transportPath + "." + FieldKubernetes
+				if client != TransportClientKubernetes {
+					v.errors.Add(kubernetesPath,
+						"kubernetes transport config is only supported when client is \"kubernetes\"")
+				} else if kubernetes.Cluster != "" {
+					v.validateTemplateString(kubernetes.Cluster, kubernetesPath+"."+FieldCluster)
+				}
+			}
+
 			if client == TransportClientMaestro {
 				// Maestro transport requires maestro config
 				if resource.Transport.Maestro == nil {
@@ -306,35 +479,323 @@ func (v *TaskConfigValidator) validateTransportConfig() {
 						maestroPath+"."+FieldTargetCluster)
 				}
 
-				// Validate manifest is set for maestro transport
-				if resource.Manifest == nil {
+				// Validate manifest is set for maestro transport; it must be a single ManifestWork
+				if resource.ManifestFrom != nil {
+					v.errors.Add(basePath+"."+FieldManifestFrom,
+						"manifestFrom is not supported for maestro transport, use manifest with a ManifestWork")
+				} else if resource.Manifest == nil {
 					v.errors.Add(basePath+"."+FieldManifest,
 						"manifest is required for maestro transport")
 				}
+
+				if deleteOption := resource.Transport.Maestro.DeleteOption; deleteOption != nil {
+					switch deleteOption.PropagationPolicy {
+					case DeletePropagationForeground, DeletePropagationOrphan, DeletePropagationSelectivelyOrphan:
+					default:
+						v.errors.Add(maestroPath+"."+FieldDeleteOption+".propagationPolicy",
+							fmt.Sprintf("unsupported propagationPolicy %q (supported: %s, %s, %s)", deleteOption.PropagationPolicy,
+								DeletePropagationForeground, DeletePropagationOrphan, DeletePropagationSelectivelyOrphan))
+					}
+				}
+
+				// The ManifestWork agent corrects workload drift on the managed cluster itself
+				if resource.DriftDetection {
+					v.errors.Add(basePath+"."+FieldDriftDetection,
+						"driftDetection is only supported for kubernetes transport")
+				}
+			}
+		}
+
+		// Status feedback and orphaning rules are handled by the ManifestWork agent
+		selectivelyOrphan := resource.GetDeleteOption() != nil &&
+			resource.GetDeleteOption().PropagationPolicy == DeletePropagationSelectivelyOrphan
+		for j, nd := range resource.NestedDiscoveries {
+			if nd.Orphan && !selectivelyOrphan {
+				v.errors.Add(fmt.Sprintf("%s.%s[%d].%s", basePath, FieldNestedDiscoveries, j, FieldOrphan),
+					fmt.Sprintf("orphan requires maestro transport with deleteOption.propagationPolicy %q",
+						DeletePropagationSelectivelyOrphan))
+			}
+			if nd.Feedback == nil {
+				continue
+			}
+			feedbackPath := fmt.Sprintf("%s.%s[%d].%s", basePath, FieldNestedDiscoveries, j, FieldFeedback)
+			if !resource.IsMaestroTransport() {
+				v.errors.Add(feedbackPath, "feedback is only supported for maestro transport")
+				continue
+			}
+			for k, jsonPath := range nd.Feedback.JSONPaths {
+				if !strings.HasPrefix(jsonPath.Path, ".") {
+					v.errors.Add(fmt.Sprintf("%s.%s[%d].%s", feedbackPath, FieldJSONPaths, k, FieldPath),
+						fmt.Sprintf("JSONPath %q must start with \".\" (e.g. .status.readyReplicas)", jsonPath.Path))
+				}
+			}
+		}
+
+		// Deletion waits for the ManifestWork agent to remove the workload
+		if del := resource.Delete; del != nil {
+			deletePath := basePath + "." + FieldDelete
+			if !resource.IsMaestroTransport() {
+				v.errors.Add(deletePath, "delete is only supported for maestro transport")
+			}
+			if del.Timeout != "" {
+				if _, err := time.ParseDuration(del.Timeout); err != nil {
+					v.errors.Add(deletePath+"."+FieldTimeout, fmt.Sprintf("invalid duration %q: %v", del.Timeout, err))
+				}
 			}
 		}
 
 		// Validate manifest is required for kubernetes transport (default)
-		if resource.GetTransportClient() == TransportClientKubernetes && resource.Manifest == nil {
+		if resource.GetTransportClient() == TransportClientKubernetes && resource.Manifest == nil && resource.ManifestFrom == nil {
 			v.errors.Add(basePath+"."+FieldManifest,
 				"manifest is required for kubernetes transport")
 		}
 	}
 }
 
+// validateResourceLookups checks the transport and the discovery templates of precondition resource lookups
+func (v *TaskConfigValidator) validateResourceLookups() {
+	for i, precond := range v.config.Spec.Preconditions {
+		lookup := precond.ResourceLookup
+		if lookup == nil {
+			continue
+		}
+		basePath := fmt.Sprintf("%s.%s[%d].%s", FieldSpec, FieldPreconditions, i, FieldResourceLookup)
+
+		if transport := lookup.Transport; transport != nil {
+			transportPath := basePath + "." + FieldTransport
+			if transport.Kubernetes != nil {
+				if transport.Client != TransportClientKubernetes {
+					v.errors.Add(transportPath+"."+FieldKubernetes,
+						"kubernetes transport config is only supported when client is \"kubernetes\"")
+				} else if transport.Kubernetes.Cluster != "" {
+					v.validateTemplateString(transport.Kubernetes.Cluster,
+						transportPath+"."+FieldKubernetes+"."+FieldCluster)
+				}
+			}
+			if transport.Client == TransportClientMaestro {
+				if transport.Maestro == nil {
+					v.errors.Add(transportPath, "maestro transport config is required when client is \"maestro\"")
+				} else {
+					v.validateTemplateString(transport.Maestro.TargetCluster,
+						transportPath+"."+FieldMaestro+"."+FieldTargetCluster)
+				}
+			}
+		}
+
+		if discovery := lookup.Discovery; discovery != nil {
+			discoveryPath := basePath + "." + FieldDiscovery
+			v.validateTemplateString(discovery.Namespace, discoveryPath+"."+FieldNamespace)
+			v.validateTemplateString(discovery.ByName, discoveryPath+"."+FieldByName)
+			if discovery.BySelectors != nil {
+				for k, val := range discovery.BySelectors.LabelSelector {
+					v.validateTemplateString(val,
+						fmt.Sprintf("%s.%s.%s[%s]", discoveryPath, FieldBySelectors, FieldLabelSelector, k))
+				}
+			}
+		}
+	}
+}
+
+// validatePreconditionRetries checks the retry intervals of preconditions
+func (v *TaskConfigValidator) validatePreconditionRetries() {
+	for i, precond := range v.config.Spec.Preconditions {
+		if precond.Retry == nil {
+			continue
+		}
+		basePath := fmt.Sprintf("%s.%s[%d].%s", FieldSpec, FieldPreconditions, i, FieldRetry)
+		interval, intervalOK := v.validateRetryDuration(basePath+"."+FieldInterval, precond.Retry.Interval)
+		maxInterval, maxOK := v.validateRetryDuration(basePath+"."+FieldMaxInterval, precond.Retry.MaxInterval)
+		if intervalOK && maxOK && interval > maxInterval {
+			v.errors.Add(basePath+"."+FieldMaxInterval, fmt.Sprintf("%q must not be less than interval %q",
+				precond.Retry.MaxInterval, precond.Retry.Interval))
+		}
+		_, maxElapsedOK := v.validateRetryDuration(basePath+"."+FieldMaxElapsed, precond.Retry.MaxElapsed)
+		if precond.Retry.MaxElapsed != "" && !maxElapsedOK {
+			continue
+		}
+		totalDelay := precond.Retry.TotalDelay(precond.MaxAttempts())
+		if maxElapsed := precond.Retry.MaxElapsedTime(); totalDelay > maxElapsed {
+			v.errors.Add(basePath, fmt.Sprintf("the delays between %d attempts add up to %v, more than maxElapsed %v "+
+				"(retries delay the other events of the resource)", precond.MaxAttempts(), totalDelay, maxElapsed))
+		}
+	}
+}
+
+// validateRetryDuration checks that a set retry duration is a positive Go duration and
+// returns it, with false when unset or invalid
+func (v *TaskConfigValidator) validateRetryDuration(path, value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		v.errors.Add(path, fmt.Sprintf("invalid duration %q: %v", value, err))
+		return 0, false
+	}
+	if d <= 0 {
+		v.errors.Add(path, fmt.Sprintf("duration %q must be positive", value))
+		return 0, false
+	}
+	return d, true
+}
+
+// validateParallelGroups checks that the members of each parallel precondition group are
+// consecutive, capture distinct variables, and do not read the response or captures of a sibling
+func (v *TaskConfigValidator) validateParallelGroups() {
+	preconds := v.config.Spec.Preconditions
+	seen := make(map[string]bool)
+	for start := 0; start < len(preconds); {
+		group := preconds[start].ParallelGroup
+		end := start + 1
+		for group != "" && end < len(preconds) && preconds[end].ParallelGroup == group {
+			end++
+		}
+		if group != "" {
+			if seen[group] {
+				path := fmt.Sprintf("%s.%s[%d].%s", FieldSpec, FieldPreconditions, start, FieldParallelGroup)
+				v.errors.Add(path, fmt.Sprintf("members of parallel group %q must be consecutive", group))
+			}
+			seen[group] = true
+			v.validateParallelGroup(group, start, end)
+		}
+		start = end
+	}
+}
+
+// validateParallelGroup checks the members preconds[start:end] of a parallel group
+func (v *TaskConfigValidator) validateParallelGroup(group string, start, end int) {
+	preconds := v.config.Spec.Preconditions
+
+	// Variables set by each member: the API response under the precondition name and the captures
+	producers := make(map[string]int)
+	for i := start; i < end; i++ {
+		if (preconds[i].APICall != nil || preconds[i].ResourceLookup != nil) && preconds[i].Name != "" {
+			producers[preconds[i].Name] = i
+		}
+		for j, capture := range preconds[i].Capture {
+			if other, exists := producers[capture.Name]; exists && other != i {
+				path := fmt.Sprintf("%s.%s[%d].%s[%d]", FieldSpec, FieldPreconditions, i, FieldCapture, j)
+				v.errors.Add(path, fmt.Sprintf("variable %q is also set by precondition %q of parallel group %q",
+					capture.Name, preconds[other].Name, group))
+				continue
+			}
+			producers[capture.Name] = i
+		}
+	}
+
+	for i := start; i < end; i++ {
+		path := fmt.Sprintf("%s.%s[%d]", FieldSpec, FieldPreconditions, i)
+		for _, ref := range preconditionReferences(preconds[i]) {
+			if other, exists := producers[ref]; exists && other != i {
+				v.errors.Add(path, fmt.Sprintf("reads %q from precondition %q of the same parallel group %q",
+					ref, preconds[other].Name, group))
+			}
+		}
+	}
+}
+
+// preconditionReferences returns the sorted root variable names read by a precondition:
+// template variables of its log message, API call and resource lookup, condition fields and
+// the CEL expression
+func preconditionReferences(precond Precondition) []string {
+	refs := make(map[string]bool)
+	addTemplate := func(s string) {
+		for _, match := range templateVarRegex.FindAllStringSubmatch(s, -1) {
+			refs[strings.SplitN(match[1], ".", 2)[0]] = true
+		}
+	}
+
+	if precond.Log != nil {
+		addTemplate(precond.Log.Message)
+	}
+	if precond.APICall != nil {
+		addTemplate(precond.APICall.URL)
+		addTemplate(precond.APICall.Body)
+		for _, header := range precond.APICall.Headers {
+			addTemplate(header.Value)
+		}
+	}
+	if lookup := precond.ResourceLookup; lookup != nil {
+		if lookup.Transport != nil && lookup.Transport.Maestro != nil {
+			addTemplate(lookup.Transport.Maestro.TargetCluster)
+		}
+		if lookup.Transport != nil && lookup.Transport.Kubernetes != nil {
+			addTemplate(lookup.Transport.Kubernetes.Cluster)
+		}
+		if discovery := lookup.Discovery; discovery != nil {
+			addTemplate(discovery.Namespace)
+			addTemplate(discovery.ByName)
+			if discovery.BySelectors != nil {
+				for _, val := range discovery.BySelectors.LabelSelector {
+					addTemplate(val)
+				}
+			}
+		}
+	}
+	for _, cond := range precond.Conditions {
+		addConditionReferences(cond, refs)
+	}
+	if names, err := criteria.ReferencedVariables(precond.Expression); err == nil {
+		for _, name := range names {
+			refs[name] = true
+		}
+	}
+
+	sorted := make([]string, 0, len(refs))
+	for name := range refs {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
+
+// addConditionReferences adds the root variable of each condition field, recursing into groups
+func addConditionReferences(cond Condition, refs map[string]bool) {
+	if parts := strings.FieldsFunc(cond.Field, func(r rune) bool { return r == '.' || r == '[' }); len(parts) > 0 {
+		refs[parts[0]] = true
+	}
+	for _, nested := range cond.AnyOf {
+		addConditionReferences(nested, refs)
+	}
+	for _, nested := range cond.AllOf {
+		addConditionReferences(nested, refs)
+	}
+	if cond.Not != nil {
+		addConditionReferences(*cond.Not, refs)
+	}
+}
+
 func (v *TaskConfigValidator) validateConditionValues() {
 	for i, precond := range v.config.Spec.Preconditions {
 		for j, cond := range precond.Conditions {
 			path := fmt.Sprintf("%s.%s[%d].%s[%d]", FieldSpec, FieldPreconditions, i, FieldConditions, j)
-			v.validateConditionValue(cond.Operator, cond.Value, path)
+			v.validateConditionTree(cond, path)
 		}
 	}
 }
 
+// validateConditionTree checks the values of a condition, recursing into anyOf, allOf and not groups.
+// The shape of each condition is checked by the struct validator.
+func (v *TaskConfigValidator) validateConditionTree(cond Condition, path string) {
+	if !cond.IsGroup() {
+		v.validateConditionValue(cond.Operator, cond.Value, path)
+		return
+	}
+	for i, nested := range cond.AnyOf {
+		v.validateConditionTree(nested, fmt.Sprintf("%s.%s[%d]", path, FieldAnyOf, i))
+	}
+	for i, nested := range cond.AllOf {
+		v.validateConditionTree(nested, fmt.Sprintf("%s.%s[%d]", path, FieldAllOf, i))
+	}
+	if cond.Not != nil {
+		v.validateConditionTree(*cond.Not, path+"."+FieldNot)
+	}
+}
+
 func (v *TaskConfigValidator) validateConditionValue(operator string, value interface{}, path string) {
 	op := criteria.Operator(operator)
 
-	if op == criteria.OperatorExists {
+	if criteria.IsValuelessOperator(op) {
 		if value != nil {
 			v.errors.Add(path, fmt.Sprintf("value/values should not be set for operator \"%s\"", operator))
 		}
@@ -346,19 +807,85 @@ func (v *TaskConfigValidator) validateConditionValue(operator string, value inte
 		return
 	}
 
-	if op == criteria.OperatorIn || op == criteria.OperatorNotIn {
+	switch op { //nolint:exhaustive // equals, notEquals and contains accept any value
+	case criteria.OperatorIn, criteria.OperatorNotIn:
 		if !isSliceOrArray(value) {
 			v.errors.Add(path, fmt.Sprintf("value must be a list for operator %q", operator))
 		}
+	case criteria.OperatorGreaterThan, criteria.OperatorLessThan,
+		criteria.OperatorGreaterThanOrEqual, criteria.OperatorLessThanOrEqual:
+		if _, ok := numberValue(value); !ok {
+			v.errors.Add(path, fmt.Sprintf("value must be a number for operator %q", operator))
+		}
+	case criteria.OperatorBetween:
+		v.validateBetweenValue(value, path)
+	case criteria.OperatorMatches:
+		pattern, ok := value.(string)
+		if !ok {
+			v.errors.Add(path, fmt.Sprintf("value must be a string for operator %q", operator))
+		} else if _, err := criteria.CompileRegex(pattern); err != nil {
+			v.errors.Add(path, fmt.Sprintf("invalid regular expression %q: %v", pattern, err))
+		}
+	case criteria.OperatorStartsWith, criteria.OperatorEndsWith:
+		if _, ok := value.(string); !ok {
+			v.errors.Add(path, fmt.Sprintf("value must be a string for operator %q", operator))
+		}
+	case criteria.OperatorOlderThan, criteria.OperatorNewerThan:
+		age, ok := value.(string)
+		if !ok {
+			v.errors.Add(path, fmt.Sprintf("value must be a duration string for operator %q", operator))
+		} else if _, err := time.ParseDuration(age); err != nil {
+			v.errors.Add(path, fmt.Sprintf("invalid duration %q: %v", age, err))
+		}
+	case criteria.OperatorSemverAtLeast:
+		version, ok := value.(string)
+		if !ok {
+			v.errors.Add(path, fmt.Sprintf("value must be a version string for operator %q", operator))
+		} else if _, err := semver.NewVersion(version); err != nil {
+			v.errors.Add(path, fmt.Sprintf("invalid semantic version %q: %v", version, err))
+		}
+	}
+}
+
+// validateBetweenValue checks that a between value is a [min, max] list of numbers
+func (v *TaskConfigValidator) validateBetweenValue(value interface{}, path string) {
+	bounds, ok := value.([]interface{})
+	if !ok || len(bounds) != 2 {
+		v.errors.Add(path, fmt.Sprintf("value must be a [min, max] list for operator %q", criteria.OperatorBetween))
+		return
+	}
+	minValue, minOK := numberValue(bounds[0])
+	maxValue, maxOK := numberValue(bounds[1])
+	if !minOK || !maxOK {
+		v.errors.Add(path, fmt.Sprintf("min and max must be numbers for operator %q", criteria.OperatorBetween))
+		return
+	}
+	if minValue > maxValue {
+		v.errors.Add(path, fmt.Sprintf("min must not be greater than max for operator %q", criteria.OperatorBetween))
 	}
 }
 
 func (v *TaskConfigValidator) validateCaptureFieldExpressions() {
 	for i, precond := range v.config.Spec.Preconditions {
 		for j, capture := range precond.Capture {
+			path := fmt.Sprintf("%s.%s[%d].%s[%d]", FieldSpec, FieldPreconditions, i, FieldCapture, j)
 			if capture.Expression != "" && v.celEnv != nil {
-				path := fmt.Sprintf("%s.%s[%d].%s[%d].%s", FieldSpec, FieldPreconditions, i, FieldCapture, j, FieldExpression)
-				v.validateCELExpression(capture.Expression, path)
+				v.validateCaptureExpression(capture.Expression, path+"."+FieldExpression)
+			}
+			if capture.Type != "" && !isSupportedParamType(capture.Type) {
+				v.errors.Add(path+"."+FieldType, fmt.Sprintf("unsupported type %q (supported: %s)",
+					capture.Type, strings.Join(utils.SupportedTypes, ", ")))
+				continue
+			}
+			if capture.Default == nil {
+				continue
+			}
+			if capture.Required {
+				v.errors.Add(path+"."+FieldDefault, "a required capture cannot have a default")
+			} else if capture.Type != "" {
+				if _, err := utils.ConvertToType(capture.Default, capture.Type); err != nil {
+					v.errors.Add(path+"."+FieldDefault, fmt.Sprintf("invalid default for type %q: %v", capture.Type, err))
+				}
 			}
 		}
 	}
@@ -384,6 +911,40 @@ func (v *TaskConfigValidator) validateTemplateVariables() {
 		if manifest, ok := resource.Manifest.(map[string]interface{}); ok {
 			v.validateTemplateMap(manifest, resourcePath+"."+FieldManifest)
 		}
+		if from := resource.ManifestFrom; from != nil {
+			fromPath := resourcePath + "." + FieldManifestFrom
+			for j, m := range from.Manifests {
+				v.validateTemplateMap(m, fmt.Sprintf("%s.%s[%d]", fromPath, FieldPath, j))
+			}
+			for k, val := range from.Labels {
+				v.validateTemplateString(val, fmt.Sprintf("%s.labels[%s]", fromPath, k))
+			}
+			for k, val := range from.Annotations {
+				v.validateTemplateString(val, fmt.Sprintf("%s.annotations[%s]", fromPath, k))
+			}
+			if from.Chart != nil {
+				chartPath := fromPath + "." + FieldChart
+				v.validateTemplateString(from.Chart.ReleaseName, chartPath+".releaseName")
+				v.validateTemplateString(from.Chart.Namespace, chartPath+"."+FieldNamespace)
+				v.validateTemplateMap(from.Chart.Values, chartPath+"."+FieldValues)
+			}
+		}
+		for j, patch := range resource.Patches {
+			patchPath := fmt.Sprintf("%s.%s[%d]", resourcePath, FieldPatches, j)
+			if patch.Target != nil {
+				v.validateTemplateString(patch.Target.Name, patchPath+"."+FieldTarget+"."+FieldName)
+			}
+			switch doc := patch.Patch.(type) {
+			case map[string]interface{}:
+				v.validateTemplateMap(doc, patchPath+"."+FieldPatch)
+			case []interface{}:
+				for k, item := range doc {
+					if op, ok := item.(map[string]interface{}); ok {
+						v.validateTemplateMap(op, fmt.Sprintf("%s.%s[%d]", patchPath, FieldPatch, k))
+					}
+				}
+			}
+		}
 		// NOTE: For maestro transport, we skip template variable validation for manifest content.
 		// ManifestWork templates may use variables provided at runtime by the framework
 		// (e.g., adapterName, timestamp) that are not necessarily declared in params or captures.
@@ -505,6 +1066,23 @@ func (v *TaskConfigValidator) validateCELExpressions() {
 		return
 	}
 
+	if match := v.config.Spec.Match; match != nil {
+		v.validateCELExpressionIn(v.matchEnv, match.Expression, FieldSpec+"."+FieldMatch+"."+FieldExpression)
+	}
+
+	for i, param := range v.config.Spec.Params {
+		if param.IsExpression() {
+			path := fmt.Sprintf("%s.%s[%d].%s", FieldSpec, FieldParams, i, FieldExpression)
+			v.validateCELExpressionIn(v.paramEnv, param.Expression, path)
+			if _, ok, err := criteria.SelectedFields(param.Expression, "env"); err == nil && !ok {
+				v.errors.Add(path, `environment variables can only be read as "env.NAME"`)
+			}
+		}
+	}
+	if _, err := OrderExpressionParams(v.config.Spec.Params); err != nil {
+		v.errors.Add(FieldSpec+"."+FieldParams, err.Error())
+	}
+
 	for i, precond := range v.config.Spec.Preconditions {
 		if precond.Expression != "" {
 			path := fmt.Sprintf("%s.%s[%d].%s", FieldSpec, FieldPreconditions, i, FieldExpression)
@@ -512,6 +1090,30 @@ func (v *TaskConfigValidator) validateCELExpressions() {
 		}
 	}
 
+	for i, resource := range v.config.Spec.Resources {
+		resourcePath := fmt.Sprintf("%s.%s[%d]", FieldSpec, FieldResources, i)
+		if resource.Manifest != nil {
+			v.validateManifestExpressions(resource.Manifest, resourcePath+"."+FieldManifest)
+		}
+		if from := resource.ManifestFrom; from != nil {
+			fromPath := resourcePath + "." + FieldManifestFrom
+			for j, m := range from.Manifests {
+				v.validateManifestExpressions(m, fmt.Sprintf("%s.%s[%d]", fromPath, FieldPath, j))
+			}
+			if from.Chart != nil {
+				v.validateManifestExpressions(from.Chart.Values, fromPath+"."+FieldChart+"."+FieldValues)
+			}
+		}
+		for j, patch := range resource.Patches {
+			patchPath := fmt.Sprintf("%s.%s[%d]", resourcePath, FieldPatches, j)
+			v.validateCELExpression(patch.When, patchPath+"."+FieldWhen)
+			v.validateManifestExpressions(patch.Patch, patchPath+"."+FieldPatch)
+		}
+		if resource.Delete != nil {
+			v.validateCELExpression(resource.Delete.When, resourcePath+"."+FieldDelete+"."+FieldWhen)
+		}
+	}
+
 	if v.config.Spec.Post != nil {
 		for i, payload := range v.config.Spec.Post.Payloads {
 			if payload.Build != nil {
@@ -523,17 +1125,84 @@ func (v *TaskConfigValidator) validateCELExpressions() {
 	}
 }
 
+// validateCELExpression validates an expression of a precondition, resource or post action
 func (v *TaskConfigValidator) validateCELExpression(expr string, path string) {
+	v.validateCELExpressionIn(v.celEnv, expr, path)
+}
+
+// validateCELExpressionIn parses and type-checks an expression against the variables declared by
+// env, and compiles its runtime program from the checked AST
+func (v *TaskConfigValidator) validateCELExpressionIn(env *cel.Env, expr string, path string) {
 	if expr == "" {
 		return
 	}
 
 	expr = strings.TrimSpace(expr)
 
-	_, issues := v.celEnv.Parse(expr)
+	ast, issues := env.Parse(expr)
 	if issues != nil && issues.Err() != nil {
 		v.errors.Add(path, fmt.Sprintf("CEL parse error: %v", issues.Err()))
+		return
+	}
+
+	// Type-check against the declared variables (all dyn), so undeclared references are errors
+	checked, issues := env.Check(ast)
+	if issues != nil && issues.Err() != nil {
+		for _, issue := range issues.Errors() {
+			v.errors.Add(path, fmt.Sprintf("CEL type error: %s", issue.Message))
+		}
+		return
+	}
+	v.warnUnboundedCost(env, checked, path)
+
+	// Compile the runtime program once, so event processing reuses it
+	if v.programs != nil {
+		if err := v.programs.CompileChecked(expr, checked); err != nil {
+			v.errors.Add(path, err.Error())
+		}
+	}
+}
+
+// validateCaptureExpression validates a capture expression. Its variables are the fields of the
+// API response, only known at runtime, so it is parsed but not type-checked.
+func (v *TaskConfigValidator) validateCaptureExpression(expr string, path string) {
+	expr = strings.TrimSpace(expr)
+	if _, issues := v.celEnv.Parse(expr); issues != nil && issues.Err() != nil {
+		v.errors.Add(path, fmt.Sprintf("CEL parse error: %v", issues.Err()))
+		return
 	}
+
+	if v.programs != nil {
+		if err := v.programs.Compile(expr); err != nil {
+			v.errors.Add(path, err.Error())
+		}
+	}
+}
+
+// warnUnboundedCost warns when the worst-case cost of a checked expression is unbounded, e.g. a
+// comprehension over a list of unknown size. Such an expression is only stopped at runtime by
+// the CEL cost limit and timeout (spec.cel of the adapter config).
+func (v *TaskConfigValidator) warnUnboundedCost(env *cel.Env, checked *cel.Ast, path string) {
+	if checked == nil {
+		return
+	}
+	estimate, err := env.EstimateCost(checked, unknownSizeEstimator{})
+	if err != nil || estimate.Max != math.MaxUint64 {
+		return
+	}
+	v.warnings.Add(path, "CEL expression has an unbounded worst-case cost (comprehension over data of unknown size); "+
+		"it is bounded at runtime by the CEL cost limit and timeout")
+}
+
+// unknownSizeEstimator is a cost estimator without size hints: the variables are runtime data
+type unknownSizeEstimator struct{}
+
+func (unknownSizeEstimator) EstimateSize(checker.AstNode) *checker.SizeEstimate {
+	return nil
+}
+
+func (unknownSizeEstimator) EstimateCallCost(string, string, *checker.AstNode, []checker.AstNode) *checker.CallEstimate {
+	return nil
 }
 
 func (v *TaskConfigValidator) validateBuildExpressions(m map[string]interface{}, path string) {
@@ -557,6 +1226,60 @@ func (v *TaskConfigValidator) validateBuildExpressions(m map[string]interface{},
 	}
 }
 
+// validateManifestExpressions validates the CEL syntax of !expr values in a manifest
+func (v *TaskConfigValidator) validateManifestExpressions(value interface{}, path string) {
+	if expr, ok := ParseManifestExpression(value); ok {
+		v.validateCELExpression(expr, path)
+		return
+	}
+
+	switch val := value.(type) {
+	case map[string]interface{}:
+		for key, item := range val {
+			v.validateManifestExpressions(item, fmt.Sprintf("%s.%s", path, key))
+		}
+	case []interface{}:
+		for i, item := range val {
+			v.validateManifestExpressions(item, fmt.Sprintf("%s[%d]", path, i))
+		}
+	}
+}
+
+// validatePatches checks that each patch document has the shape its type expects:
+// a list of operations with "op" and "path" for JSON patches, an object for merge patches
+func (v *TaskConfigValidator) validatePatches() {
+	for i, resource := range v.config.Spec.Resources {
+		for j, patch := range resource.Patches {
+			path := fmt.Sprintf("%s.%s[%d].%s[%d].%s", FieldSpec, FieldResources, i, FieldPatches, j, FieldPatch)
+
+			switch manifest.PatchType(patch.Type) {
+			case manifest.PatchTypeJSON:
+				ops, ok := patch.Patch.([]interface{})
+				if !ok {
+					v.errors.Add(path, "json patch must be a list of operations")
+					continue
+				}
+				for k, item := range ops {
+					op, ok := item.(map[string]interface{})
+					if !ok {
+						v.errors.Add(fmt.Sprintf("%s[%d]", path, k), "json patch operation must be an object")
+						continue
+					}
+					for _, field := range []string{"op", FieldPath} {
+						if _, ok := op[field].(string); !ok {
+							v.errors.Add(fmt.Sprintf("%s[%d]", path, k), fmt.Sprintf("json patch operation must have a string %q", field))
+						}
+					}
+				}
+			case manifest.PatchTypeStrategic, manifest.PatchTypeMerge:
+				if _, ok := patch.Patch.(map[string]interface{}); !ok {
+					v.errors.Add(path, fmt.Sprintf("%s patch must be an object", patch.Type))
+				}
+			}
+		}
+	}
+}
+
 func (v *TaskConfigValidator) validateK8sManifests() {
 	for i, resource := range v.config.Spec.Resources {
 		// Skip K8s manifest validation for maestro transport — manifest holds ManifestWork content
@@ -564,6 +1287,12 @@ func (v *TaskConfigValidator) validateK8sManifests() {
 			continue
 		}
 
+		if resource.ManifestFrom != nil {
+			for j, m := range resource.ManifestFrom.Manifests {
+				v.validateK8sManifest(m, fmt.Sprintf("%s.%s[%d].%s.%s[%d]", FieldSpec, FieldResources, i, FieldManifestFrom, FieldPath, j))
+			}
+		}
+
 		if resource.Manifest == nil {
 			continue
 		}
@@ -622,6 +1351,30 @@ func isSliceOrArray(value interface{}) bool {
 	return kind == reflect.Slice || kind == reflect.Array
 }
 
+// numberValue returns the value of an integer or floating-point number
+func numberValue(value interface{}) (float64, bool) {
+	v := reflect.ValueOf(value)
+	switch v.Kind() { //nolint:exhaustive // only numeric kinds
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	}
+	return 0, false
+}
+
+// isSupportedParamType checks if the given param type is supported
+func isSupportedParamType(paramType string) bool {
+	for _, t := range utils.SupportedTypes {
+		if t == paramType {
+			return true
+		}
+	}
+	return false
+}
+
 // IsSupportedAPIVersion checks if the given apiVersion is supported
 func IsSupportedAPIVersion(apiVersion string) bool {
 	for _, v := range SupportedAPIVersions {
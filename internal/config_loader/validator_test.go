@@ -1,11 +1,14 @@
 package config_loader
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/criteria"
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/pkg/logger"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -135,6 +138,506 @@ func TestValidateConditionOperators(t *testing.T) {
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "value is required for operator \"greaterThan\"")
 	})
+
+	t.Run("extended operators with valid values", func(t *testing.T) {
+		cfg := baseTaskConfig()
+		cfg.Spec.Preconditions = []Precondition{{
+			ActionBase: ActionBase{Name: "checkStatus"},
+			Conditions: []Condition{
+				{Field: "nodeCount", Operator: "greaterThanOrEqual", Value: 3},
+				{Field: "nodeCount", Operator: "lessThanOrEqual", Value: 10.5},
+				{Field: "nodeCount", Operator: "between", Value: []interface{}{1, 10}},
+				{Field: "name", Operator: "matches", Value: "^prod-[a-z]+$"},
+				{Field: "name", Operator: "startsWith", Value: "prod-"},
+				{Field: "name", Operator: "endsWith", Value: "-1"},
+				{Field: "vpcId", Operator: "notExists"},
+				{Field: "labels", Operator: "isEmpty"},
+				{Field: "zones", Operator: "notEmpty"},
+				{Field: "createdTime", Operator: "olderThan", Value: "1h"},
+				{Field: "createdTime", Operator: "newerThan", Value: "720h"},
+				{Field: "version", Operator: "semverAtLeast", Value: "4.14.0"},
+			},
+		}}
+		v := newTaskValidator(cfg)
+		require.NoError(t, v.ValidateStructure())
+		require.NoError(t, v.ValidateSemantic())
+	})
+
+	invalidValues := []struct {
+		name    string
+		cond    Condition
+		message string
+	}{
+		{"non-numeric value for greaterThanOrEqual", Condition{Field: "count", Operator: "greaterThanOrEqual", Value: "3"},
+			"value must be a number for operator \"greaterThanOrEqual\""},
+		{"single value for between", Condition{Field: "count", Operator: "between", Value: []interface{}{1}},
+			"value must be a [min, max] list for operator \"between\""},
+		{"non-numeric bounds for between", Condition{Field: "count", Operator: "between", Value: []interface{}{"a", "b"}},
+			"min and max must be numbers for operator \"between\""},
+		{"reversed bounds for between", Condition{Field: "count", Operator: "between", Value: []interface{}{10, 1}},
+			"min must not be greater than max"},
+		{"invalid regex for matches", Condition{Field: "name", Operator: "matches", Value: "("},
+			"invalid regular expression"},
+		{"non-string value for startsWith", Condition{Field: "name", Operator: "startsWith", Value: 1},
+			"value must be a string for operator \"startsWith\""},
+		{"value for isEmpty", Condition{Field: "labels", Operator: "isEmpty", Value: "x"},
+			"value/values should not be set for operator \"isEmpty\""},
+		{"invalid duration for olderThan", Condition{Field: "createdTime", Operator: "olderThan", Value: "1 day"},
+			"invalid duration"},
+		{"invalid version for semverAtLeast", Condition{Field: "version", Operator: "semverAtLeast", Value: "latest"},
+			"invalid semantic version"},
+	}
+	for _, tt := range invalidValues {
+		t.Run(tt.name, func(t *testing.T) {
+			v := newTaskValidator(withCondition(tt.cond))
+			_ = v.ValidateStructure()
+			err := v.ValidateSemantic()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.message)
+		})
+	}
+
+	t.Run("valid condition groups", func(t *testing.T) {
+		cfg := withCondition(Condition{AnyOf: []Condition{
+			{Field: "provider", Operator: "equals", Value: "aws"},
+			{AllOf: []Condition{
+				{Field: "provider", Operator: "equals", Value: "gcp"},
+				{Not: &Condition{Field: "region", Operator: "startsWith", Value: "us-gov-"}},
+			}},
+		}})
+		v := newTaskValidator(cfg)
+		require.NoError(t, v.ValidateStructure())
+		require.NoError(t, v.ValidateSemantic())
+	})
+
+	t.Run("group combined with operator", func(t *testing.T) {
+		cfg := withCondition(Condition{
+			Field:    "provider",
+			Operator: "equals",
+			Value:    "aws",
+			Not:      &Condition{Field: "region", Operator: "exists"},
+		})
+		err := newTaskValidator(cfg).ValidateStructure()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(),
+			"spec.preconditions[0].conditions[0]: must specify exactly one of operator, anyOf, allOf or not")
+	})
+
+	t.Run("several groups in one condition", func(t *testing.T) {
+		cfg := withCondition(Condition{
+			AnyOf: []Condition{{Field: "provider", Operator: "exists"}},
+			AllOf: []Condition{{Field: "region", Operator: "exists"}},
+		})
+		err := newTaskValidator(cfg).ValidateStructure()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "must specify exactly one of operator, anyOf, allOf or not")
+	})
+
+	t.Run("invalid operator in nested condition", func(t *testing.T) {
+		cfg := withCondition(Condition{AnyOf: []Condition{
+			{Field: "provider", Operator: "equals", Value: "aws"},
+			{Not: &Condition{Field: "region", Operator: "invalidOp"}},
+		}})
+		err := newTaskValidator(cfg).ValidateStructure()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "spec.preconditions[0].conditions[0].anyOf[1].not.operator: invalid operator")
+	})
+
+	t.Run("missing operator in nested condition", func(t *testing.T) {
+		cfg := withCondition(Condition{AllOf: []Condition{{Field: "provider", Value: "aws"}}})
+		err := newTaskValidator(cfg).ValidateStructure()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "spec.preconditions[0].conditions[0].allOf[0].operator is required")
+	})
+
+	t.Run("invalid value in nested condition", func(t *testing.T) {
+		cfg := withCondition(Condition{AnyOf: []Condition{
+			{Field: "count", Operator: "between", Value: []interface{}{10, 1}},
+		}})
+		v := newTaskValidator(cfg)
+		require.NoError(t, v.ValidateStructure())
+		err := v.ValidateSemantic()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "spec.preconditions[0].conditions[0].anyOf[0]")
+		assert.Contains(t, err.Error(), "min must not be greater than max")
+	})
+}
+
+func TestValidatePreconditionRetry(t *testing.T) {
+	withRetry := func(retry *PreconditionRetry) *AdapterTaskConfig {
+		cfg := baseTaskConfig()
+		cfg.Spec.Preconditions = []Precondition{{
+			ActionBase: ActionBase{Name: "waitForLandingZone"},
+			Expression: "true",
+			Retry:      retry,
+		}}
+		return cfg
+	}
+
+	t.Run("valid retry", func(t *testing.T) {
+		v := newTaskValidator(withRetry(&PreconditionRetry{Attempts: 5, Interval: "10s", Backoff: "exponential", MaxElapsed: "3m"}))
+		require.NoError(t, v.ValidateStructure())
+		require.NoError(t, v.ValidateSemantic())
+	})
+
+	t.Run("delays exceeding the default max elapsed", func(t *testing.T) {
+		// 10s + 20s + 40s + 80s
+		v := newTaskValidator(withRetry(&PreconditionRetry{Attempts: 5, Interval: "10s", Backoff: "exponential"}))
+		require.NoError(t, v.ValidateStructure())
+		err := v.ValidateSemantic()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "spec.preconditions[0].retry: the delays between 5 attempts add up to 2m30s, more than maxElapsed 2m0s")
+	})
+
+	t.Run("delays exceeding max elapsed", func(t *testing.T) {
+		v := newTaskValidator(withRetry(&PreconditionRetry{Attempts: 100, Interval: "5m", MaxElapsed: "1h"}))
+		require.NoError(t, v.ValidateStructure())
+		err := v.ValidateSemantic()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "add up to 8h15m0s, more than maxElapsed 1h0m0s")
+	})
+
+	t.Run("invalid max elapsed", func(t *testing.T) {
+		v := newTaskValidator(withRetry(&PreconditionRetry{Attempts: 3, MaxElapsed: "soon"}))
+		require.NoError(t, v.ValidateStructure())
+		err := v.ValidateSemantic()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "spec.preconditions[0].retry.maxElapsed: invalid duration")
+		assert.NotContains(t, err.Error(), "add up to")
+	})
+
+	t.Run("missing attempts", func(t *testing.T) {
+		err := newTaskValidator(withRetry(&PreconditionRetry{Interval: "10s"})).ValidateStructure()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "spec.preconditions[0].retry.attempts is required")
+	})
+
+	t.Run("too many attempts", func(t *testing.T) {
+		err := newTaskValidator(withRetry(&PreconditionRetry{Attempts: 1000})).ValidateStructure()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "spec.preconditions[0].retry.attempts: must be less than or equal to 100")
+	})
+
+	t.Run("invalid backoff", func(t *testing.T) {
+		err := newTaskValidator(withRetry(&PreconditionRetry{Attempts: 3, Backoff: "fibonacci"})).ValidateStructure()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "spec.preconditions[0].retry.backoff \"fibonacci\" is invalid")
+	})
+
+	t.Run("invalid interval", func(t *testing.T) {
+		v := newTaskValidator(withRetry(&PreconditionRetry{Attempts: 3, Interval: "soon"}))
+		require.NoError(t, v.ValidateStructure())
+		err := v.ValidateSemantic()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "spec.preconditions[0].retry.interval")
+		assert.Contains(t, err.Error(), "invalid duration \"soon\"")
+	})
+
+	t.Run("negative interval", func(t *testing.T) {
+		v := newTaskValidator(withRetry(&PreconditionRetry{Attempts: 3, Interval: "-1s"}))
+		require.NoError(t, v.ValidateStructure())
+		err := v.ValidateSemantic()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "must be positive")
+	})
+
+	t.Run("invalid max interval", func(t *testing.T) {
+		v := newTaskValidator(withRetry(&PreconditionRetry{Attempts: 3, MaxInterval: "0s"}))
+		require.NoError(t, v.ValidateStructure())
+		err := v.ValidateSemantic()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "spec.preconditions[0].retry.maxInterval")
+		assert.Contains(t, err.Error(), "must be positive")
+	})
+
+	t.Run("max interval below interval", func(t *testing.T) {
+		v := newTaskValidator(withRetry(&PreconditionRetry{Attempts: 3, Interval: "1m", MaxInterval: "30s"}))
+		require.NoError(t, v.ValidateStructure())
+		err := v.ValidateSemantic()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `spec.preconditions[0].retry.maxInterval`)
+		assert.Contains(t, err.Error(), `"30s" must not be less than interval "1m"`)
+	})
+}
+
+func TestValidateParallelGroups(t *testing.T) {
+	apiPrecondition := func(name, group, url string, captures ...string) Precondition {
+		p := Precondition{
+			ActionBase:    ActionBase{Name: name, APICall: &APICall{Method: "GET", URL: url}},
+			ParallelGroup: group,
+		}
+		for _, c := range captures {
+			p.Capture = append(p.Capture, CaptureField{Name: c, FieldExpressionDef: FieldExpressionDef{Field: "status." + c}})
+		}
+		return p
+	}
+	withPreconditions := func(preconds ...Precondition) *AdapterTaskConfig {
+		cfg := baseTaskConfig()
+		cfg.Spec.Params = []Parameter{{Name: "clusterId", Source: "event.id"}}
+		cfg.Spec.Preconditions = preconds
+		return cfg
+	}
+
+	t.Run("independent members", func(t *testing.T) {
+		cluster := apiPrecondition("cluster", "fetch", "/clusters/{{ .clusterId }}", "clusterPhase")
+		cluster.Conditions = []Condition{{Field: "clusterPhase", Operator: "equals", Value: "Ready"}}
+		nodePools := apiPrecondition("nodePools", "fetch", "/clusters/{{ .clusterId }}/nodepools", "nodePoolCount")
+		summary := Precondition{
+			ActionBase: ActionBase{Name: "summary"},
+			Expression: "clusterPhase == 'Ready' && nodePoolCount > 0",
+		}
+		v := newTaskValidator(withPreconditions(cluster, nodePools, summary))
+		require.NoError(t, v.ValidateStructure())
+		require.NoError(t, v.ValidateSemantic())
+	})
+
+	t.Run("member reads a sibling capture", func(t *testing.T) {
+		cluster := apiPrecondition("cluster", "fetch", "/clusters/{{ .clusterId }}", "projectId")
+		project := apiPrecondition("project", "fetch", "/projects/{{ .projectId }}")
+		err := newTaskValidator(withPreconditions(cluster, project)).ValidateSemantic()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(),
+			`spec.preconditions[1]: reads "projectId" from precondition "cluster" of the same parallel group "fetch"`)
+	})
+
+	t.Run("member reads a sibling response", func(t *testing.T) {
+		cluster := apiPrecondition("cluster", "fetch", "/clusters/{{ .clusterId }}")
+		nodePools := apiPrecondition("nodePools", "fetch", "/clusters/{{ .clusterId }}/nodepools")
+		nodePools.Conditions = []Condition{{AnyOf: []Condition{
+			{Field: "cluster.status.phase", Operator: "equals", Value: "Ready"},
+		}}}
+		err := newTaskValidator(withPreconditions(cluster, nodePools)).ValidateSemantic()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `spec.preconditions[1]: reads "cluster" from precondition "cluster"`)
+	})
+
+	t.Run("member expression reads a sibling capture", func(t *testing.T) {
+		cluster := apiPrecondition("cluster", "fetch", "/clusters/{{ .clusterId }}", "clusterPhase")
+		check := Precondition{
+			ActionBase:    ActionBase{Name: "check"},
+			Expression:    "clusterPhase == 'Ready'",
+			ParallelGroup: "fetch",
+		}
+		err := newTaskValidator(withPreconditions(cluster, check)).ValidateSemantic()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `spec.preconditions[1]: reads "clusterPhase"`)
+	})
+
+	t.Run("duplicate capture", func(t *testing.T) {
+		cluster := apiPrecondition("cluster", "fetch", "/clusters/{{ .clusterId }}", "phase")
+		nodePools := apiPrecondition("nodePools", "fetch", "/clusters/{{ .clusterId }}/nodepools", "phase")
+		err := newTaskValidator(withPreconditions(cluster, nodePools)).ValidateSemantic()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(),
+			`spec.preconditions[1].capture[0]: variable "phase" is also set by precondition "cluster" of parallel group "fetch"`)
+	})
+
+	t.Run("non-consecutive members", func(t *testing.T) {
+		err := newTaskValidator(withPreconditions(
+			apiPrecondition("cluster", "fetch", "/clusters/{{ .clusterId }}"),
+			apiPrecondition("project", "", "/projects"),
+			apiPrecondition("nodePools", "fetch", "/clusters/{{ .clusterId }}/nodepools"),
+		)).ValidateSemantic()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `spec.preconditions[2].parallelGroup: members of parallel group "fetch" must be consecutive`)
+	})
+}
+
+func TestValidateResourceLookup(t *testing.T) {
+	withLookup := func(lookup *ResourceLookup) *AdapterTaskConfig {
+		cfg := baseTaskConfig()
+		cfg.Spec.Params = []Parameter{{Name: "clusterName", Source: "event.name"}}
+		cfg.Spec.Preconditions = []Precondition{{
+			ActionBase:     ActionBase{Name: "pullSecret"},
+			ResourceLookup: lookup,
+			Capture:        []CaptureField{{Name: "secretType", FieldExpressionDef: FieldExpressionDef{Field: "type"}}},
+		}}
+		return cfg
+	}
+	secretLookup := func() *ResourceLookup {
+		return &ResourceLookup{
+			APIVersion: "v1",
+			Kind:       "Secret",
+			Discovery:  &DiscoveryConfig{Namespace: "openshift-config", ByName: "pull-secret"},
+		}
+	}
+
+	t.Run("valid lookup", func(t *testing.T) {
+		v := newTaskValidator(withLookup(secretLookup()))
+		require.NoError(t, v.ValidateStructure())
+		require.NoError(t, v.ValidateSemantic())
+	})
+
+	t.Run("valid maestro lookup", func(t *testing.T) {
+		lookup := &ResourceLookup{
+			APIVersion: "work.open-cluster-management.io/v1",
+			Kind:       "ManifestWork",
+			Transport: &TransportConfig{
+				Client:  TransportClientMaestro,
+				Maestro: &MaestroTransportConfig{TargetCluster: "{{ .clusterName }}"},
+			},
+			Discovery: &DiscoveryConfig{ByName: "{{ .clusterName }}-workload"},
+		}
+		v := newTaskValidator(withLookup(lookup))
+		require.NoError(t, v.ValidateStructure())
+		require.NoError(t, v.ValidateSemantic())
+	})
+
+	t.Run("missing discovery", func(t *testing.T) {
+		lookup := secretLookup()
+		lookup.Discovery = nil
+		err := newTaskValidator(withLookup(lookup)).ValidateStructure()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "spec.preconditions[0].resourceLookup.discovery is required")
+	})
+
+	t.Run("mutually exclusive with apiCall", func(t *testing.T) {
+		cfg := withLookup(secretLookup())
+		cfg.Spec.Preconditions[0].APICall = &APICall{Method: "GET", URL: "/clusters"}
+		err := newTaskValidator(cfg).ValidateStructure()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "mutually exclusive")
+	})
+
+	t.Run("maestro transport without maestro config", func(t *testing.T) {
+		lookup := secretLookup()
+		lookup.Transport = &TransportConfig{Client: TransportClientMaestro}
+		v := newTaskValidator(withLookup(lookup))
+		require.NoError(t, v.ValidateStructure())
+		err := v.ValidateSemantic()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "spec.preconditions[0].resourceLookup.transport: maestro transport config is required")
+	})
+
+	t.Run("undefined template variable", func(t *testing.T) {
+		lookup := secretLookup()
+		lookup.Discovery.Namespace = "{{ .tenantNamespace }}"
+		err := newTaskValidator(withLookup(lookup)).ValidateSemantic()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "spec.preconditions[0].resourceLookup.discovery.namespace")
+		assert.Contains(t, err.Error(), "tenantNamespace")
+	})
+
+	t.Run("lookup transport is in use", func(t *testing.T) {
+		lookup := secretLookup()
+		lookup.Transport = &TransportConfig{
+			Client:     TransportClientKubernetes,
+			Kubernetes: &KubernetesTransportConfig{Cluster: "{{ .clusterName }}"},
+		}
+		cfg := &Config{Spec: ConfigSpec{Preconditions: withLookup(lookup).Spec.Preconditions}}
+		assert.True(t, cfg.UsesTransportClient(TransportClientKubernetes))
+		assert.False(t, cfg.UsesTransportClient(TransportClientMaestro))
+		assert.True(t, cfg.UsesClusterTargets())
+	})
+}
+
+func TestValidateCaptureModeAndType(t *testing.T) {
+	withCapture := func(capture CaptureField) *AdapterTaskConfig {
+		cfg := baseTaskConfig()
+		cfg.Spec.Preconditions = []Precondition{{
+			ActionBase: ActionBase{Name: "statuses", APICall: &APICall{Method: "GET", URL: "/statuses"}},
+			Capture:    []CaptureField{capture},
+		}}
+		return cfg
+	}
+	readyAdapters := func() CaptureField {
+		return CaptureField{
+			Name:               "readyAdapters",
+			FieldExpressionDef: FieldExpressionDef{Field: "{.items[?(@.ready==true)].adapter}"},
+		}
+	}
+
+	t.Run("valid mode, type and required", func(t *testing.T) {
+		capture := readyAdapters()
+		capture.Mode = "count"
+		capture.Type = "int"
+		capture.Required = true
+		v := newTaskValidator(withCapture(capture))
+		require.NoError(t, v.ValidateStructure())
+		require.NoError(t, v.ValidateSemantic())
+	})
+
+	t.Run("invalid mode", func(t *testing.T) {
+		capture := readyAdapters()
+		capture.Mode = "last"
+		err := newTaskValidator(withCapture(capture)).ValidateStructure()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `spec.preconditions[0].capture[0].mode "last" is invalid (allowed: first, all, count)`)
+	})
+
+	t.Run("unsupported type", func(t *testing.T) {
+		capture := readyAdapters()
+		capture.Type = "set"
+		v := newTaskValidator(withCapture(capture))
+		require.NoError(t, v.ValidateStructure())
+		err := v.ValidateSemantic()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `spec.preconditions[0].capture[0].type: unsupported type "set"`)
+	})
+
+	t.Run("valid default", func(t *testing.T) {
+		capture := readyAdapters()
+		capture.Mode = "first"
+		capture.Type = "int"
+		capture.Default = "0"
+		v := newTaskValidator(withCapture(capture))
+		require.NoError(t, v.ValidateStructure())
+		require.NoError(t, v.ValidateSemantic())
+	})
+
+	t.Run("default not convertible to the type", func(t *testing.T) {
+		capture := readyAdapters()
+		capture.Type = "int"
+		capture.Default = "none"
+		v := newTaskValidator(withCapture(capture))
+		require.NoError(t, v.ValidateStructure())
+		err := v.ValidateSemantic()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `spec.preconditions[0].capture[0].default: invalid default for type "int"`)
+	})
+
+	t.Run("default of a required capture", func(t *testing.T) {
+		capture := readyAdapters()
+		capture.Required = true
+		capture.Default = []interface{}{}
+		v := newTaskValidator(withCapture(capture))
+		require.NoError(t, v.ValidateStructure())
+		err := v.ValidateSemantic()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "spec.preconditions[0].capture[0].default: a required capture cannot have a default")
+	})
+}
+
+func TestPreconditionRetryDelay(t *testing.T) {
+	assert.Equal(t, 1, (&Precondition{}).MaxAttempts())
+	assert.Equal(t, 4, (&Precondition{Retry: &PreconditionRetry{Attempts: 4}}).MaxAttempts())
+
+	tests := []struct {
+		name     string
+		retry    PreconditionRetry
+		expected []time.Duration
+	}{
+		{"default interval", PreconditionRetry{}, []time.Duration{5 * time.Second, 5 * time.Second}},
+		{"constant", PreconditionRetry{Interval: "2s", Backoff: "constant"}, []time.Duration{2 * time.Second, 2 * time.Second, 2 * time.Second}},
+		{"linear", PreconditionRetry{Interval: "2s", Backoff: "linear"}, []time.Duration{2 * time.Second, 4 * time.Second, 6 * time.Second}},
+		{"exponential", PreconditionRetry{Interval: "2s", Backoff: "exponential"}, []time.Duration{2 * time.Second, 4 * time.Second, 8 * time.Second}},
+		{"capped at max interval", PreconditionRetry{Interval: "2s", MaxInterval: "5s", Backoff: "exponential"}, []time.Duration{2 * time.Second, 4 * time.Second, 5 * time.Second}},
+		{"capped at default max interval", PreconditionRetry{Interval: "1m", Backoff: "linear"}, []time.Duration{time.Minute, 2 * time.Minute, 3 * time.Minute, 4 * time.Minute, 5 * time.Minute, 5 * time.Minute}},
+		{"interval above default max interval", PreconditionRetry{Interval: "10m", Backoff: "exponential"}, []time.Duration{10 * time.Minute, 10 * time.Minute}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i, expected := range tt.expected {
+				assert.Equal(t, expected, tt.retry.Delay(i+1), "delay after attempt %d", i+1)
+			}
+		})
+	}
+
+	t.Run("large attempt does not overflow", func(t *testing.T) {
+		retry := PreconditionRetry{Interval: "1s", Backoff: "exponential"}
+		assert.Equal(t, DefaultPreconditionRetryMaxInterval, retry.Delay(100))
+		assert.Equal(t, DefaultPreconditionRetryMaxInterval, retry.Delay(10000))
+	})
 }
 
 func TestValidateTemplateVariables(t *testing.T) {
@@ -155,102 +658,327 @@ func TestValidateTemplateVariables(t *testing.T) {
 		require.NoError(t, v.ValidateSemantic())
 	})
 
-	t.Run("undefined variable in URL", func(t *testing.T) {
+	t.Run("undefined variable in URL", func(t *testing.T) {
+		cfg := baseTaskConfig()
+		cfg.Spec.Params = []Parameter{{Name: "clusterId", Source: "event.id"}}
+		cfg.Spec.Preconditions = []Precondition{{
+			ActionBase: ActionBase{
+				Name:    "checkCluster",
+				APICall: &APICall{Method: "GET", URL: "{{ .undefinedVar }}/clusters/{{ .clusterId }}"},
+			},
+		}}
+		v := newTaskValidator(cfg)
+		_ = v.ValidateStructure()
+		err := v.ValidateSemantic()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "undefined template variable \"undefinedVar\"")
+	})
+
+	t.Run("undefined variable in resource manifest", func(t *testing.T) {
+		cfg := baseTaskConfig()
+		cfg.Spec.Params = []Parameter{{Name: "clusterId", Source: "event.id"}}
+		cfg.Spec.Resources = []Resource{{
+			Name: "testNs",
+			Manifest: map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "Namespace",
+				"metadata":   map[string]interface{}{"name": "ns-{{ .undefinedVar }}"},
+			},
+			Discovery: &DiscoveryConfig{Namespace: "*", ByName: "ns-{{ .clusterId }}"},
+		}}
+		v := newTaskValidator(cfg)
+		_ = v.ValidateStructure()
+		err := v.ValidateSemantic()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "undefined template variable \"undefinedVar\"")
+	})
+
+	t.Run("captured variable is available for resources", func(t *testing.T) {
+		cfg := baseTaskConfig()
+		cfg.Spec.Params = []Parameter{{Name: "apiUrl", Source: "env.API_URL"}}
+		cfg.Spec.Preconditions = []Precondition{{
+			ActionBase: ActionBase{
+				Name:    "getCluster",
+				APICall: &APICall{Method: "GET", URL: "{{ .apiUrl }}/clusters"},
+			},
+			Capture: []CaptureField{{Name: "clusterName", FieldExpressionDef: FieldExpressionDef{Field: "metadata.name"}}},
+		}}
+		cfg.Spec.Resources = []Resource{{
+			Name: "testNs",
+			Manifest: map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "Namespace",
+				"metadata":   map[string]interface{}{"name": "ns-{{ .clusterName }}"},
+			},
+			Discovery: &DiscoveryConfig{Namespace: "*", ByName: "ns-{{ .clusterName }}"},
+		}}
+		v := newTaskValidator(cfg)
+		require.NoError(t, v.ValidateStructure())
+		require.NoError(t, v.ValidateSemantic())
+	})
+}
+
+func TestValidateCELExpressions(t *testing.T) {
+	// Helper to create config with a CEL expression precondition
+	withExpression := func(expr string) *AdapterTaskConfig {
+		cfg := baseTaskConfig()
+		cfg.Spec.Params = []Parameter{
+			{Name: "clusterPhase", Source: "event.phase"},
+			{Name: "cluster", Source: "event.cluster"},
+		}
+		cfg.Spec.Preconditions = []Precondition{{ActionBase: ActionBase{Name: "check"}, Expression: expr}}
+		return cfg
+	}
+
+	t.Run("valid CEL expression", func(t *testing.T) {
+		cfg := withExpression(`clusterPhase == "Ready" || clusterPhase == "Provisioning"`)
+		v := newTaskValidator(cfg)
+		require.NoError(t, v.ValidateStructure())
+		require.NoError(t, v.ValidateSemantic())
+	})
+
+	t.Run("invalid CEL expression - syntax error", func(t *testing.T) {
+		cfg := withExpression(`clusterPhase ==== "Ready"`)
+		v := newTaskValidator(cfg)
+		_ = v.ValidateStructure()
+		err := v.ValidateSemantic()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "CEL parse error")
+	})
+
+	t.Run("valid CEL with has() function", func(t *testing.T) {
+		cfg := withExpression(`has(cluster.status) && cluster.status.phase == "Ready"`)
+		v := newTaskValidator(cfg)
+		require.NoError(t, v.ValidateStructure())
+		require.NoError(t, v.ValidateSemantic())
+	})
+
+	t.Run("valid CEL with HyperFleet library functions", func(t *testing.T) {
+		cfg := withExpression(`conditionStatus(cluster, "Ready") == "True" && semver.satisfies(clusterPhase, ">= 4.14") && age(cluster) > duration("1h")`)
+		v := newTaskValidator(cfg)
+		require.NoError(t, v.ValidateStructure())
+		require.NoError(t, v.ValidateSemantic())
+	})
+
+	t.Run("invalid CEL expression - type error", func(t *testing.T) {
+		cfg := withExpression(`"a" + 1 == "a1"`)
+		v := newTaskValidator(cfg)
+		_ = v.ValidateStructure()
+		err := v.ValidateSemantic()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "CEL type error")
+	})
+
+	t.Run("undeclared reference", func(t *testing.T) {
+		cfg := withExpression(`clusterStatus == "Ready"`)
+		v := newTaskValidator(cfg)
+		require.NoError(t, v.ValidateStructure())
+		err := v.ValidateSemantic()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "undeclared reference to 'clusterStatus'")
+	})
+
+	t.Run("task variables", func(t *testing.T) {
+		cfg := withExpression(`resources.namespace.status.phase == "Active" && adapter.executionStatus == "success"`)
+		cfg.Spec.Resources = []Resource{{
+			Name:      "namespace",
+			Manifest:  map[string]interface{}{"apiVersion": "v1", "kind": "Namespace", "metadata": map[string]interface{}{"name": "test"}},
+			Discovery: &DiscoveryConfig{Namespace: "*", ByName: "test"},
+		}}
+		v := newTaskValidator(cfg)
+		require.NoError(t, v.ValidateStructure())
+		require.NoError(t, v.ValidateSemantic())
+	})
+
+	t.Run("event is only declared for match and param expressions", func(t *testing.T) {
+		cfg := withExpression(`event.kind == "Cluster"`)
+		cfg.Spec.Match = &TaskMatch{Expression: `eventType == "cluster.updated" && event.kind == "Cluster"`}
+		v := newTaskValidator(cfg)
+		require.NoError(t, v.ValidateStructure())
+		err := v.ValidateSemantic()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "spec.preconditions[0].expression: CEL type error: undeclared reference to 'event'")
+		assert.NotContains(t, err.Error(), "spec.match")
+	})
+
+	t.Run("capture expressions read the API response", func(t *testing.T) {
+		cfg := baseTaskConfig()
+		cfg.Spec.Preconditions = []Precondition{{
+			ActionBase: ActionBase{
+				Name:    "getCluster",
+				APICall: &APICall{Method: "GET", URL: "http://api/clusters"},
+			},
+			Capture: []CaptureField{{Name: "ready", FieldExpressionDef: FieldExpressionDef{
+				Expression: `status.conditions.exists(c, c.type == "Ready")`,
+			}}},
+		}}
+		v := newTaskValidator(cfg)
+		require.NoError(t, v.ValidateStructure())
+		require.NoError(t, v.ValidateSemantic())
+	})
+
+	t.Run("checked expressions are compiled into the program cache", func(t *testing.T) {
+		programs, err := criteria.NewProgramCache(criteria.CELLimits{})
+		require.NoError(t, err)
+		cfg := withExpression(`clusterPhase == "Ready"`)
+		v := newTaskValidator(cfg)
+		v.programs = programs
+		require.NoError(t, v.ValidateStructure())
+		require.NoError(t, v.ValidateSemantic())
+
+		evalCtx := criteria.NewEvaluationContext()
+		evalCtx.Set("clusterPhase", "Ready")
+		criteria.UsePrograms(programs)
+		t.Cleanup(func() { criteria.UsePrograms(nil) })
+		evaluator, err := criteria.NewEvaluator(context.Background(), evalCtx, logger.NewTestLogger())
+		require.NoError(t, err)
+		result, err := evaluator.EvaluateCEL(`clusterPhase == "Ready"`)
+		require.NoError(t, err)
+		assert.True(t, result.Matched)
+	})
+}
+
+func TestValidateParamExpressions(t *testing.T) {
+	t.Run("valid expression params", func(t *testing.T) {
+		cfg := baseTaskConfig()
+		cfg.Spec.Params = []Parameter{
+			{Name: "clusterName", Source: "event.name"},
+			{Name: "namespace", Expression: `shortName + "-ns"`},
+			{Name: "shortName", Expression: `clusterName.lowerAscii().substring(0, 8)`},
+		}
+		v := newTaskValidator(cfg)
+		require.NoError(t, v.ValidateStructure())
+		require.NoError(t, v.ValidateSemantic())
+	})
+
+	t.Run("source and expression are mutually exclusive", func(t *testing.T) {
+		cfg := baseTaskConfig()
+		cfg.Spec.Params = []Parameter{{Name: "ns", Source: "event.name", Expression: `"x"`}}
+		err := newTaskValidator(cfg).ValidateStructure()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "mutually exclusive")
+	})
+
+	t.Run("invalid expression syntax", func(t *testing.T) {
+		cfg := baseTaskConfig()
+		cfg.Spec.Params = []Parameter{{Name: "ns", Expression: `event.name ==== "x"`}}
+		v := newTaskValidator(cfg)
+		require.NoError(t, v.ValidateStructure())
+		err := v.ValidateSemantic()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "spec.params[0].expression")
+		assert.Contains(t, err.Error(), "CEL parse error")
+	})
+
+	t.Run("circular dependency", func(t *testing.T) {
 		cfg := baseTaskConfig()
-		cfg.Spec.Params = []Parameter{{Name: "clusterId", Source: "event.id"}}
-		cfg.Spec.Preconditions = []Precondition{{
-			ActionBase: ActionBase{
-				Name:    "checkCluster",
-				APICall: &APICall{Method: "GET", URL: "{{ .undefinedVar }}/clusters/{{ .clusterId }}"},
-			},
-		}}
+		cfg.Spec.Params = []Parameter{
+			{Name: "a", Expression: `b + "x"`},
+			{Name: "b", Expression: `a + "y"`},
+		}
 		v := newTaskValidator(cfg)
-		_ = v.ValidateStructure()
+		require.NoError(t, v.ValidateStructure())
 		err := v.ValidateSemantic()
 		require.Error(t, err)
-		assert.Contains(t, err.Error(), "undefined template variable \"undefinedVar\"")
+		assert.Contains(t, err.Error(), "circular dependency")
 	})
 
-	t.Run("undefined variable in resource manifest", func(t *testing.T) {
+	t.Run("env read without field selection", func(t *testing.T) {
 		cfg := baseTaskConfig()
-		cfg.Spec.Params = []Parameter{{Name: "clusterId", Source: "event.id"}}
-		cfg.Spec.Resources = []Resource{{
-			Name: "testNs",
-			Manifest: map[string]interface{}{
-				"apiVersion": "v1",
-				"kind":       "Namespace",
-				"metadata":   map[string]interface{}{"name": "ns-{{ .undefinedVar }}"},
-			},
-			Discovery: &DiscoveryConfig{Namespace: "*", ByName: "ns-{{ .clusterId }}"},
-		}}
+		cfg.Spec.Params = []Parameter{
+			{Name: "region", Expression: `env.REGION`},
+			{Name: "token", Expression: `env["API_TOKEN"]`},
+			{Name: "all", Expression: `string(env)`},
+		}
 		v := newTaskValidator(cfg)
-		_ = v.ValidateStructure()
+		require.NoError(t, v.ValidateStructure())
 		err := v.ValidateSemantic()
 		require.Error(t, err)
-		assert.Contains(t, err.Error(), "undefined template variable \"undefinedVar\"")
+		assert.NotContains(t, err.Error(), "spec.params[0].expression")
+		assert.Contains(t, err.Error(), "spec.params[1].expression")
+		assert.Contains(t, err.Error(), "spec.params[2].expression")
+		assert.Contains(t, err.Error(), `can only be read as "env.NAME"`)
 	})
+}
 
-	t.Run("captured variable is available for resources", func(t *testing.T) {
+func TestValidateParamTypesAndSchemas(t *testing.T) {
+	t.Run("rich types are supported", func(t *testing.T) {
 		cfg := baseTaskConfig()
-		cfg.Spec.Params = []Parameter{{Name: "apiUrl", Source: "env.API_URL"}}
-		cfg.Spec.Preconditions = []Precondition{{
-			ActionBase: ActionBase{
-				Name:    "getCluster",
-				APICall: &APICall{Method: "GET", URL: "{{ .apiUrl }}/clusters"},
-			},
-			Capture: []CaptureField{{Name: "clusterName", FieldExpressionDef: FieldExpressionDef{Field: "metadata.name"}}},
-		}}
-		cfg.Spec.Resources = []Resource{{
-			Name: "testNs",
-			Manifest: map[string]interface{}{
-				"apiVersion": "v1",
-				"kind":       "Namespace",
-				"metadata":   map[string]interface{}{"name": "ns-{{ .clusterName }}"},
-			},
-			Discovery: &DiscoveryConfig{Namespace: "*", ByName: "ns-{{ .clusterName }}"},
-		}}
+		cfg.Spec.Params = []Parameter{
+			{Name: "labels", Source: "event.spec.labels", Type: "map"},
+			{Name: "zones", Source: "event.spec.zones", Type: "list"},
+			{Name: "timeout", Source: "env.TIMEOUT", Type: "duration"},
+			{Name: "memory", Source: "event.spec.memory", Type: "quantity"},
+			{Name: "raw", Source: "event.spec.raw", Type: "json",
+				Schema: map[string]interface{}{"type": "object", "required": []interface{}{"name"}}},
+		}
 		v := newTaskValidator(cfg)
 		require.NoError(t, v.ValidateStructure())
 		require.NoError(t, v.ValidateSemantic())
 	})
-}
 
-func TestValidateCELExpressions(t *testing.T) {
-	// Helper to create config with a CEL expression precondition
-	withExpression := func(expr string) *AdapterTaskConfig {
+	t.Run("unsupported type", func(t *testing.T) {
 		cfg := baseTaskConfig()
-		cfg.Spec.Preconditions = []Precondition{{ActionBase: ActionBase{Name: "check"}, Expression: expr}}
-		return cfg
-	}
-
-	t.Run("valid CEL expression", func(t *testing.T) {
-		cfg := withExpression(`clusterPhase == "Ready" || clusterPhase == "Provisioning"`)
+		cfg.Spec.Params = []Parameter{{Name: "x", Source: "event.x", Type: "tuple"}}
 		v := newTaskValidator(cfg)
 		require.NoError(t, v.ValidateStructure())
-		require.NoError(t, v.ValidateSemantic())
+		err := v.ValidateSemantic()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "spec.params[0].type")
 	})
 
-	t.Run("invalid CEL expression - syntax error", func(t *testing.T) {
-		cfg := withExpression(`clusterPhase ==== "Ready"`)
+	t.Run("invalid schema", func(t *testing.T) {
+		cfg := baseTaskConfig()
+		cfg.Spec.Params = []Parameter{{Name: "x", Source: "event.x",
+			Schema: map[string]interface{}{"type": 42}}}
 		v := newTaskValidator(cfg)
-		_ = v.ValidateStructure()
+		require.NoError(t, v.ValidateStructure())
 		err := v.ValidateSemantic()
 		require.Error(t, err)
-		assert.Contains(t, err.Error(), "CEL parse error")
+		assert.Contains(t, err.Error(), "spec.params[0].schema")
 	})
 
-	t.Run("valid CEL with has() function", func(t *testing.T) {
-		cfg := withExpression(`has(cluster.status) && cluster.status.phase == "Ready"`)
+	t.Run("unsupported schema keyword", func(t *testing.T) {
+		cfg := baseTaskConfig()
+		cfg.Spec.Params = []Parameter{{Name: "x", Source: "event.x", Type: "map",
+			Schema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"tier": map[string]interface{}{"type": "string", "const": "gold"},
+				},
+			}}}
 		v := newTaskValidator(cfg)
 		require.NoError(t, v.ValidateStructure())
-		require.NoError(t, v.ValidateSemantic())
+		err := v.ValidateSemantic()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "spec.params[0].schema")
+		assert.Contains(t, err.Error(), `unsupported keyword "properties.tier.const"`)
 	})
 }
 
+func TestOrderExpressionParams(t *testing.T) {
+	params := []Parameter{
+		{Name: "fullName", Expression: `prefix + "-" + shortName`},
+		{Name: "clusterId", Source: "event.id"},
+		{Name: "shortName", Expression: `clusterId.substring(0, 4)`},
+		{Name: "prefix", Expression: `"hf"`},
+	}
+
+	ordered, err := OrderExpressionParams(params)
+	require.NoError(t, err)
+
+	names := make([]string, len(ordered))
+	for i, p := range ordered {
+		names[i] = p.Name
+	}
+	assert.Equal(t, []string{"prefix", "shortName", "fullName"}, names)
+}
+
 func TestValidateK8sManifests(t *testing.T) {
 	// Helper to create config with a resource manifest
 	withResource := func(manifest map[string]interface{}) *AdapterTaskConfig {
 		cfg := baseTaskConfig()
+		cfg.Spec.Params = []Parameter{{Name: "nodeCount", Source: "event.nodeCount", Type: "int"}}
 		cfg.Spec.Resources = []Resource{{
 			Name:      "testResource",
 			Manifest:  manifest,
@@ -273,6 +1001,39 @@ func TestValidateK8sManifests(t *testing.T) {
 		require.NoError(t, v.ValidateSemantic())
 	})
 
+	t.Run("valid manifest expressions", func(t *testing.T) {
+		cfg := withResource(map[string]interface{}{
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
+			"metadata":   map[string]interface{}{"name": "test"},
+			"spec": map[string]interface{}{
+				"replicas": map[string]interface{}{ManifestExpressionTag: "nodeCount > 0 ? nodeCount : 1"},
+			},
+		})
+		v := newTaskValidator(cfg)
+		require.NoError(t, v.ValidateStructure())
+		require.NoError(t, v.ValidateSemantic())
+	})
+
+	t.Run("invalid manifest expression syntax", func(t *testing.T) {
+		cfg := withResource(map[string]interface{}{
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
+			"metadata":   map[string]interface{}{"name": "test"},
+			"spec": map[string]interface{}{
+				"template": map[string]interface{}{
+					"args": []interface{}{map[string]interface{}{ManifestExpressionTag: "args +"}},
+				},
+			},
+		})
+		v := newTaskValidator(cfg)
+		require.NoError(t, v.ValidateStructure())
+		err := v.ValidateSemantic()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "spec.resources[0].manifest.spec.template.args[0]")
+		assert.Contains(t, err.Error(), "CEL parse error")
+	})
+
 	t.Run("missing apiVersion in manifest", func(t *testing.T) {
 		cfg := withResource(map[string]interface{}{
 			"kind":     "Namespace",
@@ -339,6 +1100,93 @@ func TestValidateK8sManifests(t *testing.T) {
 	})
 }
 
+func TestValidatePatches(t *testing.T) {
+	withPatches := func(patches ...Patch) *AdapterTaskConfig {
+		cfg := baseTaskConfig()
+		cfg.Spec.Params = []Parameter{{Name: "platform", Source: "env.PLATFORM"}}
+		cfg.Spec.Resources = []Resource{{
+			Name: "testResource",
+			Manifest: map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "Namespace",
+				"metadata":   map[string]interface{}{"name": "test-namespace"},
+			},
+			Discovery: &DiscoveryConfig{Namespace: "*", ByName: "test"},
+			Patches:   patches,
+		}}
+		return cfg
+	}
+
+	tests := []struct {
+		name        string
+		patch       Patch
+		expectError string
+	}{
+		{
+			name: "valid json patch",
+			patch: Patch{
+				Type:  "json",
+				When:  `platform == "aws"`,
+				Patch: []interface{}{map[string]interface{}{"op": "add", "path": "/metadata/labels", "value": map[string]interface{}{"platform": "{{ .platform }}"}}},
+			},
+		},
+		{
+			name: "valid merge patch with target and expression",
+			patch: Patch{
+				Type:   "merge",
+				Target: &PatchTarget{Kind: "Namespace", Name: "test-{{ .platform }}"},
+				Patch:  map[string]interface{}{"metadata": map[string]interface{}{"labels": map[string]interface{}{ManifestExpressionTag: `{"platform": platform}`}}},
+			},
+		},
+		{
+			name:        "unknown patch type",
+			patch:       Patch{Type: "yaml", Patch: map[string]interface{}{}},
+			expectError: "type",
+		},
+		{
+			name:        "json patch must be a list",
+			patch:       Patch{Type: "json", Patch: map[string]interface{}{"op": "add"}},
+			expectError: "json patch must be a list of operations",
+		},
+		{
+			name:        "json patch operation without path",
+			patch:       Patch{Type: "json", Patch: []interface{}{map[string]interface{}{"op": "remove"}}},
+			expectError: `spec.resources[0].patches[0].patch[0]: json patch operation must have a string "path"`,
+		},
+		{
+			name:        "strategic patch must be an object",
+			patch:       Patch{Type: "strategic", Patch: []interface{}{"a"}},
+			expectError: "strategic patch must be an object",
+		},
+		{
+			name:        "invalid when expression",
+			patch:       Patch{Type: "merge", When: "platform ==", Patch: map[string]interface{}{}},
+			expectError: "spec.resources[0].patches[0].when",
+		},
+		{
+			name:        "undefined template variable in patch",
+			patch:       Patch{Type: "merge", Patch: map[string]interface{}{"metadata": map[string]interface{}{"name": "{{ .unknown }}"}}},
+			expectError: "undefined template variable",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := newTaskValidator(withPatches(tt.patch))
+			err := v.ValidateStructure()
+			if err == nil {
+				err = v.ValidateSemantic()
+			}
+			if tt.expectError == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expectError)
+		})
+	}
+}
+
 func TestValidOperators(t *testing.T) {
 	// Verify all expected operators are defined in criteria package
 	expectedOperators := []string{
@@ -586,6 +1434,169 @@ func TestValidateTransportConfig(t *testing.T) {
 		require.NoError(t, v.ValidateSemantic())
 	})
 
+	t.Run("kubernetes transport with target cluster", func(t *testing.T) {
+		cfg := baseTaskConfig()
+		cfg.Spec.Params = []Parameter{{Name: "clusterId", Source: "event.id"}}
+		cfg.Spec.Resources = []Resource{{
+			Name: "testNs",
+			Transport: &TransportConfig{
+				Client:     TransportClientKubernetes,
+				Kubernetes: &KubernetesTransportConfig{Cluster: "{{ .clusterId }}"},
+			},
+			Manifest: map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "Namespace",
+				"metadata":   map[string]interface{}{"name": "test"},
+			},
+			Discovery: &DiscoveryConfig{Namespace: "*", ByName: "test"},
+		}}
+		v := newTaskValidator(cfg)
+		require.NoError(t, v.ValidateStructure())
+		require.NoError(t, v.ValidateSemantic())
+
+		cfg.Spec.Resources[0].Transport.Kubernetes.Cluster = "{{ .undefinedParam }}"
+		v = newTaskValidator(cfg)
+		require.NoError(t, v.ValidateStructure())
+		err := v.ValidateSemantic()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "spec.resources[0].transport.kubernetes.cluster")
+	})
+
+	t.Run("kubernetes transport config with maestro client", func(t *testing.T) {
+		cfg := baseTaskConfig()
+		cfg.Spec.Resources = []Resource{{
+			Name: "testMW",
+			Transport: &TransportConfig{
+				Client:     TransportClientMaestro,
+				Maestro:    &MaestroTransportConfig{TargetCluster: "cluster1"},
+				Kubernetes: &KubernetesTransportConfig{Cluster: "cluster1"},
+			},
+			Manifest: map[string]interface{}{
+				"apiVersion": "work.open-cluster-management.io/v1",
+				"kind":       "ManifestWork",
+				"metadata":   map[string]interface{}{"name": "test-mw"},
+			},
+			Discovery: &DiscoveryConfig{ByName: "test-mw"},
+		}}
+		v := newTaskValidator(cfg)
+		require.NoError(t, v.ValidateStructure())
+		err := v.ValidateSemantic()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "only supported when client is \"kubernetes\"")
+	})
+
+	t.Run("nested discovery feedback", func(t *testing.T) {
+		cfg := baseTaskConfig()
+		cfg.Spec.Resources = []Resource{{
+			Name: "testMW",
+			Transport: &TransportConfig{
+				Client:  TransportClientMaestro,
+				Maestro: &MaestroTransportConfig{TargetCluster: "cluster1"},
+			},
+			Manifest: map[string]interface{}{
+				"apiVersion": "work.open-cluster-management.io/v1",
+				"kind":       "ManifestWork",
+				"metadata":   map[string]interface{}{"name": "test-mw"},
+			},
+			Discovery: &DiscoveryConfig{ByName: "test-mw"},
+			NestedDiscoveries: []NestedDiscovery{{
+				Name:      "deployment",
+				Discovery: &DiscoveryConfig{ByName: "web"},
+				Feedback: &FeedbackConfig{
+					JSONPaths: []FeedbackJSONPath{{Name: "readyReplicas", Path: ".status.readyReplicas"}},
+				},
+			}},
+		}}
+		v := newTaskValidator(cfg)
+		require.NoError(t, v.ValidateStructure())
+		require.NoError(t, v.ValidateSemantic())
+
+		cfg.Spec.Resources[0].NestedDiscoveries[0].Feedback.JSONPaths[0].Path = "status.readyReplicas"
+		v = newTaskValidator(cfg)
+		require.NoError(t, v.ValidateStructure())
+		err := v.ValidateSemantic()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "spec.resources[0].nestedDiscoveries[0].feedback.jsonPaths[0].path")
+
+		cfg.Spec.Resources[0].NestedDiscoveries[0].Feedback.JSONPaths[0].Path = ".status.readyReplicas"
+		cfg.Spec.Resources[0].Transport = nil
+		v = newTaskValidator(cfg)
+		require.NoError(t, v.ValidateStructure())
+		err = v.ValidateSemantic()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "feedback is only supported for maestro transport")
+	})
+
+	t.Run("delete option and deletion", func(t *testing.T) {
+		cfg := baseTaskConfig()
+		cfg.Spec.Resources = []Resource{{
+			Name: "testMW",
+			Transport: &TransportConfig{
+				Client: TransportClientMaestro,
+				Maestro: &MaestroTransportConfig{
+					TargetCluster: "cluster1",
+					DeleteOption:  &MaestroDeleteOption{PropagationPolicy: DeletePropagationSelectivelyOrphan},
+				},
+			},
+			Manifest: map[string]interface{}{
+				"apiVersion": "work.open-cluster-management.io/v1",
+				"kind":       "ManifestWork",
+				"metadata":   map[string]interface{}{"name": "test-mw"},
+			},
+			Discovery: &DiscoveryConfig{ByName: "test-mw"},
+			NestedDiscoveries: []NestedDiscovery{{
+				Name:      "namespace",
+				Discovery: &DiscoveryConfig{ByName: "apps"},
+				Orphan:    true,
+			}},
+			Delete: &DeleteConfig{When: "true", Wait: true, Timeout: "10m"},
+		}}
+		v := newTaskValidator(cfg)
+		require.NoError(t, v.ValidateStructure())
+		require.NoError(t, v.ValidateSemantic())
+
+		cfg.Spec.Resources[0].Transport.Maestro.DeleteOption.PropagationPolicy = DeletePropagationForeground
+		cfg.Spec.Resources[0].Delete.Timeout = "ten minutes"
+		v = newTaskValidator(cfg)
+		require.NoError(t, v.ValidateStructure())
+		err := v.ValidateSemantic()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "spec.resources[0].nestedDiscoveries[0].orphan")
+		assert.Contains(t, err.Error(), "spec.resources[0].delete.timeout")
+
+		cfg.Spec.Resources[0].NestedDiscoveries[0].Orphan = false
+		cfg.Spec.Resources[0].Delete.Timeout = ""
+		cfg.Spec.Resources[0].Transport = nil
+		v = newTaskValidator(cfg)
+		require.NoError(t, v.ValidateStructure())
+		err = v.ValidateSemantic()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "delete is only supported for maestro transport")
+	})
+
+	t.Run("driftDetection is not supported for maestro transport", func(t *testing.T) {
+		cfg := baseTaskConfig()
+		cfg.Spec.Resources = []Resource{{
+			Name: "testMW",
+			Transport: &TransportConfig{
+				Client:  TransportClientMaestro,
+				Maestro: &MaestroTransportConfig{TargetCluster: "cluster1"},
+			},
+			DriftDetection: true,
+			Manifest: map[string]interface{}{
+				"apiVersion": "work.open-cluster-management.io/v1",
+				"kind":       "ManifestWork",
+				"metadata":   map[string]interface{}{"name": "test-mw"},
+			},
+			Discovery: &DiscoveryConfig{ByName: "test-mw"},
+		}}
+		v := newTaskValidator(cfg)
+		require.NoError(t, v.ValidateStructure())
+		err := v.ValidateSemantic()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "spec.resources[0].driftDetection")
+	})
+
 	t.Run("valid maestro transport with inline manifest (ManifestWork)", func(t *testing.T) {
 		cfg := baseTaskConfig()
 		cfg.Spec.Resources = []Resource{{
@@ -30,6 +30,7 @@ var viperKeyMappings = map[string]string{
 	"spec::clients::maestro::timeout":                   "MAESTRO_TIMEOUT",
 	"spec::clients::maestro::retryAttempts":             "MAESTRO_RETRY_ATTEMPTS",
 	"spec::clients::maestro::insecure":                  "MAESTRO_INSECURE",
+	"spec::clients::maestro::statusWatch::enabled":      "MAESTRO_STATUS_WATCH_ENABLED",
 	"spec::clients::hyperfleetApi::baseUrl":             "API_BASE_URL",
 	"spec::clients::hyperfleetApi::version":             "API_VERSION",
 	"spec::clients::hyperfleetApi::timeout":             "API_TIMEOUT",
@@ -37,6 +38,8 @@ var viperKeyMappings = map[string]string{
 	"spec::clients::hyperfleetApi::retryBackoff":        "API_RETRY_BACKOFF",
 	"spec::clients::broker::subscriptionId":             "BROKER_SUBSCRIPTION_ID",
 	"spec::clients::broker::topic":                      "BROKER_TOPIC",
+	"spec::resync::enabled":                             "RESYNC_ENABLED",
+	"spec::resync::interval":                            "RESYNC_INTERVAL",
 }
 
 // cliFlags defines mappings from CLI flag names to config paths
@@ -0,0 +1,267 @@
+package criteria
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	apperrors "github.com/openshift-hyperfleet/hyperfleet-adapter/pkg/errors"
+)
+
+// celIdentifierRegex matches a CEL identifier, usable in a select expression (a.b)
+var celIdentifierRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// ConditionsToCEL translates structured conditions into the equivalent CEL expression,
+// the conjunction of the translated conditions.
+//
+// Example:
+//
+//	ConditionsToCEL([]ConditionDef{
+//		{Field: "status.phase", Operator: OperatorEquals, Value: "Ready"},
+//		{Field: "nodeCount", Operator: OperatorBetween, Value: []interface{}{1, 10}},
+//	}) // (status.phase == "Ready") && (nodeCount >= 1 && nodeCount <= 10)
+func ConditionsToCEL(conditions []ConditionDef) (string, error) {
+	parts := make([]string, 0, len(conditions))
+	for i, cond := range conditions {
+		expr, err := ConditionToCEL(cond)
+		if err != nil {
+			return "", apperrors.NewCELConditionConversionError(i, err)
+		}
+		parts = append(parts, "("+expr+")")
+	}
+	return strings.Join(parts, " && "), nil
+}
+
+// ConditionToCEL translates a structured condition into the equivalent CEL expression.
+// The field must be a dot-notation path; JSONPath fields have no CEL equivalent.
+// exists and notExists translate to presence tests (has() or a null check).
+// Timestamps, semantic versions and quantities use the HyperFleet CEL library (see CELLibrary).
+// anyOf, allOf and not groups translate to ||, && and ! over the nested conditions.
+func ConditionToCEL(cond ConditionDef) (string, error) {
+	switch {
+	case cond.Not != nil:
+		expr, err := ConditionToCEL(*cond.Not)
+		if err != nil {
+			return "", err
+		}
+		return "!(" + expr + ")", nil
+	case len(cond.AnyOf) > 0:
+		return groupToCEL(cond.AnyOf, " || ")
+	case len(cond.AllOf) > 0:
+		return groupToCEL(cond.AllOf, " && ")
+	}
+
+	field, err := fieldToCEL(cond.Field)
+	if err != nil {
+		return "", err
+	}
+
+	switch cond.Operator {
+	case OperatorExists:
+		return presenceToCEL(cond.Field)
+	case OperatorNotExists:
+		presence, err := presenceToCEL(cond.Field)
+		if err != nil {
+			return "", err
+		}
+		return "!(" + presence + ")", nil
+	case OperatorIsEmpty:
+		return fmt.Sprintf("size(%s) == 0", field), nil
+	case OperatorNotEmpty:
+		return fmt.Sprintf("size(%s) > 0", field), nil
+	}
+
+	value, err := formatCELValue(cond.Value)
+	if err != nil {
+		return "", err
+	}
+
+	switch cond.Operator {
+	case OperatorEquals:
+		return fmt.Sprintf("%s == %s", field, value), nil
+	case OperatorNotEquals:
+		return fmt.Sprintf("%s != %s", field, value), nil
+	case OperatorIn:
+		return fmt.Sprintf("%s in %s", field, value), nil
+	case OperatorNotIn:
+		return fmt.Sprintf("!(%s in %s)", field, value), nil
+	case OperatorContains:
+		if _, ok := cond.Value.(string); ok {
+			// A string needle is a substring of a string field, or an item of a list field
+			return fmt.Sprintf("(type(%s) == string ? %s.contains(%s) : %s in %s)", field, field, value, value, field), nil
+		}
+		return fmt.Sprintf("%s in %s", value, field), nil
+	case OperatorGreaterThan:
+		return fmt.Sprintf("%s > %s", field, value), nil
+	case OperatorLessThan:
+		return fmt.Sprintf("%s < %s", field, value), nil
+	case OperatorGreaterThanOrEqual:
+		return fmt.Sprintf("%s >= %s", field, value), nil
+	case OperatorLessThanOrEqual:
+		return fmt.Sprintf("%s <= %s", field, value), nil
+	case OperatorBetween:
+		minValue, maxValue, err := rangeBounds(cond.Value)
+		if err != nil {
+			return "", err
+		}
+		minLiteral, err := formatCELValue(minValue)
+		if err != nil {
+			return "", err
+		}
+		maxLiteral, err := formatCELValue(maxValue)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%s >= %s && %s <= %s", field, minLiteral, field, maxLiteral), nil
+	case OperatorMatches:
+		return fmt.Sprintf("%s.matches(%s)", field, value), nil
+	case OperatorStartsWith:
+		return fmt.Sprintf("%s.startsWith(%s)", field, value), nil
+	case OperatorEndsWith:
+		return fmt.Sprintf("%s.endsWith(%s)", field, value), nil
+	case OperatorOlderThan:
+		return fmt.Sprintf("age(%s) > duration(%s)", field, value), nil
+	case OperatorNewerThan:
+		return fmt.Sprintf("age(%s) <= duration(%s)", field, value), nil
+	case OperatorSemverAtLeast:
+		return fmt.Sprintf("semver.compare(%s, %s) >= 0", field, value), nil
+	default:
+		return "", apperrors.NewCELUnsupportedOperatorError(string(cond.Operator))
+	}
+}
+
+// groupToCEL translates nested conditions joined by a CEL logical operator
+func groupToCEL(conditions []ConditionDef, operator string) (string, error) {
+	parts := make([]string, 0, len(conditions))
+	for _, cond := range conditions {
+		expr, err := ConditionToCEL(cond)
+		if err != nil {
+			return "", err
+		}
+		parts = append(parts, "("+expr+")")
+	}
+	return strings.Join(parts, operator), nil
+}
+
+// fieldToCEL translates a dot-notation field path into a CEL select expression.
+// Segments that are not CEL identifiers (e.g. label keys with dashes) use index notation.
+func fieldToCEL(field string) (string, error) {
+	segments, err := fieldSegments(field)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	b.WriteString(segments[0])
+	for _, segment := range segments[1:] {
+		if celIdentifierRegex.MatchString(segment) {
+			b.WriteString("." + segment)
+		} else {
+			b.WriteString("[" + strconv.Quote(segment) + "]")
+		}
+	}
+	return b.String(), nil
+}
+
+// presenceToCEL translates a field path into a CEL presence test: has() for selected fields,
+// the in operator for indexed fields and a null check for root variables
+func presenceToCEL(field string) (string, error) {
+	segments, err := fieldSegments(field)
+	if err != nil {
+		return "", err
+	}
+	if len(segments) == 1 {
+		return segments[0] + " != null", nil
+	}
+	parent, err := fieldToCEL(strings.Join(segments[:len(segments)-1], "."))
+	if err != nil {
+		return "", err
+	}
+	last := segments[len(segments)-1]
+	if celIdentifierRegex.MatchString(last) {
+		return fmt.Sprintf("has(%s.%s)", parent, last), nil
+	}
+	return fmt.Sprintf("%s in %s", strconv.Quote(last), parent), nil
+}
+
+// fieldSegments splits a dot-notation field path, rejecting JSONPath expressions
+func fieldSegments(field string) ([]string, error) {
+	field = strings.TrimSpace(field)
+	if field == "" {
+		return nil, fmt.Errorf("field is required")
+	}
+	if strings.ContainsAny(field, "{}[]@$*?") {
+		return nil, fmt.Errorf("field %q is a JSONPath expression, which has no CEL equivalent", field)
+	}
+	segments := strings.Split(field, ".")
+	if !celIdentifierRegex.MatchString(segments[0]) {
+		return nil, fmt.Errorf("field %q must start with a variable name", field)
+	}
+	for _, segment := range segments {
+		if segment == "" {
+			return nil, fmt.Errorf("field %q has an empty segment", field)
+		}
+	}
+	return segments, nil
+}
+
+// formatCELValue formats a condition value as a CEL literal
+func formatCELValue(value interface{}) (string, error) {
+	if value == nil {
+		return "null", nil
+	}
+
+	switch v := value.(type) {
+	case string:
+		return strconv.Quote(v), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() { //nolint:exhaustive // other kinds have no CEL literal
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(rv.Uint(), 10) + "u", nil
+	case reflect.Float32, reflect.Float64:
+		if math.IsNaN(rv.Float()) || math.IsInf(rv.Float(), 0) {
+			return "", apperrors.NewCELUnsupportedTypeError(fmt.Sprintf("%T(%v)", value, value))
+		}
+		literal := strconv.FormatFloat(rv.Float(), 'g', -1, 64)
+		if !strings.ContainsAny(literal, ".e") {
+			literal += ".0"
+		}
+		return literal, nil
+	case reflect.Slice, reflect.Array:
+		items := make([]string, rv.Len())
+		for i := range items {
+			item, err := formatCELValue(rv.Index(i).Interface())
+			if err != nil {
+				return "", err
+			}
+			items[i] = item
+		}
+		return "[" + strings.Join(items, ", ") + "]", nil
+	case reflect.Map:
+		entries := make([]string, 0, rv.Len())
+		for _, key := range rv.MapKeys() {
+			k, err := formatCELValue(key.Interface())
+			if err != nil {
+				return "", err
+			}
+			item, err := formatCELValue(rv.MapIndex(key).Interface())
+			if err != nil {
+				return "", err
+			}
+			entries = append(entries, k+": "+item)
+		}
+		sort.Strings(entries)
+		return "{" + strings.Join(entries, ", ") + "}", nil
+	default:
+		return "", apperrors.NewCELUnsupportedTypeError(fmt.Sprintf("%T", value))
+	}
+}
@@ -0,0 +1,178 @@
+package criteria
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConditionToCEL(t *testing.T) {
+	tests := []struct {
+		name      string
+		cond      ConditionDef
+		want      string
+		wantError bool
+	}{
+		{
+			name: "equals string",
+			cond: ConditionDef{Field: "status.phase", Operator: OperatorEquals, Value: "Ready"},
+			want: `status.phase == "Ready"`,
+		},
+		{
+			name: "notIn list",
+			cond: ConditionDef{Field: "provider", Operator: OperatorNotIn, Value: []interface{}{"aws", "gcp"}},
+			want: `!(provider in ["aws", "gcp"])`,
+		},
+		{
+			name: "greaterThanOrEqual float",
+			cond: ConditionDef{Field: "nodeCount", Operator: OperatorGreaterThanOrEqual, Value: 3.0},
+			want: `nodeCount >= 3.0`,
+		},
+		{
+			name: "between",
+			cond: ConditionDef{Field: "nodeCount", Operator: OperatorBetween, Value: []interface{}{1, 10}},
+			want: `nodeCount >= 1 && nodeCount <= 10`,
+		},
+		{
+			name: "label key with dashes",
+			cond: ConditionDef{Field: "metadata.labels.app-name", Operator: OperatorStartsWith, Value: "web"},
+			want: `metadata.labels["app-name"].startsWith("web")`,
+		},
+		{
+			name: "exists nested",
+			cond: ConditionDef{Field: "status.vpcId", Operator: OperatorExists},
+			want: `has(status.vpcId)`,
+		},
+		{
+			name: "notExists root",
+			cond: ConditionDef{Field: "vpcId", Operator: OperatorNotExists},
+			want: `!(vpcId != null)`,
+		},
+		{
+			name: "olderThan",
+			cond: ConditionDef{Field: "cluster.created_time", Operator: OperatorOlderThan, Value: "1h"},
+			want: `age(cluster.created_time) > duration("1h")`,
+		},
+		{
+			name: "semverAtLeast",
+			cond: ConditionDef{Field: "version", Operator: OperatorSemverAtLeast, Value: "4.14.0"},
+			want: `semver.compare(version, "4.14.0") >= 0`,
+		},
+		{
+			name: "anyOf",
+			cond: ConditionDef{AnyOf: []ConditionDef{
+				{Field: "provider", Operator: OperatorEquals, Value: "aws"},
+				{Field: "provider", Operator: OperatorEquals, Value: "gcp"},
+			}},
+			want: `(provider == "aws") || (provider == "gcp")`,
+		},
+		{
+			name: "not allOf",
+			cond: ConditionDef{Not: &ConditionDef{AllOf: []ConditionDef{
+				{Field: "status.phase", Operator: OperatorEquals, Value: "Ready"},
+				{Field: "status.vpcId", Operator: OperatorExists},
+			}}},
+			want: `!((status.phase == "Ready") && (has(status.vpcId)))`,
+		},
+		{
+			name:      "group with JSONPath field",
+			cond:      ConditionDef{AnyOf: []ConditionDef{{Field: "{.items}", Operator: OperatorExists}}},
+			wantError: true,
+		},
+		{
+			name:      "JSONPath field",
+			cond:      ConditionDef{Field: "{.items[0].name}", Operator: OperatorEquals, Value: "a"},
+			wantError: true,
+		},
+		{
+			name:      "unsupported operator",
+			cond:      ConditionDef{Field: "a", Operator: "unknown", Value: "a"},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ConditionToCEL(tt.cond)
+			if tt.wantError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// TestConditionsToCELMatchesEvaluator checks that the translated expressions and the
+// structured evaluation agree on the same data
+func TestConditionsToCELMatchesEvaluator(t *testing.T) {
+	evalCtx := NewEvaluationContext()
+	evalCtx.Set("cluster", map[string]interface{}{
+		"name":         "prod-us-east-1",
+		"nodeCount":    int64(5),
+		"version":      "4.15.2",
+		"created_time": time.Now().Add(-2 * time.Hour).UTC().Format(time.RFC3339),
+		"zones":        []interface{}{"a", "b"},
+		"labels":       map[string]interface{}{"app-name": "web"},
+	})
+
+	conditions := []ConditionDef{
+		{Field: "cluster.name", Operator: OperatorContains, Value: "us-east"},
+		{Field: "cluster.zones", Operator: OperatorContains, Value: "b"},
+		{Field: "cluster.nodeCount", Operator: OperatorBetween, Value: []interface{}{1, 5}},
+		{Field: "cluster.nodeCount", Operator: OperatorGreaterThan, Value: 4.5},
+		{Field: "cluster.name", Operator: OperatorMatches, Value: "^prod-"},
+		{Field: "cluster.name", Operator: OperatorEndsWith, Value: "-1"},
+		{Field: "cluster.labels.app-name", Operator: OperatorIn, Value: []interface{}{"web", "api"}},
+		{Field: "cluster.zones", Operator: OperatorNotEmpty},
+		{Field: "cluster.vpcId", Operator: OperatorNotExists},
+		{Field: "cluster.created_time", Operator: OperatorOlderThan, Value: "1h"},
+		{Field: "cluster.created_time", Operator: OperatorNewerThan, Value: "1h"},
+		{Field: "cluster.version", Operator: OperatorSemverAtLeast, Value: "4.16.0"},
+	}
+
+	evaluator, err := NewEvaluator(context.Background(), evalCtx, logger.NewTestLogger())
+	require.NoError(t, err)
+
+	for _, cond := range conditions {
+		t.Run(string(cond.Operator)+" "+cond.Field, func(t *testing.T) {
+			structured, err := evaluator.EvaluateCondition(cond.Field, cond.Operator, cond.Value)
+			require.NoError(t, err)
+
+			expr, err := ConditionToCEL(cond)
+			require.NoError(t, err)
+			celResult, err := evaluator.EvaluateCEL(expr)
+			require.NoError(t, err)
+			require.NoError(t, celResult.Error)
+
+			assert.Equal(t, structured.Matched, celResult.Matched, "CEL: %s", expr)
+		})
+	}
+
+	expr, err := ConditionsToCEL(conditions[:3])
+	require.NoError(t, err)
+	celResult, err := evaluator.EvaluateCEL(expr)
+	require.NoError(t, err)
+	assert.True(t, celResult.Matched, "CEL: %s", expr)
+
+	groups := []ConditionDef{
+		{AnyOf: []ConditionDef{conditions[0], conditions[11]}},
+		{Not: &ConditionDef{AllOf: []ConditionDef{conditions[2], conditions[11]}}},
+	}
+	groupResult, err := evaluator.EvaluateConditions(groups)
+	require.NoError(t, err)
+	expr, err = ConditionsToCEL(groups)
+	require.NoError(t, err)
+	celResult, err = evaluator.EvaluateCEL(expr)
+	require.NoError(t, err)
+	assert.Equal(t, groupResult.Matched, celResult.Matched, "CEL: %s", expr)
+
+	_, err = ConditionsToCEL([]ConditionDef{conditions[0], {Field: "{.items}", Operator: OperatorExists}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to convert condition 1 to CEL")
+}
@@ -1,19 +1,29 @@
 package criteria
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/google/cel-go/cel"
+	celast "github.com/google/cel-go/common/ast"
 	"github.com/google/cel-go/common/types"
 	"github.com/google/cel-go/common/types/ref"
+	"github.com/google/cel-go/common/types/traits"
+	"github.com/google/cel-go/interpreter"
+	"github.com/google/cel-go/parser"
 	apperrors "github.com/openshift-hyperfleet/hyperfleet-adapter/pkg/errors"
 )
 
-// CELEvaluator evaluates CEL expressions against a context
+// CELEvaluator evaluates CEL expressions against a context.
+// Programs come from the shared program cache, so creating an evaluator is cheap.
+// Evaluations are bounded by the limits of the cache (see UsePrograms) and by ctx.
 type CELEvaluator struct {
-	env     *cel.Env
-	evalCtx *EvaluationContext
+	programs *ProgramCache
+	ctx      context.Context
+	evalCtx  *EvaluationContext
 }
 
 // CELResult contains the result of evaluating a CEL expression.
@@ -33,6 +43,18 @@ type CELResult struct {
 	// Error indicates if evaluation failed (nil if successful)
 	// Common causes: "field not found", "null value access", "type mismatch"
 	Error error
+	// Trace contains the values of the evaluated sub-expressions (only set by EvaluateTraced)
+	Trace []CELTraceEntry
+}
+
+// CELTraceEntry is the value of a sub-expression observed during a traced evaluation
+type CELTraceEntry struct {
+	// Expression is the sub-expression, e.g. "status.phase" or "size(items) > 0"
+	Expression string `json:"expression"`
+	// Value is the value of the sub-expression (nil if it failed)
+	Value interface{} `json:"value"`
+	// Error is the evaluation error of the sub-expression, if any
+	Error string `json:"error,omitempty"`
 }
 
 // HasError returns true if the evaluation resulted in an error
@@ -42,70 +64,26 @@ func (r *CELResult) HasError() bool {
 
 // newCELEvaluator creates a new CEL evaluator with the given context
 // NOTE: Caller (NewEvaluator) is responsible for parameter validation
-func newCELEvaluator(evalCtx *EvaluationContext) (*CELEvaluator, error) {
-	// Build CEL environment with variables from context
-	options := buildCELOptions(evalCtx)
-
-	env, err := cel.NewEnv(options...)
+func newCELEvaluator(ctx context.Context, evalCtx *EvaluationContext) (*CELEvaluator, error) {
+	programs, err := sharedPrograms()
 	if err != nil {
-		return nil, apperrors.NewCELEnvError("failed to initialize", err)
+		return nil, err
 	}
 
 	return &CELEvaluator{
-		env:     env,
-		evalCtx: evalCtx,
+		programs: programs,
+		ctx:      ctx,
+		evalCtx:  evalCtx,
 	}, nil
 }
 
-// buildCELOptions creates CEL environment options from the context
-// Variables are dynamically registered based on what's in ctx.Data()
-func buildCELOptions(ctx *EvaluationContext) []cel.EnvOption {
-	options := make([]cel.EnvOption, 0)
-
-	// Enable optional types for optional chaining syntax (e.g., a.?b.?c)
-	options = append(options, cel.OptionalTypes())
-
-	// Get a snapshot of the data for thread safety
-	data := ctx.Data()
-	for key, value := range data {
-		celType := inferCELType(value)
-		options = append(options, cel.Variable(key, celType))
-	}
-
-	return options
-}
-
-// inferCELType infers the CEL type from a Go value
-func inferCELType(value interface{}) *cel.Type {
-	if value == nil {
-		return cel.DynType
-	}
-
-	switch value.(type) {
-	case string:
-		return cel.StringType
-	case bool:
-		return cel.BoolType
-	case int, int8, int16, int32, int64:
-		return cel.IntType
-	case uint, uint8, uint16, uint32, uint64:
-		return cel.UintType
-	case float32, float64:
-		return cel.DoubleType
-	case []interface{}:
-		return cel.ListType(cel.DynType)
-	case map[string]interface{}:
-		return cel.MapType(cel.StringType, cel.DynType)
-	default:
-		return cel.DynType
-	}
-}
-
 // EvaluateSafe evaluates a CEL expression with safe handling for evaluation errors.
 //
 // Error handling strategy:
 //   - Parse errors: returned as error (fail fast - indicates bug in expression)
 //   - Program creation errors: returned as error (fail fast - indicates invalid expression)
+//   - Evaluations exceeding the cost limit or the timeout, or canceled with the context:
+//     returned as error (fail fast - the expression is too expensive for the data)
 //   - Evaluation errors: captured in CELResult.Error (safe - data might not exist yet)
 //
 // Use this when you expect that some fields might not exist or be null, and you want
@@ -116,6 +94,19 @@ func inferCELType(value interface{}) *cel.Type {
 //   - "null value access": when accessing a field on a null value
 //   - "type mismatch": when operations are applied to incompatible types
 func (e *CELEvaluator) EvaluateSafe(expression string) (*CELResult, error) {
+	return e.evaluate(expression, false)
+}
+
+// EvaluateTraced evaluates a CEL expression like EvaluateSafe, and records in CELResult.Trace the
+// values of its sub-expressions (fields, function calls, comparisons and macros) in pre-order.
+// Sub-expressions skipped by short-circuiting (e.g. the right side of a false &&) are not recorded.
+// Tracking the values makes the evaluation slower, so use it to explain a result.
+func (e *CELEvaluator) EvaluateTraced(expression string) (*CELResult, error) {
+	return e.evaluate(expression, true)
+}
+
+// evaluate evaluates an expression, tracking the values of its sub-expressions if traced
+func (e *CELEvaluator) evaluate(expression string, traced bool) (*CELResult, error) {
 	expression = strings.TrimSpace(expression)
 	if expression == "" {
 		return &CELResult{
@@ -126,45 +117,55 @@ func (e *CELEvaluator) EvaluateSafe(expression string) (*CELResult, error) {
 		}, nil
 	}
 
-	// Parse the expression - errors here indicate bugs in configuration
-	ast, issues := e.env.Parse(expression)
-	if issues != nil && issues.Err() != nil {
-		return nil, apperrors.NewCELParseError(expression, issues.Err())
-	}
-
-	// Safety check: ensure AST is valid after parse
-	if ast == nil {
-		return nil, apperrors.NewCELParseError(expression, nil)
+	// Get the compiled program - parse errors here indicate bugs in configuration
+	var prg cel.Program
+	var tracedPrg *tracedProgram
+	var err error
+	if traced {
+		tracedPrg, err = e.programs.tracedProgram(expression)
+		if tracedPrg != nil {
+			prg = tracedPrg.prg
+		}
+	} else {
+		prg, err = e.programs.program(expression)
 	}
-
-	// Create the program directly from parsed AST
-	// Skip type-check: we use DynType, so type errors are caught during evaluation
-	prg, err := e.env.Program(ast)
 	if err != nil {
-		return nil, apperrors.NewCELProgramError(expression, err)
+		return nil, err
 	}
 
 	// Evaluate the expression - errors here are SAFE (data might not exist yet)
 	// Get a snapshot of the data for thread-safe evaluation
-	out, _, err := prg.Eval(e.evalCtx.Data())
+	ctx, cancel := context.WithTimeout(e.ctx, e.programs.limits.Timeout)
+	defer cancel()
+	out, details, err := prg.ContextEval(ctx, e.evalCtx.Data())
 	if err != nil {
+		if limitErr := e.limitError(ctx, expression, err); limitErr != nil {
+			return nil, limitErr
+		}
 		// Capture evaluation error in result - this is the "safe" part
 		// These errors are expected when data fields don't exist yet
 		// Caller should handle logging based on CELResult.Error
-		return &CELResult{
+		result := &CELResult{
 			Value:      nil,
 			Matched:    false,
 			Expression: expression,
 			Error:      apperrors.NewCELEvalError(expression, err),
-		}, nil // No error returned - evaluation errors are captured in result
+		}
+		if traced {
+			result.Trace = traceEntries(tracedPrg.ast, details)
+		}
+		return result, nil // No error returned - evaluation errors are captured in result
 	}
 
 	// Convert result
 	result := &CELResult{
-		Value:      out.Value(),
+		Value:      nativeValue(out),
 		ValueType:  out.Type().TypeName(),
 		Expression: expression,
 	}
+	if traced {
+		result.Trace = traceEntries(tracedPrg.ast, details)
+	}
 
 	// Check if result is boolean true
 	// This is the most common use case for CEL expressions
@@ -181,6 +182,89 @@ func (e *CELEvaluator) EvaluateSafe(expression string) (*CELResult, error) {
 	return result, nil
 }
 
+// traceEntries lists the values recorded for the sub-expressions of a traced evaluation.
+// Literals are omitted, macros (e.g. exists()) are recorded as a whole, and a sub-expression
+// appearing several times is recorded once.
+func traceEntries(ast *cel.Ast, details *cel.EvalDetails) []CELTraceEntry {
+	if ast == nil || details == nil || details.State() == nil {
+		return nil
+	}
+	state := details.State()
+	native := ast.NativeRep()
+
+	var entries []CELTraceEntry
+	seen := make(map[string]bool)
+	visitTraceable(native.Expr(), func(expr celast.Expr) {
+		val, ok := state.Value(expr.ID())
+		if !ok {
+			return
+		}
+		text, err := parser.Unparse(expr, native.SourceInfo())
+		if err != nil || seen[text] {
+			return
+		}
+		seen[text] = true
+
+		entry := CELTraceEntry{Expression: text}
+		if types.IsError(val) {
+			entry.Error = fmt.Sprintf("%v", val)
+		} else {
+			entry.Value = nativeValue(val)
+		}
+		entries = append(entries, entry)
+	})
+	return entries
+}
+
+// visitTraceable visits the sub-expressions worth tracing in pre-order. Comprehension bodies
+// are not visited: their values change on each iteration.
+func visitTraceable(expr celast.Expr, visit func(celast.Expr)) {
+	switch expr.Kind() { //nolint:exhaustive // literals and struct creations are not traced
+	case celast.IdentKind:
+		visit(expr)
+	case celast.SelectKind:
+		visit(expr)
+		visitTraceable(expr.AsSelect().Operand(), visit)
+	case celast.CallKind:
+		visit(expr)
+		call := expr.AsCall()
+		if call.IsMemberFunction() {
+			visitTraceable(call.Target(), visit)
+		}
+		for _, arg := range call.Args() {
+			visitTraceable(arg, visit)
+		}
+	case celast.ComprehensionKind:
+		visit(expr)
+		visitTraceable(expr.AsComprehension().IterRange(), visit)
+	case celast.ListKind:
+		for _, elem := range expr.AsList().Elements() {
+			visitTraceable(elem, visit)
+		}
+	case celast.MapKind:
+		for _, entry := range expr.AsMap().Entries() {
+			visitTraceable(entry.AsMapEntry().Key(), visit)
+			visitTraceable(entry.AsMapEntry().Value(), visit)
+		}
+	}
+}
+
+// limitError returns the error of an evaluation stopped by the cost limit, the timeout or the
+// cancellation of the context, or nil for other evaluation errors
+func (e *CELEvaluator) limitError(ctx context.Context, expression string, err error) error {
+	var canceled interpreter.EvalCancelledError
+	if errors.As(err, &canceled) && canceled.Cause == interpreter.CostLimitExceeded {
+		return apperrors.NewCELCostExceededError(expression, e.programs.limits.CostLimit, err)
+	}
+	if ctx.Err() == nil {
+		return nil
+	}
+	if e.ctx.Err() != nil {
+		return apperrors.NewCELCanceledError(expression, err)
+	}
+	return apperrors.NewCELTimeoutError(expression, e.programs.limits.Timeout, err)
+}
+
 // EvaluateAs evaluates a CEL expression and returns the result as the specified type.
 // This is a type-safe generic function that handles all type assertions properly.
 // Returns an error if:
@@ -240,6 +324,119 @@ func (e *CELEvaluator) EvaluateMap(expression string) (map[string]any, error) {
 	return EvaluateAs[map[string]any](e, expression)
 }
 
+// ReferencedVariables returns the sorted root variable names referenced by a CEL expression.
+// Comprehension variables (e.g., "c" in "items.filter(c, c.ready)") are not included.
+// Only parsing is performed, so the referenced variables do not need to be declared.
+//
+// Example:
+//
+//	ReferencedVariables("clusterName.lowerAscii() + '-' + event.kind") // ["clusterName", "event"]
+func ReferencedVariables(expression string) ([]string, error) {
+	expression = strings.TrimSpace(expression)
+	if expression == "" {
+		return nil, nil
+	}
+
+	env, err := cel.NewEnv(CELLibrary())
+	if err != nil {
+		return nil, apperrors.NewCELEnvError("failed to initialize", err)
+	}
+
+	ast, issues := env.Parse(expression)
+	if issues != nil && issues.Err() != nil {
+		return nil, apperrors.NewCELParseError(expression, issues.Err())
+	}
+
+	// Namespaces of library functions (e.g. "semver" in semver.compare(a, b)) parse as identifiers
+	functions := env.Functions()
+	namespaces := make(map[int64]bool)
+
+	idents := make(map[string]bool)
+	localVars := make(map[string]bool)
+	celast.PreOrderVisit(ast.NativeRep().Expr(), celast.NewExprVisitor(func(e celast.Expr) {
+		switch e.Kind() { //nolint:exhaustive // only identifiers, calls and comprehensions declare names
+		case celast.CallKind:
+			call := e.AsCall()
+			if call.IsMemberFunction() && call.Target().Kind() == celast.IdentKind {
+				if _, ok := functions[call.Target().AsIdent()+"."+call.FunctionName()]; ok {
+					namespaces[call.Target().ID()] = true
+				}
+			}
+		case celast.IdentKind:
+			if namespaces[e.ID()] {
+				return
+			}
+			idents[e.AsIdent()] = true
+		case celast.ComprehensionKind:
+			comp := e.AsComprehension()
+			localVars[comp.IterVar()] = true
+			localVars[comp.AccuVar()] = true
+			if comp.HasIterVar2() {
+				localVars[comp.IterVar2()] = true
+			}
+		}
+	}))
+
+	names := make([]string, 0, len(idents))
+	for name := range idents {
+		if !localVars[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// SelectedFields returns the sorted field names a CEL expression selects on a variable
+// (e.g. "REGION" in "env.REGION"). ok is false when the variable is also used in another way,
+// such as "env['REGION']" or "env" alone, whose accessed fields cannot be known statically.
+//
+// Example:
+//
+//	SelectedFields("env.REGION + '-' + env.ZONE", "env") // ["REGION", "ZONE"], true
+func SelectedFields(expression, variable string) (fields []string, ok bool, err error) {
+	expression = strings.TrimSpace(expression)
+	if expression == "" {
+		return nil, true, nil
+	}
+
+	env, err := cel.NewEnv(CELLibrary())
+	if err != nil {
+		return nil, false, apperrors.NewCELEnvError("failed to initialize", err)
+	}
+
+	ast, issues := env.Parse(expression)
+	if issues != nil && issues.Err() != nil {
+		return nil, false, apperrors.NewCELParseError(expression, issues.Err())
+	}
+
+	selected := make(map[string]bool)
+	operands := make(map[int64]bool)
+	ok = true
+	celast.PreOrderVisit(ast.NativeRep().Expr(), celast.NewExprVisitor(func(e celast.Expr) {
+		switch e.Kind() { //nolint:exhaustive // only selections and identifiers reference the variable
+		case celast.SelectKind:
+			sel := e.AsSelect()
+			if sel.Operand().Kind() == celast.IdentKind && sel.Operand().AsIdent() == variable {
+				selected[sel.FieldName()] = true
+				operands[sel.Operand().ID()] = true
+			}
+		case celast.IdentKind:
+			// Pre-order visits a selection before its operand
+			if e.AsIdent() == variable && !operands[e.ID()] {
+				ok = false
+			}
+		}
+	}))
+
+	fields = make([]string, 0, len(selected))
+	for name := range selected {
+		fields = append(fields, name)
+	}
+	sort.Strings(fields)
+	return fields, ok, nil
+}
+
 // isEmptyValue checks if a CEL value is empty/nil
 func isEmptyValue(val ref.Val) bool {
 	if val == nil {
@@ -264,3 +461,47 @@ func isEmptyValue(val ref.Val) bool {
 		return false
 	}
 }
+
+// nativeValue converts a CEL value into plain Go values.
+// Lists and maps built inside an expression (e.g. "[a, b]" or "{'k': v}") hold CEL values
+// rather than Go values, so they are converted recursively into []interface{} and
+// map[string]interface{} to be safely marshaled to JSON/YAML.
+func nativeValue(val interface{}) interface{} {
+	switch v := val.(type) {
+	case nil:
+		return nil
+	case types.Null:
+		return nil
+	case traits.Mapper:
+		result := make(map[string]interface{})
+		it := v.Iterator()
+		for it.HasNext() == types.True {
+			key := it.Next()
+			result[fmt.Sprintf("%v", key.Value())] = nativeValue(v.Get(key))
+		}
+		return result
+	case traits.Lister:
+		size, _ := v.Size().(types.Int) //nolint:errcheck // Size() of a list is always an Int
+		result := make([]interface{}, 0, int(size))
+		for i := types.Int(0); i < size; i++ {
+			result = append(result, nativeValue(v.Get(i)))
+		}
+		return result
+	case ref.Val:
+		return nativeValue(v.Value())
+	case []ref.Val:
+		result := make([]interface{}, len(v))
+		for i, item := range v {
+			result[i] = nativeValue(item)
+		}
+		return result
+	case map[ref.Val]ref.Val:
+		result := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			result[fmt.Sprintf("%v", key.Value())] = nativeValue(item)
+		}
+		return result
+	default:
+		return val
+	}
+}
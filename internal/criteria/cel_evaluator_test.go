@@ -14,7 +14,7 @@ func TestNewCELEvaluator(t *testing.T) {
 	ctx.Set("status", "Ready")
 	ctx.Set("replicas", 3)
 
-	evaluator, err := newCELEvaluator(ctx)
+	evaluator, err := newCELEvaluator(context.Background(), ctx)
 	require.NoError(t, err)
 	require.NotNil(t, evaluator)
 }
@@ -26,7 +26,7 @@ func TestCELEvaluatorEvaluate(t *testing.T) {
 	ctx.Set("provider", "aws")
 	ctx.Set("enabled", true)
 
-	evaluator, err := newCELEvaluator(ctx)
+	evaluator, err := newCELEvaluator(context.Background(), ctx)
 	require.NoError(t, err)
 
 	tests := []struct {
@@ -130,7 +130,7 @@ func TestCELEvaluatorWithNestedData(t *testing.T) {
 		},
 	})
 
-	evaluator, err := newCELEvaluator(ctx)
+	evaluator, err := newCELEvaluator(context.Background(), ctx)
 	require.NoError(t, err)
 
 	// Test nested field access
@@ -155,7 +155,7 @@ func TestCELEvaluatorEvaluateSafe(t *testing.T) {
 	})
 	ctx.Set("nullValue", nil)
 
-	evaluator, err := newCELEvaluator(ctx)
+	evaluator, err := newCELEvaluator(context.Background(), ctx)
 	require.NoError(t, err)
 
 	t.Run("successful evaluation", func(t *testing.T) {
@@ -243,7 +243,7 @@ func TestCELEvaluatorEvaluateBool(t *testing.T) {
 	ctx := NewEvaluationContext()
 	ctx.Set("status", "Ready")
 
-	evaluator, err := newCELEvaluator(ctx)
+	evaluator, err := newCELEvaluator(context.Background(), ctx)
 	require.NoError(t, err)
 
 	// True result
@@ -262,7 +262,7 @@ func TestCELEvaluatorEvaluateString(t *testing.T) {
 	ctx.Set("status", "Ready")
 	ctx.Set("name", "test-cluster")
 
-	evaluator, err := newCELEvaluator(ctx)
+	evaluator, err := newCELEvaluator(context.Background(), ctx)
 	require.NoError(t, err)
 
 	// String result
@@ -302,7 +302,7 @@ func TestEvaluateSafeErrorHandling(t *testing.T) {
 		},
 	})
 
-	evaluator, err := newCELEvaluator(ctx)
+	evaluator, err := newCELEvaluator(context.Background(), ctx)
 	require.NoError(t, err)
 
 	tests := []struct {
@@ -365,3 +365,167 @@ func TestEvaluateSafeErrorHandling(t *testing.T) {
 		})
 	}
 }
+
+func TestCELEvaluatorNativeValues(t *testing.T) {
+	ctx := NewEvaluationContext()
+	ctx.Set("zones", []interface{}{"a", "b"})
+	ctx.Set("count", 2)
+
+	evaluator, err := newCELEvaluator(context.Background(), ctx)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		expression string
+		expected   interface{}
+	}{
+		{name: "list literal", expression: "[1, 'two', true]", expected: []interface{}{int64(1), "two", true}},
+		{name: "map literal with nested list", expression: "{'n': count, 'zones': [zones[0]]}",
+			expected: map[string]interface{}{"n": int64(2), "zones": []interface{}{"a"}}},
+		{name: "macro result", expression: "zones.map(z, z + '-x')", expected: []interface{}{"a-x", "b-x"}},
+		{name: "variable list", expression: "zones", expected: []interface{}{"a", "b"}},
+		{name: "null", expression: "null", expected: nil},
+		{name: "scalar", expression: "count * 2", expected: int64(4)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := evaluator.EvaluateSafe(tt.expression)
+			require.NoError(t, err)
+			require.NoError(t, result.Error)
+			assert.Equal(t, tt.expected, result.Value)
+		})
+	}
+}
+
+func TestCELEvaluatorEvaluateTraced(t *testing.T) {
+	ctx := NewEvaluationContext()
+	ctx.Set("status", map[string]interface{}{
+		"phase":      "Provisioning",
+		"conditions": []interface{}{map[string]interface{}{"type": "Ready", "status": "False"}},
+	})
+	ctx.Set("nodeCount", 3)
+
+	evaluator, err := newCELEvaluator(context.Background(), ctx)
+	require.NoError(t, err)
+
+	traceValues := func(trace []CELTraceEntry) map[string]interface{} {
+		values := make(map[string]interface{}, len(trace))
+		for _, entry := range trace {
+			values[entry.Expression] = entry.Value
+		}
+		return values
+	}
+
+	t.Run("records sub-expression values", func(t *testing.T) {
+		result, err := evaluator.EvaluateTraced(`nodeCount > 2 && status.phase == "Ready"`)
+		require.NoError(t, err)
+		assert.False(t, result.Matched)
+		require.NotEmpty(t, result.Trace)
+		assert.Equal(t, `nodeCount > 2 && status.phase == "Ready"`, result.Trace[0].Expression)
+
+		values := traceValues(result.Trace)
+		assert.Equal(t, true, values["nodeCount > 2"])
+		assert.Equal(t, int64(3), values["nodeCount"])
+		assert.Equal(t, false, values[`status.phase == "Ready"`])
+		assert.Equal(t, "Provisioning", values["status.phase"])
+	})
+
+	t.Run("records macros as a whole", func(t *testing.T) {
+		result, err := evaluator.EvaluateTraced(`status.conditions.exists(c, c.type == "Ready" && c.status == "True")`)
+		require.NoError(t, err)
+		assert.False(t, result.Matched)
+
+		values := traceValues(result.Trace)
+		assert.Equal(t, false, values[`status.conditions.exists(c, c.type == "Ready" && c.status == "True")`])
+		assert.Contains(t, values, "status.conditions")
+		assert.NotContains(t, values, "c.type")
+	})
+
+	t.Run("skips short-circuited sub-expressions", func(t *testing.T) {
+		result, err := evaluator.EvaluateTraced(`nodeCount > 5 && status.phase == "Ready"`)
+		require.NoError(t, err)
+		assert.NotContains(t, traceValues(result.Trace), "status.phase")
+	})
+
+	t.Run("records failing sub-expressions", func(t *testing.T) {
+		result, err := evaluator.EvaluateTraced(`status.missing == "x"`)
+		require.NoError(t, err)
+		require.Error(t, result.Error)
+		require.NotEmpty(t, result.Trace)
+		found := false
+		for _, entry := range result.Trace {
+			if entry.Expression == "status.missing" {
+				found = true
+				assert.Contains(t, entry.Error, "no such key")
+			}
+		}
+		assert.True(t, found, "status.missing should be traced")
+	})
+
+	t.Run("EvaluateSafe does not trace", func(t *testing.T) {
+		result, err := evaluator.EvaluateSafe(`nodeCount > 2`)
+		require.NoError(t, err)
+		assert.Nil(t, result.Trace)
+	})
+}
+
+func TestReferencedVariables(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		want       []string
+		wantErr    bool
+	}{
+		{name: "empty expression", expression: "", want: nil},
+		{name: "single variable", expression: `clusterName.lowerAscii()`, want: []string{"clusterName"}},
+		{name: "root of field selection", expression: `event.spec.region + "-" + env.ZONE`, want: []string{"env", "event"}},
+		{name: "comprehension variables excluded", expression: `items.filter(i, i.ready).size() > minReady`, want: []string{"items", "minReady"}},
+		{name: "optional chaining", expression: `cluster.?status.?phase.orValue("")`, want: []string{"cluster"}},
+		{name: "library namespaces excluded", expression: `semver.compare(version, "4.14.0") >= 0 && math.greatest(a, 1) > 0`, want: []string{"a", "version"}},
+		{name: "parse error", expression: `a ==== b`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReferencedVariables(tt.expression)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSelectedFields(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		want       []string
+		wantOK     bool
+		wantErr    bool
+	}{
+		{name: "empty expression", expression: "", want: nil, wantOK: true},
+		{name: "field selections", expression: `env.ZONE + "-" + env.REGION + env.ZONE`, want: []string{"REGION", "ZONE"}, wantOK: true},
+		{name: "variable not referenced", expression: `event.env.REGION`, want: []string{}, wantOK: true},
+		{name: "has macro", expression: `has(env.REGION) ? env.REGION : "global"`, want: []string{"REGION"}, wantOK: true},
+		{name: "index access", expression: `env.REGION + env["TOKEN"]`, want: []string{"REGION"}, wantOK: false},
+		{name: "bare variable", expression: `size(env) > 0`, want: []string{}, wantOK: false},
+		{name: "parse error", expression: `env ==== b`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok, err := SelectedFields(tt.expression, "env")
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantOK, ok)
+		})
+	}
+}
@@ -0,0 +1,260 @@
+package criteria
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	"github.com/google/cel-go/common/types/traits"
+	"github.com/google/cel-go/ext"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// Native types CEL maps and lists are converted to
+var (
+	mapType  = reflect.TypeOf(map[string]interface{}{})
+	listType = reflect.TypeOf([]interface{}{})
+)
+
+// conditionStatusMissing is returned by conditionStatus when the condition is not found
+const conditionStatusMissing = "False"
+
+// CELLibrary returns the HyperFleet CEL library shared by the runtime evaluator and the
+// config-time validator, so both accept the same expressions. It adds:
+//   - the cel-go extensions ext.Strings, ext.Encoders, ext.Math, ext.Lists and ext.Sets
+//   - conditionStatus(obj, type) and hasCondition(obj, type, status) for status conditions
+//   - semver.compare(a, b) and semver.satisfies(version, constraint)
+//   - now() and age(obj), next to the built-in duration() and timestamp()
+//   - quantity.compare(a, b) and quantity.value(q) for Kubernetes quantities
+//
+// Optional types (e.g. a.?b.?c) are enabled as well.
+func CELLibrary() cel.EnvOption {
+	return cel.Lib(hyperfleetLib{})
+}
+
+// hyperfleetLib implements cel.Library
+type hyperfleetLib struct{}
+
+// LibraryName implements cel.SingletonLibrary so the library is only configured once per environment
+func (hyperfleetLib) LibraryName() string {
+	return "hyperfleet"
+}
+
+// CompileOptions implements cel.Library
+func (hyperfleetLib) CompileOptions() []cel.EnvOption {
+	return []cel.EnvOption{
+		cel.OptionalTypes(),
+		ext.Strings(),
+		ext.Encoders(),
+		ext.Math(),
+		ext.Lists(),
+		ext.Sets(),
+
+		cel.Function("conditionStatus",
+			cel.Overload("conditionStatus_dyn_string", []*cel.Type{cel.DynType, cel.StringType}, cel.StringType,
+				cel.BinaryBinding(conditionStatus))),
+		cel.Function("hasCondition",
+			cel.Overload("hasCondition_dyn_string_string", []*cel.Type{cel.DynType, cel.StringType, cel.StringType}, cel.BoolType,
+				cel.FunctionBinding(hasCondition))),
+
+		cel.Function("semver.compare",
+			cel.Overload("semver_compare_string_string", []*cel.Type{cel.StringType, cel.StringType}, cel.IntType,
+				cel.BinaryBinding(semverCompare))),
+		cel.Function("semver.satisfies",
+			cel.Overload("semver_satisfies_string_string", []*cel.Type{cel.StringType, cel.StringType}, cel.BoolType,
+				cel.BinaryBinding(semverSatisfies))),
+
+		cel.Function("now",
+			cel.Overload("now", []*cel.Type{}, cel.TimestampType,
+				cel.FunctionBinding(func(...ref.Val) ref.Val { return types.Timestamp{Time: time.Now()} }))),
+		cel.Function("age",
+			cel.Overload("age_dyn", []*cel.Type{cel.DynType}, cel.DurationType,
+				cel.UnaryBinding(age))),
+
+		cel.Function("quantity.compare",
+			cel.Overload("quantity_compare_dyn_dyn", []*cel.Type{cel.DynType, cel.DynType}, cel.IntType,
+				cel.BinaryBinding(quantityCompare))),
+		cel.Function("quantity.value",
+			cel.Overload("quantity_value_dyn", []*cel.Type{cel.DynType}, cel.DoubleType,
+				cel.UnaryBinding(quantityValue))),
+	}
+}
+
+// ProgramOptions implements cel.Library
+func (hyperfleetLib) ProgramOptions() []cel.ProgramOption {
+	return nil
+}
+
+// conditionStatus returns the status of the condition of the given type, or "False" when the
+// object has no such condition
+func conditionStatus(obj, conditionType ref.Val) ref.Val {
+	condition, err := findCondition(obj, conditionType)
+	if err != nil {
+		return types.NewErr("conditionStatus: %v", err)
+	}
+	if condition == nil {
+		return types.String(conditionStatusMissing)
+	}
+	status, _ := condition["status"].(string)
+	return types.String(status)
+}
+
+// hasCondition reports whether the object has a condition of the given type and status
+func hasCondition(args ...ref.Val) ref.Val {
+	condition, err := findCondition(args[0], args[1])
+	if err != nil {
+		return types.NewErr("hasCondition: %v", err)
+	}
+	if condition == nil {
+		return types.False
+	}
+	status, _ := condition["status"].(string)
+	return types.Bool(status == string(args[2].(types.String)))
+}
+
+// findCondition returns the condition of the given type of an object, or nil.
+// The object is a resource with status.conditions, an object with conditions, or the conditions list itself.
+func findCondition(obj, conditionType ref.Val) (map[string]interface{}, error) {
+	var conditions []interface{}
+	switch v := obj.(type) {
+	case types.Null:
+		return nil, nil
+	case traits.Lister:
+		native, err := v.ConvertToNative(listType)
+		if err != nil {
+			return nil, err
+		}
+		conditions = native.([]interface{})
+	case traits.Mapper:
+		native, err := v.ConvertToNative(mapType)
+		if err != nil {
+			return nil, err
+		}
+		m := native.(map[string]interface{})
+		if status, ok := m["status"].(map[string]interface{}); ok {
+			conditions, _ = status["conditions"].([]interface{})
+		} else {
+			conditions, _ = m["conditions"].([]interface{})
+		}
+	default:
+		return nil, fmt.Errorf("expected an object or a list of conditions, got %s", obj.Type())
+	}
+
+	want := string(conditionType.(types.String))
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if ok && condition["type"] == want {
+			return condition, nil
+		}
+	}
+	return nil, nil
+}
+
+// semverCompare returns -1, 0 or 1 when version a is lower than, equal to or greater than b
+func semverCompare(a, b ref.Val) ref.Val {
+	va, err := semver.NewVersion(string(a.(types.String)))
+	if err != nil {
+		return types.NewErr("semver.compare: invalid version %q: %v", a, err)
+	}
+	vb, err := semver.NewVersion(string(b.(types.String)))
+	if err != nil {
+		return types.NewErr("semver.compare: invalid version %q: %v", b, err)
+	}
+	return types.Int(va.Compare(vb))
+}
+
+// semverSatisfies reports whether a version satisfies a constraint (e.g. ">= 4.14, < 5")
+func semverSatisfies(version, constraint ref.Val) ref.Val {
+	v, err := semver.NewVersion(string(version.(types.String)))
+	if err != nil {
+		return types.NewErr("semver.satisfies: invalid version %q: %v", version, err)
+	}
+	c, err := semver.NewConstraint(string(constraint.(types.String)))
+	if err != nil {
+		return types.NewErr("semver.satisfies: invalid constraint %q: %v", constraint, err)
+	}
+	return types.Bool(c.Check(v))
+}
+
+// age returns the time elapsed since a timestamp. The argument is a timestamp, an RFC 3339
+// string, or an object with metadata.creationTimestamp or created_time.
+func age(obj ref.Val) ref.Val {
+	created, err := creationTime(obj)
+	if err != nil {
+		return types.NewErr("age: %v", err)
+	}
+	return types.Duration{Duration: time.Since(created)}
+}
+
+// creationTime returns the timestamp an age is computed from
+func creationTime(obj ref.Val) (time.Time, error) {
+	switch v := obj.(type) {
+	case types.Timestamp:
+		return v.Time, nil
+	case types.String:
+		return time.Parse(time.RFC3339, string(v))
+	case traits.Mapper:
+		native, err := v.ConvertToNative(mapType)
+		if err != nil {
+			return time.Time{}, err
+		}
+		m := native.(map[string]interface{})
+		if metadata, ok := m["metadata"].(map[string]interface{}); ok {
+			if ts, ok := metadata["creationTimestamp"].(string); ok {
+				return time.Parse(time.RFC3339, ts)
+			}
+		}
+		if ts, ok := m["created_time"].(string); ok {
+			return time.Parse(time.RFC3339, ts)
+		}
+		return time.Time{}, fmt.Errorf("object has no metadata.creationTimestamp or created_time")
+	default:
+		return time.Time{}, fmt.Errorf("expected a timestamp, a string or an object, got %s", obj.Type())
+	}
+}
+
+// quantityCompare returns -1, 0 or 1 when quantity a is lower than, equal to or greater than b
+func quantityCompare(a, b ref.Val) ref.Val {
+	qa, err := parseQuantity(a)
+	if err != nil {
+		return types.NewErr("quantity.compare: %v", err)
+	}
+	qb, err := parseQuantity(b)
+	if err != nil {
+		return types.NewErr("quantity.compare: %v", err)
+	}
+	return types.Int(qa.Cmp(qb))
+}
+
+// quantityValue returns the numeric value of a quantity (e.g. "1Gi" is 1073741824)
+func quantityValue(q ref.Val) ref.Val {
+	parsed, err := parseQuantity(q)
+	if err != nil {
+		return types.NewErr("quantity.value: %v", err)
+	}
+	return types.Double(parsed.AsApproximateFloat64())
+}
+
+// parseQuantity parses a quantity string (e.g. "500m", "1Gi") or a number
+func parseQuantity(v ref.Val) (resource.Quantity, error) {
+	switch q := v.(type) {
+	case types.String:
+		parsed, err := resource.ParseQuantity(string(q))
+		if err != nil {
+			return resource.Quantity{}, fmt.Errorf("invalid quantity %q: %v", string(q), err)
+		}
+		return parsed, nil
+	case types.Int:
+		return *resource.NewQuantity(int64(q), resource.DecimalSI), nil
+	case types.Uint:
+		return *resource.NewQuantity(int64(q), resource.DecimalSI), nil //nolint:gosec // quantities fit in int64
+	case types.Double:
+		return resource.ParseQuantity(fmt.Sprintf("%g", float64(q)))
+	default:
+		return resource.Quantity{}, fmt.Errorf("expected a quantity string or a number, got %s", v.Type())
+	}
+}
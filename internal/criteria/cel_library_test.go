@@ -0,0 +1,76 @@
+package criteria
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCELLibrary(t *testing.T) {
+	ctx := NewEvaluationContext()
+	ctx.Set("cluster", map[string]interface{}{
+		"created_time": time.Now().Add(-2 * time.Hour).UTC().Format(time.RFC3339),
+		"status": map[string]interface{}{
+			"conditions": []interface{}{
+				map[string]interface{}{"type": "Ready", "status": "True"},
+				map[string]interface{}{"type": "Degraded", "status": "False"},
+			},
+		},
+	})
+	ctx.Set("ns", map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"creationTimestamp": time.Now().Add(-time.Minute).UTC().Format(time.RFC3339),
+		},
+	})
+	ctx.Set("version", "4.15.2")
+	ctx.Set("memory", "1536Mi")
+
+	evaluator, err := newCELEvaluator(context.Background(), ctx)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		expression string
+		wantValue  interface{}
+		wantErr    bool
+	}{
+		{name: "conditionStatus", expression: `conditionStatus(cluster, "Ready")`, wantValue: "True"},
+		{name: "conditionStatus missing condition", expression: `conditionStatus(cluster, "Available")`, wantValue: "False"},
+		{name: "conditionStatus of a conditions list", expression: `conditionStatus(cluster.status.conditions, "Degraded")`, wantValue: "False"},
+		{name: "hasCondition", expression: `hasCondition(cluster, "Ready", "True")`, wantValue: true},
+		{name: "hasCondition wrong status", expression: `hasCondition(cluster, "Degraded", "True")`, wantValue: false},
+		{name: "conditionStatus of a string", expression: `conditionStatus(version, "Ready")`, wantErr: true},
+		{name: "semver.compare", expression: `semver.compare(version, "4.14.0")`, wantValue: int64(1)},
+		{name: "semver.satisfies", expression: `semver.satisfies(version, ">= 4.14, < 5")`, wantValue: true},
+		{name: "semver invalid version", expression: `semver.compare("latest", version)`, wantErr: true},
+		{name: "age of an API object", expression: `age(cluster) > duration("1h")`, wantValue: true},
+		{name: "age of a Kubernetes object", expression: `age(ns) < duration("1h")`, wantValue: true},
+		{name: "age of a timestamp", expression: `age(timestamp("2020-01-01T00:00:00Z")) > duration("24h")`, wantValue: true},
+		{name: "now", expression: `now() > timestamp("2020-01-01T00:00:00Z")`, wantValue: true},
+		{name: "quantity.compare", expression: `quantity.compare(memory, "1Gi")`, wantValue: int64(1)},
+		{name: "quantity.compare with a number", expression: `quantity.compare("2k", 2000)`, wantValue: int64(0)},
+		{name: "quantity.value", expression: `quantity.value("500m")`, wantValue: 0.5},
+		{name: "invalid quantity", expression: `quantity.compare("lots", "1Gi")`, wantErr: true},
+		{name: "ext.Strings", expression: `"a,b".split(",")`, wantValue: []interface{}{"a", "b"}},
+		{name: "ext.Math", expression: `math.greatest(1, 3, 2)`, wantValue: int64(3)},
+		{name: "ext.Sets", expression: `sets.contains(["a", "b"], ["a"])`, wantValue: true},
+		{name: "ext.Encoders", expression: `base64.encode(b"hi")`, wantValue: "aGk="},
+		{name: "ext.Lists", expression: `lists.range(3)`, wantValue: []interface{}{int64(0), int64(1), int64(2)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := evaluator.EvaluateSafe(tt.expression)
+			require.NoError(t, err)
+			if tt.wantErr {
+				assert.Error(t, result.Error)
+				return
+			}
+			require.NoError(t, result.Error)
+			assert.EqualValues(t, tt.wantValue, result.Value)
+		})
+	}
+}
@@ -0,0 +1,239 @@
+package criteria
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/cel-go/cel"
+	apperrors "github.com/openshift-hyperfleet/hyperfleet-adapter/pkg/errors"
+)
+
+// Default CEL evaluation limits
+const (
+	// DefaultCELCostLimit bounds the runtime cost of a single CEL evaluation, so a runaway
+	// comprehension over a large API response fails instead of starving the adapter
+	DefaultCELCostLimit uint64 = 10_000_000
+	// DefaultCELInterruptCheckFrequency is the number of comprehension iterations between two
+	// checks of the evaluation deadline
+	DefaultCELInterruptCheckFrequency uint = 100
+	// DefaultCELTimeout is the deadline of a single CEL evaluation
+	DefaultCELTimeout = time.Second
+)
+
+// maxCachedPrograms bounds the program cache. Expressions beyond it are still evaluated,
+// but compiled on each use.
+const maxCachedPrograms = 4096
+
+// CELLimits bounds the evaluation of CEL expressions. Zero values use the defaults.
+type CELLimits struct {
+	// CostLimit is the maximum runtime cost of one evaluation
+	CostLimit uint64
+	// InterruptCheckFrequency is the number of comprehension iterations between two checks of
+	// the evaluation deadline
+	InterruptCheckFrequency uint
+	// Timeout is the deadline of one evaluation
+	Timeout time.Duration
+}
+
+// withDefaults returns the limits with zero values replaced by the defaults
+func (l CELLimits) withDefaults() CELLimits {
+	if l.CostLimit == 0 {
+		l.CostLimit = DefaultCELCostLimit
+	}
+	if l.InterruptCheckFrequency == 0 {
+		l.InterruptCheckFrequency = DefaultCELInterruptCheckFrequency
+	}
+	if l.Timeout <= 0 {
+		l.Timeout = DefaultCELTimeout
+	}
+	return l
+}
+
+// ProgramCache holds the CEL programs of a loaded config set, compiled against the shared
+// HyperFleet environment with the limits of the set. The config loader compiles the expressions
+// of the task configs from ASTs type-checked against the variables each task config declares
+// (see CompileChecked). Other expressions, e.g. capture expressions reading fields of an API
+// response, are parsed without type-checking and their variables are resolved from the activation
+// at evaluation. A compiled program is valid for every event and safe for concurrent use.
+type ProgramCache struct {
+	env    *cel.Env
+	limits CELLimits
+
+	mu       sync.RWMutex
+	programs map[string]cel.Program
+	traced   map[string]*tracedProgram
+}
+
+// tracedProgram is a program tracking the values of its sub-expressions, with the AST used to
+// name them (see EvaluateTraced)
+type tracedProgram struct {
+	prg cel.Program
+	ast *cel.Ast
+}
+
+// The program cache used by all evaluators, replaced when a config set is loaded or reloaded
+var (
+	sharedMu    sync.Mutex
+	sharedCache *ProgramCache
+)
+
+// sharedPrograms returns the program cache used by evaluators, creating one with the default
+// limits when no config set installed its own
+func sharedPrograms() (*ProgramCache, error) {
+	sharedMu.Lock()
+	defer sharedMu.Unlock()
+
+	if sharedCache == nil {
+		cache, err := NewProgramCache(CELLimits{})
+		if err != nil {
+			return nil, err
+		}
+		sharedCache = cache
+	}
+	return sharedCache, nil
+}
+
+// UsePrograms installs the program cache of a loaded config set, used by the evaluators created
+// afterwards; evaluators created before keep evaluating with the previous cache. Replacing the
+// cache drops the programs of expressions the new config set no longer uses. A nil cache restores
+// a cache with the default limits.
+func UsePrograms(cache *ProgramCache) {
+	sharedMu.Lock()
+	defer sharedMu.Unlock()
+	sharedCache = cache
+}
+
+// NewProgramCache creates an empty program cache evaluating with the given limits
+func NewProgramCache(limits CELLimits) (*ProgramCache, error) {
+	// Macro calls are tracked so traced macros (e.g. exists()) can be printed as written
+	env, err := cel.NewEnv(CELLibrary(), cel.EnableMacroCallTracking())
+	if err != nil {
+		return nil, apperrors.NewCELEnvError("failed to initialize", err)
+	}
+	return &ProgramCache{
+		env:      env,
+		limits:   limits.withDefaults(),
+		programs: make(map[string]cel.Program),
+		traced:   make(map[string]*tracedProgram),
+	}, nil
+}
+
+// program returns the compiled program of an expression, compiling and caching it on first use.
+// Parse and program creation errors are returned as CEL parse/program errors.
+func (c *ProgramCache) program(expression string) (cel.Program, error) {
+	c.mu.RLock()
+	prg, ok := c.programs[expression]
+	c.mu.RUnlock()
+	if ok {
+		return prg, nil
+	}
+
+	prg, _, err := c.compile(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	if len(c.programs) < maxCachedPrograms {
+		c.programs[expression] = prg
+	}
+	c.mu.Unlock()
+	return prg, nil
+}
+
+// tracedProgram returns the compiled program of an expression tracking the values of its
+// sub-expressions, compiling and caching it on first use
+func (c *ProgramCache) tracedProgram(expression string) (*tracedProgram, error) {
+	c.mu.RLock()
+	traced, ok := c.traced[expression]
+	c.mu.RUnlock()
+	if ok {
+		return traced, nil
+	}
+
+	prg, ast, err := c.compile(expression, cel.EvalOptions(cel.OptTrackState))
+	if err != nil {
+		return nil, err
+	}
+	traced = &tracedProgram{prg: prg, ast: ast}
+
+	c.mu.Lock()
+	if len(c.traced) < maxCachedPrograms {
+		c.traced[expression] = traced
+	}
+	c.mu.Unlock()
+	return traced, nil
+}
+
+// compile parses an expression and creates its program with the limits of the cache.
+// The expression is not type-checked: its variables are resolved from the activation.
+func (c *ProgramCache) compile(expression string, opts ...cel.ProgramOption) (cel.Program, *cel.Ast, error) {
+	ast, issues := c.env.Parse(expression)
+	if issues != nil && issues.Err() != nil {
+		return nil, nil, apperrors.NewCELParseError(expression, issues.Err())
+	}
+	if ast == nil {
+		return nil, nil, apperrors.NewCELParseError(expression, nil)
+	}
+
+	prg, err := c.newProgram(expression, ast, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return prg, ast, nil
+}
+
+// newProgram creates the program of a parsed expression with the limits of the cache
+func (c *ProgramCache) newProgram(expression string, ast *cel.Ast, opts ...cel.ProgramOption) (cel.Program, error) {
+	opts = append(opts,
+		cel.CostLimit(c.limits.CostLimit),
+		cel.InterruptCheckFrequency(c.limits.InterruptCheckFrequency))
+	prg, err := c.env.Program(ast, opts...)
+	if err != nil {
+		return nil, apperrors.NewCELProgramError(expression, err)
+	}
+	return prg, nil
+}
+
+// size returns the number of cached programs
+func (c *ProgramCache) size() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.programs)
+}
+
+// Compile compiles an expression without type-checking it, e.g. a capture expression whose
+// variables are the fields of an API response, so its evaluations reuse the program
+func (c *ProgramCache) Compile(expression string) error {
+	expression = strings.TrimSpace(expression)
+	if expression == "" {
+		return nil
+	}
+	_, err := c.program(expression)
+	return err
+}
+
+// CompileChecked creates and caches the program of an expression from its AST type-checked
+// against the variables declared by the task config, so evaluations of the expression reuse it
+func (c *ProgramCache) CompileChecked(expression string, checked *cel.Ast) error {
+	expression = strings.TrimSpace(expression)
+	if expression == "" {
+		return nil
+	}
+	if checked == nil || !checked.IsChecked() {
+		return apperrors.NewCELProgramError(expression, errors.New("expression is not type-checked"))
+	}
+	prg, err := c.newProgram(expression, checked)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.programs) < maxCachedPrograms {
+		c.programs[expression] = prg
+	}
+	return nil
+}
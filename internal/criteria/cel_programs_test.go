@@ -0,0 +1,180 @@
+package criteria
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/google/cel-go/cel"
+	apperrors "github.com/openshift-hyperfleet/hyperfleet-adapter/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProgramCache(t *testing.T) {
+	t.Run("programs are compiled once and reused", func(t *testing.T) {
+		cache, err := NewProgramCache(CELLimits{})
+		require.NoError(t, err)
+
+		first, err := cache.program(`replicas > 1`)
+		require.NoError(t, err)
+		second, err := cache.program(`replicas > 1`)
+		require.NoError(t, err)
+
+		assert.Same(t, first, second)
+		assert.Equal(t, 1, cache.size())
+	})
+
+	t.Run("parse errors are not cached", func(t *testing.T) {
+		cache, err := NewProgramCache(CELLimits{})
+		require.NoError(t, err)
+
+		_, err = cache.program(`replicas >>> 1`)
+		require.Error(t, err)
+		assert.Equal(t, 0, cache.size())
+	})
+
+	t.Run("cost limit stops expensive expressions", func(t *testing.T) {
+		cache, err := NewProgramCache(CELLimits{CostLimit: 100})
+		require.NoError(t, err)
+
+		prg, err := cache.program(`items.all(i, items.all(j, i + j >= 0))`)
+		require.NoError(t, err)
+
+		items := make([]interface{}, 100)
+		for i := range items {
+			items[i] = int64(i)
+		}
+		_, _, err = prg.Eval(map[string]interface{}{"items": items})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "cost limit")
+	})
+}
+
+func TestCELLimits(t *testing.T) {
+	items := make([]interface{}, 200)
+	for i := range items {
+		items[i] = int64(i)
+	}
+	evalCtx := NewEvaluationContext()
+	evalCtx.Set("items", items)
+	// 8 million iterations, far beyond the limits below
+	expensive := `items.all(i, items.all(j, items.all(k, i + j + k >= 0)))`
+
+	usePrograms := func(t *testing.T, limits CELLimits) {
+		cache, err := NewProgramCache(limits)
+		require.NoError(t, err)
+		UsePrograms(cache)
+	}
+	t.Cleanup(func() { UsePrograms(nil) })
+
+	t.Run("cost limit", func(t *testing.T) {
+		usePrograms(t, CELLimits{CostLimit: 1000})
+		evaluator, err := newCELEvaluator(context.Background(), evalCtx)
+		require.NoError(t, err)
+
+		_, err = evaluator.EvaluateSafe(expensive)
+		celErr, ok := apperrors.IsCELError(err)
+		require.True(t, ok, "expected CELError, got %v", err)
+		assert.True(t, celErr.IsCostExceeded())
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		usePrograms(t, CELLimits{CostLimit: math.MaxUint64, InterruptCheckFrequency: 1, Timeout: 10 * time.Millisecond})
+		evaluator, err := newCELEvaluator(context.Background(), evalCtx)
+		require.NoError(t, err)
+
+		_, err = evaluator.EvaluateSafe(expensive)
+		celErr, ok := apperrors.IsCELError(err)
+		require.True(t, ok, "expected CELError, got %v", err)
+		assert.True(t, celErr.IsTimeout())
+	})
+
+	t.Run("canceled context", func(t *testing.T) {
+		usePrograms(t, CELLimits{CostLimit: math.MaxUint64, InterruptCheckFrequency: 1})
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		evaluator, err := newCELEvaluator(ctx, evalCtx)
+		require.NoError(t, err)
+
+		_, err = evaluator.EvaluateSafe(expensive)
+		celErr, ok := apperrors.IsCELError(err)
+		require.True(t, ok, "expected CELError, got %v", err)
+		assert.True(t, celErr.IsCanceled())
+	})
+
+	t.Run("evaluators created before keep their cache", func(t *testing.T) {
+		usePrograms(t, CELLimits{CostLimit: 1000})
+		before, err := newCELEvaluator(context.Background(), evalCtx)
+		require.NoError(t, err)
+		usePrograms(t, CELLimits{})
+
+		_, err = before.EvaluateSafe(expensive)
+		celErr, ok := apperrors.IsCELError(err)
+		require.True(t, ok, "expected CELError, got %v", err)
+		assert.True(t, celErr.IsCostExceeded())
+	})
+}
+
+func TestUsePrograms(t *testing.T) {
+	t.Cleanup(func() { UsePrograms(nil) })
+
+	loaded, err := NewProgramCache(CELLimits{})
+	require.NoError(t, err)
+	require.NoError(t, loaded.Compile(`items.size() > 0`))
+	UsePrograms(loaded)
+	current, err := sharedPrograms()
+	require.NoError(t, err)
+	assert.Same(t, loaded, current)
+
+	// A reloaded config set replaces the cache, dropping the programs of the previous set
+	reloaded, err := NewProgramCache(CELLimits{})
+	require.NoError(t, err)
+	UsePrograms(reloaded)
+	current, err = sharedPrograms()
+	require.NoError(t, err)
+	assert.Same(t, reloaded, current)
+	assert.Equal(t, 0, current.size())
+
+	UsePrograms(nil)
+	current, err = sharedPrograms()
+	require.NoError(t, err)
+	assert.Equal(t, DefaultCELCostLimit, current.limits.CostLimit)
+}
+
+func TestProgramCacheCompile(t *testing.T) {
+	cache, err := NewProgramCache(CELLimits{})
+	require.NoError(t, err)
+
+	require.NoError(t, cache.Compile(`cluster.status.phase == "Ready"`))
+	require.NoError(t, cache.Compile("  "))
+	require.Error(t, cache.Compile(`cluster.status.phase ==== "Ready"`))
+	assert.Equal(t, 1, cache.size())
+}
+
+func TestProgramCacheCompileChecked(t *testing.T) {
+	env, err := cel.NewEnv(CELLibrary(), cel.Variable("cluster", cel.DynType))
+	require.NoError(t, err)
+	cache, err := NewProgramCache(CELLimits{})
+	require.NoError(t, err)
+
+	expression := `cluster.status.phase == "Ready"`
+	checked, issues := env.Compile(expression)
+	require.NoError(t, issues.Err())
+	require.NoError(t, cache.CompileChecked(expression, checked))
+
+	prg, err := cache.program(expression)
+	require.NoError(t, err)
+	out, _, err := prg.Eval(map[string]interface{}{
+		"cluster": map[string]interface{}{"status": map[string]interface{}{"phase": "Ready"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, true, out.Value())
+
+	t.Run("parsed only expressions are rejected", func(t *testing.T) {
+		parsed, issues := env.Parse(`cluster.name`)
+		require.NoError(t, issues.Err())
+		require.Error(t, cache.CompileChecked(`cluster.name`, parsed))
+	})
+}
@@ -4,9 +4,12 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"regexp"
 	"strings"
 	"sync"
+	"time"
 
+	"github.com/Masterminds/semver/v3"
 	"github.com/openshift-hyperfleet/hyperfleet-adapter/pkg/logger"
 )
 
@@ -22,15 +25,27 @@ type EvaluationResult struct {
 	Operator Operator
 	// ExpectedValue is the value the condition was compared against
 	ExpectedValue interface{}
+	// Path locates the condition in the evaluated list, e.g. "[0]" or "[1].anyOf[0].not"
+	// (set by EvaluateConditions)
+	Path string
+	// Negated indicates the condition is nested in an odd number of not groups
+	Negated bool
+	// CausedFailure indicates the condition made the conditions fail: an unmatched condition,
+	// or a matched condition under not (set by EvaluateConditions)
+	CausedFailure bool
+	// Error is the operator error of a condition nested in a group, which then counts as
+	// not matched (set by EvaluateConditions)
+	Error error
 }
 
 // ConditionsResult contains the result of evaluating multiple conditions
 type ConditionsResult struct {
 	// Matched indicates if all conditions were satisfied
 	Matched bool
-	// Results contains individual results for each condition
+	// Results contains individual results for each evaluated leaf condition, in order of
+	// appearance; anyOf groups stop at the first match and allOf groups at the first miss
 	Results []EvaluationResult
-	// FailedCondition is the index of the first failed condition (-1 if all passed)
+	// FailedCondition is the index of the first failed top-level condition (-1 if all passed)
 	FailedCondition int
 	// ExtractedFields maps field paths to their values
 	ExtractedFields map[string]interface{}
@@ -42,11 +57,9 @@ type Evaluator struct {
 	log     logger.Logger
 	ctx     context.Context
 
-	// Lazily cached CEL evaluator for repeated CEL evaluations
-	// Recreated when context version changes
-	celEval        *CELEvaluator
-	celEvalVersion int64 // Track which context version the CEL eval was created with
-	mu             sync.Mutex
+	// Lazily created CEL evaluator; programs are shared, so it does not depend on the context data
+	celEval *CELEvaluator
+	mu      sync.Mutex
 }
 
 // NewEvaluator creates a new criteria evaluator.
@@ -69,24 +82,19 @@ func NewEvaluator(ctx context.Context, evalCtx *EvaluationContext, log logger.Lo
 	}, nil
 }
 
-// getCELEvaluator returns a cached CEL evaluator, creating it lazily on first use.
-// If the context has been modified (version changed), the CEL evaluator is recreated
-// to ensure the CEL environment stays in sync with the context data.
-// This prevents "undeclared reference" errors when variables are added after first evaluation.
+// getCELEvaluator returns the CEL evaluator, creating it lazily on first use.
+// Variables added to the context after the first evaluation are visible to later evaluations,
+// as every evaluation reads a snapshot of the context data.
 func (e *Evaluator) getCELEvaluator() (*CELEvaluator, error) {
 	e.mu.Lock()
 	defer e.mu.Unlock()
 
-	currentVersion := e.evalCtx.Version()
-
-	// Recreate CEL evaluator if context changed or not yet created
-	if e.celEval == nil || e.celEvalVersion != currentVersion {
-		celEval, err := newCELEvaluator(e.evalCtx)
+	if e.celEval == nil {
+		celEval, err := newCELEvaluator(e.ctx, e.evalCtx)
 		if err != nil {
 			return nil, err
 		}
 		e.celEval = celEval
-		e.celEvalVersion = currentVersion
 	}
 
 	return e.celEval, nil
@@ -107,6 +115,16 @@ func (e *Evaluator) EvaluateField(field string) (*FieldResult, error) {
 
 // EvaluateCondition evaluates a single condition and returns detailed result
 func (e *Evaluator) EvaluateCondition(field string, operator Operator, value interface{}) (*EvaluationResult, error) {
+	result, err := e.evaluateCondition(field, operator, value)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// evaluateCondition evaluates a single condition. When the operator fails on the field value,
+// the result is returned along with the error.
+func (e *Evaluator) evaluateCondition(field string, operator Operator, value interface{}) (*EvaluationResult, error) {
 	// Get the field value from context
 	fieldResult, err := e.evalCtx.GetField(field)
 	if err != nil {
@@ -121,21 +139,17 @@ func (e *Evaluator) EvaluateCondition(field string, operator Operator, value int
 	}
 
 	// Evaluate based on operator
-	var matched bool
-	if operator == OperatorExists {
-		// Exists is special - only checks fieldValue, no expected value
-		matched = evaluateExists(fieldResult.Value)
-	} else if evalFn, ok := operatorFuncs[operator]; ok {
-		matched, err = evalFn(fieldResult.Value, value)
-		if err != nil {
-			return nil, err
-		}
-	} else {
+	evalFn, ok := operatorFuncs[operator]
+	if !ok {
 		return nil, &EvaluationError{
 			Field:   field,
 			Message: fmt.Sprintf("unsupported operator: %s", operator),
 		}
 	}
+	matched, err := evalFn(fieldResult.Value, value)
+	if err != nil {
+		return result, err
+	}
 
 	result.Matched = matched
 	return result, nil
@@ -151,28 +165,138 @@ func (e *Evaluator) EvaluateConditions(conditions []ConditionDef) (*ConditionsRe
 	}
 
 	for i, cond := range conditions {
-		evalResult, err := e.EvaluateCondition(cond.Field, cond.Operator, cond.Value)
+		node, err := e.evaluateConditionTree(cond, fmt.Sprintf("[%d]", i), false, false, result)
 		if err != nil {
 			return nil, err
 		}
 
-		result.Results = append(result.Results, *evalResult)
-		result.ExtractedFields[cond.Field] = evalResult.FieldValue
-
-		if !evalResult.Matched && result.Matched {
-			result.Matched = false
-			result.FailedCondition = i
+		if !node.matched {
+			markFailureCauses(node, true, result.Results)
+			if result.Matched {
+				result.Matched = false
+				result.FailedCondition = i
+			}
 		}
 	}
 
 	return result, nil
 }
 
+// conditionNode is the evaluated tree of a condition, used to find the leaves causing a failure
+type conditionNode struct {
+	matched  bool
+	leaf     int // index of the leaf result in ConditionsResult.Results, -1 for groups
+	negate   bool
+	children []*conditionNode
+}
+
+// evaluateConditionTree evaluates a condition and its nested groups, appending the leaf results.
+// anyOf stops at the first matched child and allOf at the first unmatched one; the evaluated
+// children are the ones deciding the group, so they explain a failure. A leaf nested in a
+// group whose operator fails on the field value (e.g. greaterThan on a missing field) counts
+// as not matched, with the error recorded in its result.
+func (e *Evaluator) evaluateConditionTree(cond ConditionDef, path string, negated, nested bool, result *ConditionsResult) (*conditionNode, error) {
+	switch {
+	case cond.Not != nil:
+		child, err := e.evaluateConditionTree(*cond.Not, path+".not", !negated, true, result)
+		if err != nil {
+			return nil, err
+		}
+		return &conditionNode{matched: !child.matched, leaf: -1, negate: true, children: []*conditionNode{child}}, nil
+	case len(cond.AnyOf) > 0, len(cond.AllOf) > 0:
+		anyOf := len(cond.AnyOf) > 0
+		children, name := cond.AllOf, "allOf"
+		if anyOf {
+			children, name = cond.AnyOf, "anyOf"
+		}
+		node := &conditionNode{matched: !anyOf, leaf: -1}
+		for i, c := range children {
+			child, err := e.evaluateConditionTree(c, fmt.Sprintf("%s.%s[%d]", path, name, i), negated, true, result)
+			if err != nil {
+				return nil, err
+			}
+			node.children = append(node.children, child)
+			if child.matched == anyOf {
+				node.matched = anyOf
+				break
+			}
+		}
+		return node, nil
+	default:
+		evalResult, err := e.evaluateCondition(cond.Field, cond.Operator, cond.Value)
+		if err != nil {
+			if !nested || evalResult == nil {
+				return nil, err
+			}
+			evalResult.Matched = false
+			evalResult.Error = err
+		}
+		evalResult.Path = path
+		evalResult.Negated = negated
+		result.Results = append(result.Results, *evalResult)
+		result.ExtractedFields[cond.Field] = evalResult.FieldValue
+		return &conditionNode{matched: evalResult.Matched, leaf: len(result.Results) - 1}, nil
+	}
+}
+
+// markFailureCauses flags the leaves that made a node differ from the expected result:
+// the mismatching children of anyOf and allOf groups, and the child of a not group
+// against the inverted expectation
+func markFailureCauses(node *conditionNode, expected bool, results []EvaluationResult) {
+	if node.matched == expected {
+		return
+	}
+	if node.leaf >= 0 {
+		results[node.leaf].CausedFailure = true
+		return
+	}
+	if node.negate {
+		expected = !expected
+	}
+	for _, child := range node.children {
+		markFailureCauses(child, expected, results)
+	}
+}
+
 // ExtractValueResult contains the result of value extraction
 type ExtractValueResult struct {
-	Value  interface{} // Extracted value
-	Source string      // The field path or expression used
-	Error  error       // Error if extraction failed
+	Value  interface{}   // Extracted value
+	Values []interface{} // Every extracted value: the field matches, or the elements of a list expression result
+	Source string        // The field path or expression used
+	Error  error         // Error if extraction failed (missing field, CEL evaluation error)
+}
+
+// ExtractMode selects the value of an extraction that may produce several values
+type ExtractMode string
+
+const (
+	// ExtractModeDefault keeps Value: nil, the single match, or the list of matches
+	ExtractModeDefault ExtractMode = ""
+	// ExtractModeFirst selects the first value, or nil when there is none
+	ExtractModeFirst ExtractMode = "first"
+	// ExtractModeAll selects the list of values, empty when there is none
+	ExtractModeAll ExtractMode = "all"
+	// ExtractModeCount selects the number of values as an int64
+	ExtractModeCount ExtractMode = "count"
+)
+
+// Select returns the extracted value selected by mode
+func (r *ExtractValueResult) Select(mode ExtractMode) interface{} {
+	switch mode {
+	case ExtractModeFirst:
+		if len(r.Values) == 0 {
+			return nil
+		}
+		return r.Values[0]
+	case ExtractModeAll:
+		values := make([]interface{}, len(r.Values))
+		copy(values, r.Values)
+		return values
+	case ExtractModeCount:
+		return int64(len(r.Values))
+	default:
+		return r.Value
+	}
 }
 
 // ExtractValue extracts a value from the context using either field (JSONPath) or expression (CEL).
@@ -207,8 +331,16 @@ func (e *Evaluator) ExtractValue(field, expression string) (*ExtractValueResult,
 			// This is NOT a parse error, so we don't return error - caller can use default
 			// Only caught field missing or empty value as warn log
 			e.log.Warnf(e.ctx, "CEL evaluation failed for %q: %v", expression, celResult.Error)
+			result.Error = celResult.Error
 		}
 		result.Value = celResult.Value
+		switch v := celResult.Value.(type) {
+		case nil:
+		case []interface{}:
+			result.Values = v
+		default:
+			result.Values = []interface{}{v}
+		}
 		result.Source = expression
 		return result, nil
 	} else if field != "" {
@@ -223,8 +355,10 @@ func (e *Evaluator) ExtractValue(field, expression string) (*ExtractValueResult,
 		// This is NOT a parse error, so we don't return error - caller can use default
 		if fieldResult.Error != nil {
 			e.log.Warnf(e.ctx, "failed to extract field %s: %v", field, fieldResult.Error)
+			result.Error = fieldResult.Error
 		}
 		result.Value = fieldResult.Value
+		result.Values = fieldResult.Values
 		result.Source = field
 		return result, nil
 	}
@@ -249,27 +383,56 @@ func (e *Evaluator) EvaluateCEL(expression string) (*CELResult, error) {
 	})
 }
 
-// ConditionDef defines a condition to evaluate
+// EvaluateCELTraced evaluates a CEL expression against the current context, recording the values
+// of its sub-expressions in CELResult.Trace (see CELEvaluator.EvaluateTraced)
+func (e *Evaluator) EvaluateCELTraced(expression string) (*CELResult, error) {
+	return withCELEvaluator(e, func(c *CELEvaluator) (*CELResult, error) {
+		return c.EvaluateTraced(expression)
+	})
+}
+
+// ConditionDef defines a condition to evaluate: either a comparison of Field with Value using
+// Operator, or a group of nested conditions (exactly one of AnyOf, AllOf or Not)
 type ConditionDef struct {
 	Field    string
 	Operator Operator
 	Value    interface{}
+	// AnyOf matches when at least one nested condition matches
+	AnyOf []ConditionDef
+	// AllOf matches when every nested condition matches
+	AllOf []ConditionDef
+	// Not matches when the nested condition does not match
+	Not *ConditionDef
 }
 
 // ConditionDefJSON is used for JSON/YAML unmarshaling with string operator
 type ConditionDefJSON struct {
-	Field    string      `json:"field" yaml:"field"`
-	Operator string      `json:"operator" yaml:"operator"`
-	Value    interface{} `json:"value" yaml:"value"`
+	Field    string             `json:"field" yaml:"field"`
+	Operator string             `json:"operator" yaml:"operator"`
+	Value    interface{}        `json:"value" yaml:"value"`
+	AnyOf    []ConditionDefJSON `json:"anyOf,omitempty" yaml:"anyOf,omitempty"`
+	AllOf    []ConditionDefJSON `json:"allOf,omitempty" yaml:"allOf,omitempty"`
+	Not      *ConditionDefJSON  `json:"not,omitempty" yaml:"not,omitempty"`
 }
 
 // ToConditionDef converts ConditionDefJSON to ConditionDef with typed Operator
 func (c ConditionDefJSON) ToConditionDef() ConditionDef {
-	return ConditionDef{
+	def := ConditionDef{
 		Field:    c.Field,
 		Operator: Operator(c.Operator),
 		Value:    c.Value,
 	}
+	for _, nested := range c.AnyOf {
+		def.AnyOf = append(def.AnyOf, nested.ToConditionDef())
+	}
+	for _, nested := range c.AllOf {
+		def.AllOf = append(def.AllOf, nested.ToConditionDef())
+	}
+	if c.Not != nil {
+		not := c.Not.ToConditionDef()
+		def.Not = &not
+	}
+	return def
 }
 
 // evalFunc is a function type for operator evaluation
@@ -277,13 +440,33 @@ type evalFunc func(fieldValue, expected interface{}) (bool, error)
 
 // operatorFuncs maps operators to their evaluation functions
 var operatorFuncs = map[Operator]evalFunc{
-	OperatorEquals:      evaluateEquals,
-	OperatorNotEquals:   negate(evaluateEquals),
-	OperatorIn:          evaluateIn,
-	OperatorNotIn:       negate(evaluateIn),
-	OperatorContains:    evaluateContains,
-	OperatorGreaterThan: evaluateGreaterThan,
-	OperatorLessThan:    evaluateLessThan,
+	OperatorEquals:             evaluateEquals,
+	OperatorNotEquals:          negate(evaluateEquals),
+	OperatorIn:                 evaluateIn,
+	OperatorNotIn:              negate(evaluateIn),
+	OperatorContains:           evaluateContains,
+	OperatorGreaterThan:        evaluateGreaterThan,
+	OperatorLessThan:           evaluateLessThan,
+	OperatorGreaterThanOrEqual: evaluateGreaterThanOrEqual,
+	OperatorLessThanOrEqual:    evaluateLessThanOrEqual,
+	OperatorBetween:            evaluateBetween,
+	OperatorMatches:            evaluateMatches,
+	OperatorStartsWith:         evaluateStartsWith,
+	OperatorEndsWith:           evaluateEndsWith,
+	OperatorExists:             valueless(evaluateExists),
+	OperatorNotExists:          negate(valueless(evaluateExists)),
+	OperatorIsEmpty:            evaluateIsEmpty,
+	OperatorNotEmpty:           negate(evaluateIsEmpty),
+	OperatorOlderThan:          evaluateOlderThan,
+	OperatorNewerThan:          negate(evaluateOlderThan),
+	OperatorSemverAtLeast:      evaluateSemverAtLeast,
+}
+
+// valueless wraps a check of the field value alone into an evalFunc ignoring the expected value
+func valueless(fn func(fieldValue interface{}) bool) evalFunc {
+	return func(fieldValue, _ interface{}) (bool, error) {
+		return fn(fieldValue), nil
+	}
 }
 
 // negate wraps an evalFunc to return the opposite result
@@ -424,6 +607,166 @@ func evaluateLessThan(fieldValue, threshold interface{}) (bool, error) {
 	})
 }
 
+// evaluateGreaterThanOrEqual checks if a value is greater than or equal to another
+func evaluateGreaterThanOrEqual(fieldValue, threshold interface{}) (bool, error) {
+	return compareNumbers(fieldValue, threshold, func(a, b float64) bool {
+		return a >= b
+	})
+}
+
+// evaluateLessThanOrEqual checks if a value is less than or equal to another
+func evaluateLessThanOrEqual(fieldValue, threshold interface{}) (bool, error) {
+	return compareNumbers(fieldValue, threshold, func(a, b float64) bool {
+		return a <= b
+	})
+}
+
+// evaluateBetween checks if a value is within an inclusive [min, max] range
+func evaluateBetween(fieldValue, bounds interface{}) (bool, error) {
+	minValue, maxValue, err := rangeBounds(bounds)
+	if err != nil {
+		return false, err
+	}
+	aboveMin, err := evaluateGreaterThanOrEqual(fieldValue, minValue)
+	if err != nil || !aboveMin {
+		return false, err
+	}
+	return evaluateLessThanOrEqual(fieldValue, maxValue)
+}
+
+// rangeBounds returns the bounds of a [min, max] list
+func rangeBounds(bounds interface{}) (minValue, maxValue interface{}, err error) {
+	list := reflect.ValueOf(bounds)
+	if !list.IsValid() || (list.Kind() != reflect.Slice && list.Kind() != reflect.Array) || list.Len() != 2 {
+		return nil, nil, fmt.Errorf("between operator requires a [min, max] list, got %v", bounds)
+	}
+	return list.Index(0).Interface(), list.Index(1).Interface(), nil
+}
+
+// evaluateMatches checks if a string matches a regular expression
+func evaluateMatches(fieldValue, pattern interface{}) (bool, error) {
+	str, patternStr, err := stringOperands("matches", fieldValue, pattern)
+	if err != nil {
+		return false, err
+	}
+	re, err := CompileRegex(patternStr)
+	if err != nil {
+		return false, fmt.Errorf("invalid regular expression %q: %w", patternStr, err)
+	}
+	return re.MatchString(str), nil
+}
+
+// Compiled regular expressions of the matches operator, shared across evaluations
+var (
+	regexMu    sync.RWMutex
+	regexCache = make(map[string]*regexp.Regexp)
+)
+
+// CompileRegex compiles a regular expression of the matches operator, caching it so later
+// evaluations reuse it. The config loader compiles every matches pattern at load time.
+// Invalid patterns are not cached.
+func CompileRegex(pattern string) (*regexp.Regexp, error) {
+	regexMu.RLock()
+	re, ok := regexCache[pattern]
+	regexMu.RUnlock()
+	if ok {
+		return re, nil
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	regexMu.Lock()
+	if len(regexCache) < maxCachedPrograms {
+		regexCache[pattern] = re
+	}
+	regexMu.Unlock()
+	return re, nil
+}
+
+// evaluateStartsWith checks if a string starts with a prefix
+func evaluateStartsWith(fieldValue, prefix interface{}) (bool, error) {
+	str, prefixStr, err := stringOperands("startsWith", fieldValue, prefix)
+	if err != nil {
+		return false, err
+	}
+	return strings.HasPrefix(str, prefixStr), nil
+}
+
+// evaluateEndsWith checks if a string ends with a suffix
+func evaluateEndsWith(fieldValue, suffix interface{}) (bool, error) {
+	str, suffixStr, err := stringOperands("endsWith", fieldValue, suffix)
+	if err != nil {
+		return false, err
+	}
+	return strings.HasSuffix(str, suffixStr), nil
+}
+
+// stringOperands returns the field value and the expected value of a string operator
+func stringOperands(operator string, fieldValue, expected interface{}) (string, string, error) {
+	str, ok := fieldValue.(string)
+	if !ok {
+		return "", "", fmt.Errorf("%s operator requires a string field, got %T", operator, fieldValue)
+	}
+	expectedStr, ok := expected.(string)
+	if !ok {
+		return "", "", fmt.Errorf("%s operator requires a string value, got %T", operator, expected)
+	}
+	return str, expectedStr, nil
+}
+
+// evaluateIsEmpty checks if a string, list or map value is nil or empty
+func evaluateIsEmpty(fieldValue, _ interface{}) (bool, error) {
+	if fieldValue == nil {
+		return true, nil
+	}
+	value := reflect.ValueOf(fieldValue)
+	switch value.Kind() { //nolint:exhaustive // only collection types have a size
+	case reflect.String, reflect.Slice, reflect.Map, reflect.Array:
+		return value.Len() == 0, nil
+	}
+	return false, fmt.Errorf("isEmpty operator requires a string, list or map field, got %T", fieldValue)
+}
+
+// evaluateOlderThan checks if an RFC 3339 timestamp is older than a duration (e.g. "1h")
+func evaluateOlderThan(fieldValue, age interface{}) (bool, error) {
+	ts, ok := fieldValue.(string)
+	if !ok {
+		return false, fmt.Errorf("olderThan/newerThan operators require an RFC 3339 timestamp field, got %T", fieldValue)
+	}
+	t, err := time.Parse(time.RFC3339, ts)
+	if err != nil {
+		return false, fmt.Errorf("invalid RFC 3339 timestamp %q: %w", ts, err)
+	}
+	ageStr, ok := age.(string)
+	if !ok {
+		return false, fmt.Errorf("olderThan/newerThan operators require a duration value, got %T", age)
+	}
+	d, err := time.ParseDuration(ageStr)
+	if err != nil {
+		return false, fmt.Errorf("invalid duration %q: %w", ageStr, err)
+	}
+	return time.Since(t) > d, nil
+}
+
+// evaluateSemverAtLeast checks if a semantic version is greater than or equal to a minimum version
+func evaluateSemverAtLeast(fieldValue, minVersion interface{}) (bool, error) {
+	versionStr, minStr, err := stringOperands("semverAtLeast", fieldValue, minVersion)
+	if err != nil {
+		return false, err
+	}
+	version, err := semver.NewVersion(versionStr)
+	if err != nil {
+		return false, fmt.Errorf("invalid semantic version %q: %w", versionStr, err)
+	}
+	minimum, err := semver.NewVersion(minStr)
+	if err != nil {
+		return false, fmt.Errorf("invalid semantic version %q: %w", minStr, err)
+	}
+	return !version.LessThan(minimum), nil
+}
+
 // evaluateExists checks if a value exists (is not nil or empty)
 func evaluateExists(fieldValue interface{}) bool {
 	if fieldValue == nil {
@@ -3,6 +3,7 @@ package criteria
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/openshift-hyperfleet/hyperfleet-adapter/pkg/logger"
 	"github.com/stretchr/testify/assert"
@@ -603,6 +604,71 @@ func TestEvaluatorEvaluateCondition(t *testing.T) {
 	}
 }
 
+func TestEvaluatorExtendedOperators(t *testing.T) {
+	ctx := NewEvaluationContext()
+	ctx.Set("nodeCount", 5)
+	ctx.Set("clusterName", "prod-us-east-1")
+	ctx.Set("version", "4.15.2")
+	ctx.Set("createdAt", time.Now().Add(-2*time.Hour).UTC().Format(time.RFC3339))
+	ctx.Set("labels", map[string]interface{}{})
+	ctx.Set("zones", []interface{}{"a", "b"})
+	ctx.Set("empty", "")
+
+	evaluator, err := NewEvaluator(context.Background(), ctx, logger.NewTestLogger())
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		field     string
+		operator  Operator
+		value     interface{}
+		want      bool
+		wantError bool
+	}{
+		{name: "greaterThanOrEqual equal", field: "nodeCount", operator: OperatorGreaterThanOrEqual, value: 5, want: true},
+		{name: "greaterThanOrEqual lower", field: "nodeCount", operator: OperatorGreaterThanOrEqual, value: 6, want: false},
+		{name: "lessThanOrEqual equal", field: "nodeCount", operator: OperatorLessThanOrEqual, value: 5.0, want: true},
+		{name: "lessThanOrEqual greater", field: "nodeCount", operator: OperatorLessThanOrEqual, value: 4, want: false},
+		{name: "between inside", field: "nodeCount", operator: OperatorBetween, value: []interface{}{1, 5}, want: true},
+		{name: "between outside", field: "nodeCount", operator: OperatorBetween, value: []interface{}{6, 10}, want: false},
+		{name: "between invalid bounds", field: "nodeCount", operator: OperatorBetween, value: []interface{}{1}, wantError: true},
+		{name: "matches", field: "clusterName", operator: OperatorMatches, value: "^prod-[a-z]+-", want: true},
+		{name: "matches no match", field: "clusterName", operator: OperatorMatches, value: "^dev-", want: false},
+		{name: "matches invalid regex", field: "clusterName", operator: OperatorMatches, value: "(", wantError: true},
+		{name: "matches non-string field", field: "nodeCount", operator: OperatorMatches, value: "5", wantError: true},
+		{name: "startsWith", field: "clusterName", operator: OperatorStartsWith, value: "prod-", want: true},
+		{name: "endsWith", field: "clusterName", operator: OperatorEndsWith, value: "-1", want: true},
+		{name: "endsWith no match", field: "clusterName", operator: OperatorEndsWith, value: "-2", want: false},
+		{name: "notExists missing field", field: "missing", operator: OperatorNotExists, want: true},
+		{name: "notExists present field", field: "clusterName", operator: OperatorNotExists, want: false},
+		{name: "isEmpty empty map", field: "labels", operator: OperatorIsEmpty, want: true},
+		{name: "isEmpty empty string", field: "empty", operator: OperatorIsEmpty, want: true},
+		{name: "isEmpty missing field", field: "missing", operator: OperatorIsEmpty, want: true},
+		{name: "isEmpty number", field: "nodeCount", operator: OperatorIsEmpty, wantError: true},
+		{name: "notEmpty list", field: "zones", operator: OperatorNotEmpty, want: true},
+		{name: "olderThan", field: "createdAt", operator: OperatorOlderThan, value: "1h", want: true},
+		{name: "olderThan too recent", field: "createdAt", operator: OperatorOlderThan, value: "3h", want: false},
+		{name: "newerThan", field: "createdAt", operator: OperatorNewerThan, value: "3h", want: true},
+		{name: "olderThan invalid timestamp", field: "clusterName", operator: OperatorOlderThan, value: "1h", wantError: true},
+		{name: "semverAtLeast", field: "version", operator: OperatorSemverAtLeast, value: "4.14.0", want: true},
+		{name: "semverAtLeast same version", field: "version", operator: OperatorSemverAtLeast, value: "4.15.2", want: true},
+		{name: "semverAtLeast lower", field: "version", operator: OperatorSemverAtLeast, value: "4.16", want: false},
+		{name: "semverAtLeast invalid version", field: "clusterName", operator: OperatorSemverAtLeast, value: "4.14", wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := evaluator.EvaluateCondition(tt.field, tt.operator, tt.value)
+			if tt.wantError {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, result.Matched)
+			}
+		})
+	}
+}
+
 func TestEvaluatorEvaluateConditions(t *testing.T) {
 	ctx := NewEvaluationContext()
 	ctx.Set("clusterPhase", "Ready")
@@ -944,6 +1010,93 @@ func TestExtractValue(t *testing.T) {
 	assert.Nil(t, result.Value) // Value is nil (field not found)
 }
 
+func TestExtractValueSelect(t *testing.T) {
+	ctx := NewEvaluationContext()
+	ctx.Set("items", []interface{}{
+		map[string]interface{}{"adapter": "dns", "ready": true},
+		map[string]interface{}{"adapter": "network", "ready": true},
+		map[string]interface{}{"adapter": "storage", "ready": false},
+	})
+
+	evaluator, err := NewEvaluator(context.Background(), ctx, logger.NewTestLogger())
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		field      string
+		expression string
+		want       map[ExtractMode]interface{}
+	}{
+		{
+			name:  "filter matching several items",
+			field: "{.items[?(@.ready==true)].adapter}",
+			want: map[ExtractMode]interface{}{
+				ExtractModeDefault: []interface{}{"dns", "network"},
+				ExtractModeFirst:   "dns",
+				ExtractModeAll:     []interface{}{"dns", "network"},
+				ExtractModeCount:   int64(2),
+			},
+		},
+		{
+			name:  "filter matching one item",
+			field: "{.items[?(@.ready==false)].adapter}",
+			want: map[ExtractMode]interface{}{
+				ExtractModeDefault: "storage",
+				ExtractModeFirst:   "storage",
+				ExtractModeAll:     []interface{}{"storage"},
+				ExtractModeCount:   int64(1),
+			},
+		},
+		{
+			name:  "filter matching nothing",
+			field: "{.items[?(@.adapter=='compute')].adapter}",
+			want: map[ExtractMode]interface{}{
+				ExtractModeDefault: nil,
+				ExtractModeFirst:   nil,
+				ExtractModeAll:     []interface{}{},
+				ExtractModeCount:   int64(0),
+			},
+		},
+		{
+			name:       "expression returning a list",
+			expression: "items.filter(i, i.ready).map(i, i.adapter)",
+			want: map[ExtractMode]interface{}{
+				ExtractModeDefault: []interface{}{"dns", "network"},
+				ExtractModeFirst:   "dns",
+				ExtractModeAll:     []interface{}{"dns", "network"},
+				ExtractModeCount:   int64(2),
+			},
+		},
+		{
+			name:       "expression returning a scalar",
+			expression: "items.size()",
+			want: map[ExtractMode]interface{}{
+				ExtractModeDefault: int64(3),
+				ExtractModeFirst:   int64(3),
+				ExtractModeAll:     []interface{}{int64(3)},
+				ExtractModeCount:   int64(1),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := evaluator.ExtractValue(tt.field, tt.expression)
+			require.NoError(t, err)
+			for mode, want := range tt.want {
+				assert.Equal(t, want, result.Select(mode), "mode %q", mode)
+			}
+		})
+	}
+
+	t.Run("missing field sets the error", func(t *testing.T) {
+		result, err := evaluator.ExtractValue("status.phase", "")
+		require.NoError(t, err)
+		assert.Error(t, result.Error)
+		assert.Empty(t, result.Values)
+	})
+}
+
 func TestEvaluateCondition(t *testing.T) {
 	ctx := NewEvaluationContext()
 	ctx.Set("status", "Ready")
@@ -1027,6 +1180,165 @@ func TestEvaluateConditions(t *testing.T) {
 	assert.Equal(t, 3, result.ExtractedFields["replicas"])
 }
 
+func TestEvaluateConditionGroups(t *testing.T) {
+	ctx := NewEvaluationContext()
+	ctx.Set("status", "Ready")
+	ctx.Set("provider", "gcp")
+	ctx.Set("region", "us-gov-west-1")
+
+	evaluator, err := NewEvaluator(context.Background(), ctx, logger.NewTestLogger())
+	require.NoError(t, err)
+
+	t.Run("anyOf matches with one branch", func(t *testing.T) {
+		result, err := evaluator.EvaluateConditions([]ConditionDef{
+			{Field: "status", Operator: OperatorEquals, Value: "Ready"},
+			{AnyOf: []ConditionDef{
+				{Field: "provider", Operator: OperatorEquals, Value: "aws"},
+				{Field: "provider", Operator: OperatorEquals, Value: "gcp"},
+			}},
+		})
+		require.NoError(t, err)
+		assert.True(t, result.Matched)
+		assert.Equal(t, -1, result.FailedCondition)
+		require.Len(t, result.Results, 3)
+		assert.Equal(t, "[1].anyOf[0]", result.Results[1].Path)
+		for _, r := range result.Results {
+			assert.False(t, r.CausedFailure)
+		}
+	})
+
+	t.Run("failed anyOf blames every branch", func(t *testing.T) {
+		result, err := evaluator.EvaluateConditions([]ConditionDef{
+			{Field: "status", Operator: OperatorEquals, Value: "Ready"},
+			{AnyOf: []ConditionDef{
+				{Field: "provider", Operator: OperatorEquals, Value: "aws"},
+				{Field: "provider", Operator: OperatorEquals, Value: "azure"},
+			}},
+		})
+		require.NoError(t, err)
+		assert.False(t, result.Matched)
+		assert.Equal(t, 1, result.FailedCondition)
+		require.Len(t, result.Results, 3)
+		assert.False(t, result.Results[0].CausedFailure)
+		assert.True(t, result.Results[1].CausedFailure)
+		assert.True(t, result.Results[2].CausedFailure)
+	})
+
+	t.Run("failed allOf blames unmatched conditions only", func(t *testing.T) {
+		result, err := evaluator.EvaluateConditions([]ConditionDef{
+			{AllOf: []ConditionDef{
+				{Field: "status", Operator: OperatorEquals, Value: "Ready"},
+				{Field: "provider", Operator: OperatorEquals, Value: "aws"},
+			}},
+		})
+		require.NoError(t, err)
+		assert.False(t, result.Matched)
+		require.Len(t, result.Results, 2)
+		assert.False(t, result.Results[0].CausedFailure)
+		assert.True(t, result.Results[1].CausedFailure)
+		assert.Equal(t, "[0].allOf[1]", result.Results[1].Path)
+	})
+
+	t.Run("failed not blames matched condition", func(t *testing.T) {
+		result, err := evaluator.EvaluateConditions([]ConditionDef{
+			{Not: &ConditionDef{Field: "region", Operator: OperatorStartsWith, Value: "us-gov-"}},
+		})
+		require.NoError(t, err)
+		assert.False(t, result.Matched)
+		require.Len(t, result.Results, 1)
+		assert.True(t, result.Results[0].Matched)
+		assert.True(t, result.Results[0].Negated)
+		assert.True(t, result.Results[0].CausedFailure)
+		assert.Equal(t, "[0].not", result.Results[0].Path)
+	})
+
+	t.Run("nested groups", func(t *testing.T) {
+		// not(anyOf(provider == gcp, status == Pending)) fails because provider == gcp
+		result, err := evaluator.EvaluateConditions([]ConditionDef{
+			{Not: &ConditionDef{AnyOf: []ConditionDef{
+				{Field: "provider", Operator: OperatorEquals, Value: "gcp"},
+				{Field: "status", Operator: OperatorEquals, Value: "Pending"},
+			}}},
+		})
+		require.NoError(t, err)
+		assert.False(t, result.Matched)
+		require.Len(t, result.Results, 1, "anyOf stops at the first match")
+		assert.True(t, result.Results[0].CausedFailure)
+		assert.Equal(t, "[0].not.anyOf[0]", result.Results[0].Path)
+	})
+
+	t.Run("allOf stops at the first miss", func(t *testing.T) {
+		result, err := evaluator.EvaluateConditions([]ConditionDef{
+			{AllOf: []ConditionDef{
+				{Field: "provider", Operator: OperatorEquals, Value: "aws"},
+				{Field: "status", Operator: OperatorEquals, Value: "Ready"},
+			}},
+		})
+		require.NoError(t, err)
+		assert.False(t, result.Matched)
+		require.Len(t, result.Results, 1)
+		assert.True(t, result.Results[0].CausedFailure)
+	})
+
+	t.Run("missing field under anyOf counts as not matched", func(t *testing.T) {
+		result, err := evaluator.EvaluateConditions([]ConditionDef{
+			{AnyOf: []ConditionDef{
+				{Field: "nodeCount", Operator: OperatorGreaterThan, Value: 3},
+				{Field: "status", Operator: OperatorEquals, Value: "Ready"},
+			}},
+		})
+		require.NoError(t, err)
+		assert.True(t, result.Matched)
+		require.Len(t, result.Results, 2)
+		assert.False(t, result.Results[0].Matched)
+		assert.Error(t, result.Results[0].Error)
+		assert.True(t, result.Results[1].Matched)
+		assert.NoError(t, result.Results[1].Error)
+	})
+
+	t.Run("missing field under anyOf blames the failed operator", func(t *testing.T) {
+		result, err := evaluator.EvaluateConditions([]ConditionDef{
+			{AnyOf: []ConditionDef{
+				{Field: "createdTime", Operator: OperatorOlderThan, Value: "1h"},
+				{Field: "provider", Operator: OperatorEquals, Value: "aws"},
+			}},
+		})
+		require.NoError(t, err)
+		assert.False(t, result.Matched)
+		require.Len(t, result.Results, 2)
+		assert.True(t, result.Results[0].CausedFailure)
+		assert.Error(t, result.Results[0].Error)
+	})
+
+	t.Run("missing field under not counts as not matched", func(t *testing.T) {
+		result, err := evaluator.EvaluateConditions([]ConditionDef{
+			{Not: &ConditionDef{Field: "labels", Operator: OperatorContains, Value: "legacy"}},
+		})
+		require.NoError(t, err)
+		assert.True(t, result.Matched)
+		require.Len(t, result.Results, 1)
+		assert.False(t, result.Results[0].Matched)
+		assert.Error(t, result.Results[0].Error)
+		assert.Equal(t, "[0].not", result.Results[0].Path)
+	})
+
+	t.Run("operator error in top-level condition", func(t *testing.T) {
+		_, err := evaluator.EvaluateConditions([]ConditionDef{
+			{Field: "nodeCount", Operator: OperatorGreaterThan, Value: 3},
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("unsupported operator in nested condition", func(t *testing.T) {
+		_, err := evaluator.EvaluateConditions([]ConditionDef{
+			{AnyOf: []ConditionDef{
+				{Field: "status", Operator: "unknown", Value: "Ready"},
+			}},
+		})
+		assert.Error(t, err)
+	})
+}
+
 func TestEvaluationResultStruct(t *testing.T) {
 	result := EvaluationResult{
 		Matched:       true,
@@ -1102,3 +1414,14 @@ func TestDeepNullPath(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, result.Value)
 }
+
+func TestCompileRegex(t *testing.T) {
+	first, err := CompileRegex(`^prod-[a-z]+-`)
+	require.NoError(t, err)
+	second, err := CompileRegex(`^prod-[a-z]+-`)
+	require.NoError(t, err)
+	assert.Same(t, first, second, "compiled patterns are reused")
+
+	_, err = CompileRegex(`(`)
+	require.Error(t, err)
+}
@@ -142,7 +142,7 @@ func TestVersionIncrements(t *testing.T) {
 	assert.Equal(t, int64(6), ctx.Version(), "Version should increment for each new Set")
 }
 
-// TestNoVersionChangeNoRecreate verifies CEL evaluator is not recreated unnecessarily
+// TestNoVersionChangeNoRecreate verifies the CEL evaluator is not recreated
 func TestNoVersionChangeNoRecreate(t *testing.T) {
 	ctx := NewEvaluationContext()
 	ctx.Set("status", "Ready")
@@ -180,6 +180,10 @@ func TestNoVersionChangeNoRecreate(t *testing.T) {
 	celEval3, err := evaluator.getCELEvaluator()
 	require.NoError(t, err)
 
-	// Should be a different instance (recreated)
-	assert.NotSame(t, celEval1, celEval3, "CEL evaluator should be recreated when context changes")
+	// Programs are shared and read a snapshot of the context, so the evaluator is kept
+	// and the new variable is visible
+	assert.Same(t, celEval1, celEval3, "CEL evaluator should not depend on the context data")
+	result3, err := evaluator.EvaluateCEL("replicas == 3")
+	require.NoError(t, err)
+	assert.True(t, result3.Matched)
 }
@@ -9,8 +9,12 @@ import (
 )
 
 type FieldResult struct {
+	// Value is nil when nothing matched, the match itself for a single match,
+	// and the list of matches for several matches
 	Value interface{}
-	Error error
+	// Values holds every match in document order (empty when nothing matched)
+	Values []interface{}
+	Error  error
 }
 
 // ExtractField extracts a field from any data structure using JSONPath.
@@ -21,6 +25,10 @@ type FieldResult struct {
 //   - JSONPath with filter: "{.items[?(@.adapter=='landing-zone-adapter')].data.namespace.status}"
 //
 // Simple paths are auto-converted to JSONPath (e.g., "metadata.name" → "{.metadata.name}")
+//
+// A path matches one value per node it selects: "items" matches the items list once, while
+// "{.items[*]}" matches each item. Since Value depends on the number of matches, a filter
+// that may select several nodes should be read through Values (or a capture mode).
 func ExtractField(data interface{}, field string) (*FieldResult, error) {
 	result := &FieldResult{}
 	originalField := field
@@ -80,6 +88,7 @@ func ExtractField(data interface{}, field string) (*FieldResult, error) {
 	}
 
 	// Set result value based on count
+	result.Values = values
 	switch len(values) {
 	case 0:
 		// result.Value remains nil
@@ -0,0 +1,86 @@
+package criteria
+
+// EvaluationTrace explains the evaluation of a precondition: the values it captured, the
+// result of each structured condition and the values of the CEL sub-expressions
+type EvaluationTrace struct {
+	// Precondition is the name of the evaluated precondition
+	Precondition string `json:"precondition"`
+	// Matched indicates whether the precondition was met
+	Matched bool `json:"matched"`
+	// Captured contains the values captured from the API response
+	Captured map[string]interface{} `json:"captured,omitempty"`
+	// Conditions contains the result of each evaluated structured condition, nested conditions included
+	Conditions []ConditionTrace `json:"conditions,omitempty"`
+	// Expression is the evaluated CEL expression
+	Expression string `json:"expression,omitempty"`
+	// SubExpressions contains the values of the evaluated CEL sub-expressions, in pre-order
+	SubExpressions []CELTraceEntry `json:"subExpressions,omitempty"`
+	// Error is the CEL evaluation error, if any (e.g. a missing field)
+	Error string `json:"error,omitempty"`
+}
+
+// ConditionTrace is the result of a structured condition in an evaluation trace
+type ConditionTrace struct {
+	// Path locates the condition, e.g. "conditions[1].anyOf[0]"
+	Path string `json:"path"`
+	// Field is the field path that was evaluated
+	Field string `json:"field"`
+	// Operator is the operator used
+	Operator string `json:"operator"`
+	// Expected is the value the field was compared against
+	Expected interface{} `json:"expected"`
+	// Actual is the value of the field
+	Actual interface{} `json:"actual"`
+	// Matched indicates if the condition was satisfied
+	Matched bool `json:"matched"`
+	// Negated indicates the condition is nested in a not group
+	Negated bool `json:"negated,omitempty"`
+	// CausedFailure indicates the condition made the precondition fail
+	CausedFailure bool `json:"causedFailure,omitempty"`
+	// Error is the operator error of a condition nested in a group, if any (e.g. a missing field)
+	Error string `json:"error,omitempty"`
+}
+
+// EvaluationTraceToMap converts an EvaluationTrace to a map for CEL evaluation (nil for a nil trace)
+func EvaluationTraceToMap(trace *EvaluationTrace) interface{} {
+	if trace == nil {
+		return nil
+	}
+
+	captured := make(map[string]interface{}, len(trace.Captured))
+	for name, value := range trace.Captured {
+		captured[name] = value
+	}
+	conditions := make([]interface{}, len(trace.Conditions))
+	for i, cond := range trace.Conditions {
+		conditions[i] = map[string]interface{}{
+			"path":          cond.Path,
+			"field":         cond.Field,
+			"operator":      cond.Operator,
+			"expected":      cond.Expected,
+			"actual":        cond.Actual,
+			"matched":       cond.Matched,
+			"negated":       cond.Negated,
+			"causedFailure": cond.CausedFailure,
+			"error":         cond.Error,
+		}
+	}
+	subExpressions := make([]interface{}, len(trace.SubExpressions))
+	for i, entry := range trace.SubExpressions {
+		subExpressions[i] = map[string]interface{}{
+			"expression": entry.Expression,
+			"value":      entry.Value,
+			"error":      entry.Error,
+		}
+	}
+
+	return map[string]interface{}{
+		"precondition":   trace.Precondition,
+		"matched":        trace.Matched,
+		"captured":       captured,
+		"conditions":     conditions,
+		"expression":     trace.Expression,
+		"subExpressions": subExpressions,
+		"error":          trace.Error,
+	}
+}
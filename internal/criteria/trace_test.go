@@ -0,0 +1,45 @@
+package criteria
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvaluationTraceToMap(t *testing.T) {
+	assert.Nil(t, EvaluationTraceToMap(nil))
+
+	trace := &EvaluationTrace{
+		Precondition: "checkNodes",
+		Captured:     map[string]interface{}{"nodeCount": 3},
+		Conditions: []ConditionTrace{{
+			Path:          "conditions[0].anyOf[0]",
+			Field:         "nodeCount",
+			Operator:      "greaterThan",
+			Expected:      5,
+			Actual:        3,
+			CausedFailure: true,
+		}},
+		Expression:     "nodeCount > 5",
+		SubExpressions: []CELTraceEntry{{Expression: "nodeCount > 5", Value: false}},
+	}
+
+	m, ok := EvaluationTraceToMap(trace).(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, "checkNodes", m["precondition"])
+	assert.Equal(t, false, m["matched"])
+	assert.Equal(t, map[string]interface{}{"nodeCount": 3}, m["captured"])
+	assert.Equal(t, "nodeCount > 5", m["expression"])
+
+	conditions := m["conditions"].([]interface{})
+	require.Len(t, conditions, 1)
+	condition := conditions[0].(map[string]interface{})
+	assert.Equal(t, "conditions[0].anyOf[0]", condition["path"])
+	assert.Equal(t, true, condition["causedFailure"])
+	assert.Equal(t, "", condition["error"])
+
+	subExpressions := m["subExpressions"].([]interface{})
+	require.Len(t, subExpressions, 1)
+	assert.Equal(t, false, subExpressions[0].(map[string]interface{})["value"])
+}
@@ -24,8 +24,32 @@ const (
 	OperatorGreaterThan Operator = "greaterThan"
 	// OperatorLessThan checks if field is less than value
 	OperatorLessThan Operator = "lessThan"
+	// OperatorGreaterThanOrEqual checks if field is greater than or equal to value
+	OperatorGreaterThanOrEqual Operator = "greaterThanOrEqual"
+	// OperatorLessThanOrEqual checks if field is less than or equal to value
+	OperatorLessThanOrEqual Operator = "lessThanOrEqual"
+	// OperatorBetween checks if a numeric field is within an inclusive [min, max] range
+	OperatorBetween Operator = "between"
+	// OperatorMatches checks if a string field matches a regular expression
+	OperatorMatches Operator = "matches"
+	// OperatorStartsWith checks if a string field starts with value
+	OperatorStartsWith Operator = "startsWith"
+	// OperatorEndsWith checks if a string field ends with value
+	OperatorEndsWith Operator = "endsWith"
 	// OperatorExists checks if field exists (is not nil/empty)
 	OperatorExists Operator = "exists"
+	// OperatorNotExists checks if field does not exist (is nil/empty)
+	OperatorNotExists Operator = "notExists"
+	// OperatorIsEmpty checks if a string, list or map field is nil or empty
+	OperatorIsEmpty Operator = "isEmpty"
+	// OperatorNotEmpty checks if a string, list or map field is not empty
+	OperatorNotEmpty Operator = "notEmpty"
+	// OperatorOlderThan checks if an RFC 3339 timestamp field is older than a duration
+	OperatorOlderThan Operator = "olderThan"
+	// OperatorNewerThan checks if an RFC 3339 timestamp field is newer than a duration
+	OperatorNewerThan Operator = "newerThan"
+	// OperatorSemverAtLeast checks if a semantic version field is greater than or equal to value
+	OperatorSemverAtLeast Operator = "semverAtLeast"
 )
 
 // SupportedOperators lists all supported operators.
@@ -37,7 +61,28 @@ var SupportedOperators = []Operator{
 	OperatorContains,
 	OperatorGreaterThan,
 	OperatorLessThan,
+	OperatorGreaterThanOrEqual,
+	OperatorLessThanOrEqual,
+	OperatorBetween,
+	OperatorMatches,
+	OperatorStartsWith,
+	OperatorEndsWith,
 	OperatorExists,
+	OperatorNotExists,
+	OperatorIsEmpty,
+	OperatorNotEmpty,
+	OperatorOlderThan,
+	OperatorNewerThan,
+	OperatorSemverAtLeast,
+}
+
+// IsValuelessOperator reports whether the operator only checks the field and takes no value
+func IsValuelessOperator(op Operator) bool {
+	switch op { //nolint:exhaustive // only the operators without value
+	case OperatorExists, OperatorNotExists, OperatorIsEmpty, OperatorNotEmpty:
+		return true
+	}
+	return false
 }
 
 // IsValidOperator checks if the given operator string is valid
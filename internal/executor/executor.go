@@ -6,13 +6,13 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"time"
 
 	"github.com/cloudevents/sdk-go/v2/event"
 	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/config_loader"
 	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/hyperfleet_api"
 	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/transport_client"
 	"github.com/openshift-hyperfleet/hyperfleet-adapter/pkg/logger"
-	pkgotel "github.com/openshift-hyperfleet/hyperfleet-adapter/pkg/otel"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/trace"
 )
@@ -22,14 +22,18 @@ func NewExecutor(config *ExecutorConfig) (*Executor, error) {
 	if err := validateExecutorConfig(config); err != nil {
 		return nil, err
 	}
+	registerMetrics()
 
-	return &Executor{
+	e := &Executor{
 		config:             config,
 		precondExecutor:    newPreconditionExecutor(config),
 		resourceExecutor:   newResourceExecutor(config),
 		postActionExecutor: newPostActionExecutor(config),
+		keyLocks:           newKeyLocker(),
 		log:                config.Logger,
-	}, nil
+	}
+	e.current.Store(config.Config)
+	return e, nil
 }
 
 func validateExecutorConfig(config *ExecutorConfig) error {
@@ -43,8 +47,7 @@ func validateExecutorConfig(config *ExecutorConfig) error {
 
 	requiredFields := []string{
 		"APIClient",
-		"Logger",
-		"TransportClient"}
+		"Logger"}
 
 	for _, field := range requiredFields {
 		if reflect.ValueOf(config).Elem().FieldByName(field).IsNil() {
@@ -52,15 +55,47 @@ func validateExecutorConfig(config *ExecutorConfig) error {
 		}
 	}
 
+	if config.TransportClient == nil && len(config.TransportClients) == 0 {
+		return fmt.Errorf("field TransportClient or TransportClients is required")
+	}
+	for name, client := range config.TransportClients {
+		if client == nil {
+			return fmt.Errorf("transport client %q is nil", name)
+		}
+	}
+
 	return nil
 }
 
 // Execute processes event data according to the adapter configuration
+// Executions of events for the same resource (see EventData.Key) are serialized,
+// whether they come from the broker or from the resync loop.
+// Logs and metrics are labeled with the task config name.
 // The caller is responsible for:
 // - Adding event ID to context for logging correlation using logger.WithEventID()
 func (e *Executor) Execute(ctx context.Context, data interface{}) *ExecutionResult {
+	// Snapshot the config so a reload never changes it in the middle of an event
+	config := e.Config()
+	taskName := config.GetTaskName()
+	if taskName != "" {
+		ctx = logger.WithTask(ctx, taskName)
+	}
+
+	start := time.Now()
+	result := e.execute(ctx, config, data)
+	recordExecution(config.Metadata.Name, taskName, result.Status, time.Since(start))
+	return result
+}
+
+// Config returns the config currently used for new executions
+func (e *Executor) Config() *config_loader.Config {
+	return e.current.Load()
+}
+
+// execute runs the execution phases for a single event
+func (e *Executor) execute(ctx context.Context, config *config_loader.Config, data interface{}) *ExecutionResult {
 	// Start OTel span and add trace context to logs
-	ctx, span := e.startTracedExecution(ctx)
+	ctx, span := e.startTracedExecution(ctx, config.Metadata.Name)
 	defer span.End()
 
 	// Parse event data
@@ -76,6 +111,9 @@ func (e *Executor) Execute(ctx context.Context, data interface{}) *ExecutionResu
 		}
 	}
 
+	unlock := e.keyLocks.Lock(eventData.Key())
+	defer unlock()
+
 	// This is intended to set OwnerReference and ResourceID for the event when it exist
 	// For example, when a NodePool event arrived
 	// the logger will set the cluster_id=owner_id, nodepool_id=resource_id, resource_type=nodepool
@@ -88,7 +126,7 @@ func (e *Executor) Execute(ctx context.Context, data interface{}) *ExecutionResu
 		ctx = logger.WithDynamicResourceID(ctx, eventData.Kind, eventData.ID)
 	}
 
-	execCtx := NewExecutionContext(ctx, rawData, e.config.Config)
+	execCtx := NewExecutionContext(ctx, rawData, config)
 
 	// Initialize execution result
 	result := &ExecutionResult{
@@ -116,7 +154,7 @@ func (e *Executor) Execute(ctx context.Context, data interface{}) *ExecutionResu
 
 	// Phase 2: Preconditions
 	result.CurrentPhase = PhasePreconditions
-	preconditions := e.config.Config.Spec.Preconditions
+	preconditions := config.Spec.Preconditions
 	e.log.Infof(ctx, "Phase %s: RUNNING - %d configured", result.CurrentPhase, len(preconditions))
 	precondOutcome := e.precondExecutor.ExecuteAll(ctx, preconditions, execCtx)
 	result.PreconditionResults = precondOutcome.Results
@@ -146,7 +184,7 @@ func (e *Executor) Execute(ctx context.Context, data interface{}) *ExecutionResu
 
 	// Phase 3: Resources (skip if preconditions not met or previous error)
 	result.CurrentPhase = PhaseResources
-	resources := e.config.Config.Spec.Resources
+	resources := config.Spec.Resources
 	e.log.Infof(ctx, "Phase %s: RUNNING - %d configured", result.CurrentPhase, len(resources))
 	if !result.ResourcesSkipped {
 		resourceResults, err := e.resourceExecutor.ExecuteAll(ctx, resources, execCtx)
@@ -169,7 +207,7 @@ func (e *Executor) Execute(ctx context.Context, data interface{}) *ExecutionResu
 
 	// Phase 4: Post Actions (always execute for error reporting)
 	result.CurrentPhase = PhasePostActions
-	postConfig := e.config.Config.Spec.Post
+	postConfig := config.Spec.Post
 	postActionCount := 0
 	if postConfig != nil {
 		postActionCount = len(postConfig.PostActions)
@@ -209,12 +247,12 @@ func (e *Executor) Execute(ctx context.Context, data interface{}) *ExecutionResu
 // executeParamExtraction extracts parameters from the event and environment
 func (e *Executor) executeParamExtraction(execCtx *ExecutionContext) error {
 	// Extract configured parameters
-	if err := extractConfigParams(e.config.Config, execCtx); err != nil {
+	if err := extractConfigParams(execCtx.Config, execCtx, e.log); err != nil {
 		return err
 	}
 
 	// Add metadata params
-	addMetadataParams(e.config.Config, execCtx)
+	addMetadataParams(execCtx.Config, execCtx)
 
 	return nil
 }
@@ -226,8 +264,7 @@ func (e *Executor) executeParamExtraction(execCtx *ExecutionContext) error {
 //   - Creates an OTel span with trace_id and span_id (for distributed tracing)
 //   - Adds trace_id and span_id to logger context (for log correlation)
 //   - The trace context is automatically propagated to outgoing HTTP requests
-func (e *Executor) startTracedExecution(ctx context.Context) (context.Context, trace.Span) {
-	componentName := e.config.Config.Metadata.Name
+func (e *Executor) startTracedExecution(ctx context.Context, componentName string) (context.Context, trace.Span) {
 	ctx, span := otel.Tracer(componentName).Start(ctx, "Execute")
 
 	// Add trace_id and span_id to logger context for log correlation
@@ -237,32 +274,15 @@ func (e *Executor) startTracedExecution(ctx context.Context) (context.Context, t
 }
 
 // CreateHandler creates an event handler function that can be used with the broker subscriber
-// This is a convenience method for integrating with the broker_consumer package
+// This is a convenience method for integrating with the broker_consumer package.
+// Events that do not match the task config (see Matches) are skipped.
 //
 // Error handling strategy:
 // - All failures are logged but the message is ACKed (return nil)
 // - This prevents infinite retry loops for non-recoverable errors (e.g., 400 Bad Request, invalid data)
 func (e *Executor) CreateHandler() func(ctx context.Context, evt *event.Event) error {
-	return func(ctx context.Context, evt *event.Event) error {
-		// Add event ID to context for logging correlation
-		ctx = logger.WithEventID(ctx, evt.ID())
-
-		// Extract W3C trace context from CloudEvent extensions (if present)
-		// This enables distributed tracing when upstream services (e.g., Sentinel)
-		// include traceparent/tracestate in the CloudEvent
-		ctx = pkgotel.ExtractTraceContextFromCloudEvent(ctx, evt)
-
-		// Log event metadata
-		e.log.Infof(ctx, "Event received: id=%s type=%s source=%s time=%s",
-			evt.ID(), evt.Type(), evt.Source(), evt.Time())
-
-		_ = e.Execute(ctx, evt.Data())
-
-		e.log.Infof(ctx, "Event processed: type=%s source=%s time=%s",
-			evt.Type(), evt.Source(), evt.Time())
-
-		return nil
-	}
+	router := &Router{executors: []*Executor{e}, log: e.log}
+	return router.CreateHandler()
 }
 
 // ParseEventData parses event data from various input types into structured EventData and raw map.
@@ -341,6 +361,16 @@ func (b *ExecutorBuilder) WithTransportClient(client transport_client.TransportC
 	return b
 }
 
+// WithNamedTransportClient registers the transport client used for resources whose
+// transport.client is the given type (e.g. "kubernetes" or "maestro")
+func (b *ExecutorBuilder) WithNamedTransportClient(name string, client transport_client.TransportClient) *ExecutorBuilder {
+	if b.config.TransportClients == nil {
+		b.config.TransportClients = make(map[string]transport_client.TransportClient)
+	}
+	b.config.TransportClients[name] = client
+	return b
+}
+
 // WithLogger sets the logger
 func (b *ExecutorBuilder) WithLogger(log logger.Logger) *ExecutorBuilder {
 	b.config.Logger = log
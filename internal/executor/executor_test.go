@@ -2,6 +2,7 @@ package executor
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	"github.com/cloudevents/sdk-go/v2/event"
@@ -9,7 +10,9 @@ import (
 	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/criteria"
 	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/hyperfleet_api"
 	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/k8s_client"
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/transport_client"
 	"github.com/openshift-hyperfleet/hyperfleet-adapter/pkg/logger"
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/pkg/utils"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -65,6 +68,27 @@ func TestNewExecutor(t *testing.T) {
 			},
 			expectError: false,
 		},
+		{
+			name: "missing transport client",
+			config: &ExecutorConfig{
+				Config:    &config_loader.Config{},
+				APIClient: newMockAPIClient(),
+				Logger:    logger.NewTestLogger(),
+			},
+			expectError: true,
+		},
+		{
+			name: "valid config with named transport clients",
+			config: &ExecutorConfig{
+				Config:    &config_loader.Config{},
+				APIClient: newMockAPIClient(),
+				TransportClients: map[string]transport_client.TransportClient{
+					config_loader.TransportClientKubernetes: k8s_client.NewMockK8sClient(),
+				},
+				Logger: logger.NewTestLogger(),
+			},
+			expectError: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -362,7 +386,7 @@ func TestParamExtractor(t *testing.T) {
 			}
 
 			// Extract params using pure function
-			err := extractConfigParams(config, execCtx)
+			err := extractConfigParams(config, execCtx, logger.NewTestLogger())
 
 			if tt.expectError {
 				assert.Error(t, err)
@@ -385,6 +409,7 @@ func TestRenderTemplate(t *testing.T) {
 		name        string
 		template    string
 		data        map[string]interface{}
+		lenient     bool
 		expected    string
 		expectError bool
 	}{
@@ -414,11 +439,174 @@ func TestRenderTemplate(t *testing.T) {
 			data:        map[string]interface{}{},
 			expectError: true,
 		},
+		{
+			name:     "missing variable in lenient mode",
+			template: "name={{ .missing }}",
+			data:     map[string]interface{}{},
+			lenient:  true,
+			expected: "name=",
+		},
+		{
+			name:     "missing variables in lenient mode inside control structures",
+			template: `{{ if .enabled }}on{{ else }}off={{ .reason }}{{ end }}{{ range .items }}{{ .name }},{{ end }}{{ with .cluster }}{{ .id }}/{{ .zone }}{{ end }}`,
+			data: map[string]interface{}{
+				"items":   []interface{}{map[string]interface{}{"name": "a"}, map[string]interface{}{}},
+				"cluster": map[string]interface{}{"id": "test-123"},
+			},
+			lenient:  true,
+			expected: "off=a,,test-123/",
+		},
+		{
+			name:     "nil value in lenient mode",
+			template: "region={{ .region }}",
+			data:     map[string]interface{}{"region": nil},
+			lenient:  true,
+			expected: "region=",
+		},
+		{
+			name:     "literal no value text is kept in lenient mode",
+			template: "{{ .message }}{{ .missing }}",
+			data:     map[string]interface{}{"message": "<no value>"},
+			lenient:  true,
+			expected: "<no value>",
+		},
+		{
+			name:     "missing nested variable in lenient mode",
+			template: "{{ .cluster.missing | default \"none\" }}",
+			data: map[string]interface{}{
+				"cluster": map[string]interface{}{"id": "test-123"},
+			},
+			lenient:  true,
+			expected: "none",
+		},
+		{
+			name:        "required fails on missing value in lenient mode",
+			template:    `{{ required "region is required" .region }}`,
+			data:        map[string]interface{}{},
+			lenient:     true,
+			expectError: true,
+		},
+		{
+			name:        "required fails on empty string",
+			template:    `{{ required "region is required" .region }}`,
+			data:        map[string]interface{}{"region": ""},
+			expectError: true,
+		},
+		{
+			name:     "required passes value through",
+			template: `{{ required "region is required" .region }}`,
+			data:     map[string]interface{}{"region": "us-east-1"},
+			expected: "us-east-1",
+		},
+		{
+			name:     "toYaml with nindent",
+			template: "labels:{{ .labels | toYaml | nindent 2 }}",
+			data: map[string]interface{}{
+				"labels": map[string]interface{}{"app": "web", "tier": "gold"},
+			},
+			expected: "labels:\n  app: web\n  tier: gold",
+		},
+		{
+			name:     "toJson",
+			template: "{{ toJson .spec }}",
+			data: map[string]interface{}{
+				"spec": map[string]interface{}{"replicas": 3, "zones": []interface{}{"a", "b"}},
+			},
+			expected: `{"replicas":3,"zones":["a","b"]}`,
+		},
+		{
+			name:     "indent multi-line",
+			template: `{{ indent 4 "a\nb" }}`,
+			data:     map[string]interface{}{},
+			expected: "    a\n    b",
+		},
+		{
+			name:     "base64 round trip",
+			template: "{{ .secret | b64enc }}/{{ .secret | b64enc | b64dec }}",
+			data:     map[string]interface{}{"secret": "s3cr3t"},
+			expected: "czNjcjN0/s3cr3t",
+		},
+		{
+			name:        "invalid base64",
+			template:    `{{ b64dec "not base64!" }}`,
+			data:        map[string]interface{}{},
+			expectError: true,
+		},
+		{
+			name:     "sha256sum",
+			template: `{{ sha256sum "hello" }}`,
+			data:     map[string]interface{}{},
+			expected: "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824",
+		},
+		{
+			name:     "list and join",
+			template: `{{ list "a" "b" "c" | join "," }}`,
+			data:     map[string]interface{}{},
+			expected: "a,b,c",
+		},
+		{
+			name:     "join typed slice",
+			template: `{{ join "-" .zones }}`,
+			data:     map[string]interface{}{"zones": []string{"us", "east", "1"}},
+			expected: "us-east-1",
+		},
+		{
+			name:     "dict",
+			template: `{{ $d := dict "name" .name "region" "us" }}{{ $d.name }}@{{ $d.region }}`,
+			data:     map[string]interface{}{"name": "web"},
+			expected: "web@us",
+		},
+		{
+			name:     "split",
+			template: `{{ $parts := split "/" .ref }}{{ $parts._1 }}`,
+			data:     map[string]interface{}{"ref": "ns/name"},
+			expected: "name",
+		},
+		{
+			name:     "splitList",
+			template: `{{ range splitList "," .csv }}[{{ . }}]{{ end }}`,
+			data:     map[string]interface{}{"csv": "a,b"},
+			expected: "[a][b]",
+		},
+		{
+			name:     "regexReplaceAll with capture group",
+			template: `{{ regexReplaceAll "^cls-(.*)$" .id "cluster-${1}" }}`,
+			data:     map[string]interface{}{"id": "cls-123"},
+			expected: "cluster-123",
+		},
+		{
+			name:        "regexReplaceAll invalid regex",
+			template:    `{{ regexReplaceAll "(" .id "" }}`,
+			data:        map[string]interface{}{"id": "x"},
+			expectError: true,
+		},
+		{
+			name:     "trunc",
+			template: `{{ trunc 5 .name }}/{{ trunc -3 .name }}/{{ trunc 50 .name }}`,
+			data:     map[string]interface{}{"name": "hyperfleet"},
+			expected: "hyper/eet/hyperfleet",
+		},
+		{
+			name:     "dns1123",
+			template: `{{ dns1123 .name }}`,
+			data:     map[string]interface{}{"name": "--My_Cluster.Prod--"},
+			expected: "my-cluster-prod",
+		},
+		{
+			name:     "dns1123 truncates to 63 characters",
+			template: `{{ dns1123 .name | len }}`,
+			data:     map[string]interface{}{"name": strings.Repeat("a", 70)},
+			expected: "63",
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result, err := renderTemplate(tt.template, tt.data)
+			opts := utils.DefaultTemplateOptions
+			if tt.lenient {
+				opts = utils.TemplateOptions{Strict: false}
+			}
+			result, err := renderTemplate(tt.template, tt.data, opts)
 
 			if tt.expectError {
 				assert.Error(t, err)
@@ -547,6 +735,194 @@ func TestSequentialExecution_Preconditions(t *testing.T) {
 	}
 }
 
+// TestPreconditionConditionGroups tests that a failed condition group reports the failing branches
+func TestPreconditionConditionGroups(t *testing.T) {
+	config := &config_loader.Config{
+		Metadata: config_loader.Metadata{
+			Name: "test-adapter",
+		},
+		Spec: config_loader.ConfigSpec{
+			Params: []config_loader.Parameter{
+				{Name: "provider", Source: "event.provider"},
+				{Name: "region", Source: "event.region"},
+			},
+			Preconditions: []config_loader.Precondition{{
+				ActionBase: config_loader.ActionBase{Name: "checkPlacement"},
+				Conditions: []config_loader.Condition{
+					{Field: "provider", Operator: "exists"},
+					{AnyOf: []config_loader.Condition{
+						{Field: "provider", Operator: "equals", Value: "aws"},
+						{Not: &config_loader.Condition{Field: "region", Operator: "startsWith", Value: "us-gov-"}},
+					}},
+				},
+			}},
+		},
+	}
+
+	exec, err := NewBuilder().
+		WithConfig(config).
+		WithAPIClient(newMockAPIClient()).
+		WithTransportClient(k8s_client.NewMockK8sClient()).
+		WithLogger(logger.NewTestLogger()).
+		Build()
+	require.NoError(t, err)
+
+	ctx := logger.WithEventID(context.Background(), "test-event-groups")
+	result := exec.Execute(ctx, map[string]interface{}{"provider": "gcp", "region": "us-gov-west-1"})
+
+	assert.Equal(t, StatusSuccess, result.Status)
+	assert.True(t, result.ResourcesSkipped, "ResourcesSkipped")
+	assert.Contains(t, result.SkipReason, "conditions[1].anyOf[0]: provider equals aws (actual: gcp)")
+	assert.Contains(t, result.SkipReason, "conditions[1].anyOf[1].not: not(region startsWith us-gov-) (actual: us-gov-west-1)")
+	assert.NotContains(t, result.SkipReason, "exists")
+
+	require.NotNil(t, result.ExecutionContext)
+	require.Len(t, result.ExecutionContext.Evaluations, 1)
+	fieldResults := result.ExecutionContext.Evaluations[0].FieldResults
+	assert.Len(t, fieldResults, 3)
+	assert.True(t, fieldResults["provider"].Matched)
+	assert.True(t, fieldResults["conditions[1].anyOf[0]"].CausedFailure)
+	assert.True(t, fieldResults["conditions[1].anyOf[1].not"].Negated)
+}
+
+// TestPreconditionConditionGroupErrors tests that a nested condition failing on a missing field
+// counts as not matched and is reported with its error
+func TestPreconditionConditionGroupErrors(t *testing.T) {
+	config := &config_loader.Config{
+		Metadata: config_loader.Metadata{
+			Name: "test-adapter",
+		},
+		Spec: config_loader.ConfigSpec{
+			Params: []config_loader.Parameter{
+				{Name: "provider", Source: "event.provider"},
+				{Name: "nodeCount", Source: "event.nodeCount"},
+			},
+			Preconditions: []config_loader.Precondition{{
+				ActionBase: config_loader.ActionBase{Name: "checkSize"},
+				Conditions: []config_loader.Condition{
+					{AnyOf: []config_loader.Condition{
+						{Field: "nodeCount", Operator: "greaterThan", Value: 3},
+						{Field: "provider", Operator: "equals", Value: "aws"},
+					}},
+				},
+			}},
+		},
+	}
+
+	exec, err := NewBuilder().
+		WithConfig(config).
+		WithAPIClient(newMockAPIClient()).
+		WithTransportClient(k8s_client.NewMockK8sClient()).
+		WithLogger(logger.NewTestLogger()).
+		Build()
+	require.NoError(t, err)
+
+	ctx := logger.WithEventID(context.Background(), "test-event-group-errors")
+	result := exec.Execute(ctx, map[string]interface{}{"provider": "gcp"})
+
+	assert.Equal(t, StatusSuccess, result.Status)
+	assert.True(t, result.ResourcesSkipped, "ResourcesSkipped")
+	assert.Contains(t, result.SkipReason, "conditions[0].anyOf[0]: nodeCount greaterThan 3 (error: ")
+	assert.Contains(t, result.SkipReason, "conditions[0].anyOf[1]: provider equals aws (actual: gcp)")
+}
+
+// TestPreconditionEvaluationTrace tests that preconditions record an evaluation trace
+func TestPreconditionEvaluationTrace(t *testing.T) {
+	config := &config_loader.Config{
+		Metadata: config_loader.Metadata{
+			Name: "test-adapter",
+		},
+		Spec: config_loader.ConfigSpec{
+			Params: []config_loader.Parameter{
+				{Name: "provider", Source: "event.provider"},
+				{Name: "nodeCount", Source: "event.nodeCount"},
+			},
+			Preconditions: []config_loader.Precondition{
+				{
+					ActionBase: config_loader.ActionBase{Name: "checkProvider"},
+					Conditions: []config_loader.Condition{
+						{Field: "provider", Operator: "in", Value: []interface{}{"aws", "gcp"}},
+					},
+				},
+				{
+					ActionBase: config_loader.ActionBase{Name: "checkNodes"},
+					Expression: `provider == "gcp" && nodeCount > 5`,
+				},
+			},
+		},
+	}
+
+	execute := func(t *testing.T, log logger.Logger) *ExecutionResult {
+		exec, err := NewBuilder().
+			WithConfig(config).
+			WithAPIClient(newMockAPIClient()).
+			WithTransportClient(k8s_client.NewMockK8sClient()).
+			WithLogger(log).
+			Build()
+		require.NoError(t, err)
+
+		ctx := logger.WithEventID(context.Background(), "test-event-trace")
+		result := exec.Execute(ctx, map[string]interface{}{"provider": "gcp", "nodeCount": 3})
+
+		assert.True(t, result.ResourcesSkipped, "ResourcesSkipped")
+		require.NotNil(t, result.ExecutionContext)
+		require.Len(t, result.ExecutionContext.Evaluations, 2)
+		return result
+	}
+
+	// assertNotMetTrace checks the trace of the precondition not met, also available to
+	// payloads as adapter.evaluationTrace
+	assertNotMetTrace := func(t *testing.T, result *ExecutionResult) {
+		celTrace := result.ExecutionContext.Evaluations[1].Trace
+		require.NotNil(t, celTrace)
+		assert.False(t, celTrace.Matched)
+		assert.Equal(t, `provider == "gcp" && nodeCount > 5`, celTrace.Expression)
+		subExpressions := make(map[string]interface{})
+		for _, entry := range celTrace.SubExpressions {
+			subExpressions[entry.Expression] = entry.Value
+		}
+		assert.Equal(t, true, subExpressions[`provider == "gcp"`])
+		assert.Equal(t, false, subExpressions["nodeCount > 5"])
+		assert.Equal(t, float64(3), subExpressions["nodeCount"])
+
+		assert.Same(t, celTrace, result.ExecutionContext.Adapter.EvaluationTrace)
+		adapter := result.ExecutionContext.GetCELVariables()["adapter"].(map[string]interface{})
+		evaluationTrace := adapter["evaluationTrace"].(map[string]interface{})
+		assert.Equal(t, "checkNodes", evaluationTrace["precondition"])
+		assert.Equal(t, false, evaluationTrace["matched"])
+		assert.Len(t, evaluationTrace["subExpressions"], len(celTrace.SubExpressions))
+	}
+
+	t.Run("debug logging traces every precondition", func(t *testing.T) {
+		log, capture := logger.NewCaptureLogger()
+		result := execute(t, log)
+
+		conditionsTrace := result.ExecutionContext.Evaluations[0].Trace
+		require.NotNil(t, conditionsTrace)
+		assert.True(t, conditionsTrace.Matched)
+		require.Len(t, conditionsTrace.Conditions, 1)
+		assert.Equal(t, criteria.ConditionTrace{
+			Path:     "conditions[0]",
+			Field:    "provider",
+			Operator: "in",
+			Expected: []interface{}{"aws", "gcp"},
+			Actual:   "gcp",
+			Matched:  true,
+		}, conditionsTrace.Conditions[0])
+
+		assertNotMetTrace(t, result)
+		assert.True(t, capture.Contains("Precondition[checkProvider] evaluation trace"))
+		assert.True(t, capture.Contains("Precondition[checkNodes] evaluation trace"))
+	})
+
+	t.Run("without debug logging only the precondition not met is traced", func(t *testing.T) {
+		result := execute(t, logger.NewTestLogger())
+
+		assert.Nil(t, result.ExecutionContext.Evaluations[0].Trace)
+		assertNotMetTrace(t, result)
+	})
+}
+
 // TestSequentialExecution_Resources tests that resources stop on first failure
 func TestSequentialExecution_Resources(t *testing.T) {
 	// Note: This test uses dry-run mode and focuses on the sequential logic
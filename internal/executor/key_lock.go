@@ -0,0 +1,49 @@
+package executor
+
+import "sync"
+
+// keyLocker serializes executions that share a key while executions of different
+// keys run in parallel. Entries are reference counted and dropped once unused.
+type keyLocker struct {
+	mu    sync.Mutex
+	locks map[string]*keyLockEntry
+}
+
+type keyLockEntry struct {
+	mu   sync.Mutex
+	refs int
+}
+
+func newKeyLocker() *keyLocker {
+	return &keyLocker{locks: make(map[string]*keyLockEntry)}
+}
+
+// Lock blocks until the key is free and returns the function releasing it.
+// An empty key is never serialized.
+func (l *keyLocker) Lock(key string) func() {
+	if key == "" {
+		return func() {}
+	}
+
+	l.mu.Lock()
+	entry, ok := l.locks[key]
+	if !ok {
+		entry = &keyLockEntry{}
+		l.locks[key] = entry
+	}
+	entry.refs++
+	l.mu.Unlock()
+
+	entry.mu.Lock()
+
+	return func() {
+		entry.mu.Unlock()
+
+		l.mu.Lock()
+		entry.refs--
+		if entry.refs == 0 {
+			delete(l.locks, key)
+		}
+		l.mu.Unlock()
+	}
+}
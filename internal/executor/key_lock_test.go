@@ -0,0 +1,75 @@
+package executor
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeyLocker(t *testing.T) {
+	t.Run("serializes the same key", func(t *testing.T) {
+		locks := newKeyLocker()
+		var running, maxRunning int32
+		var wg sync.WaitGroup
+
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				unlock := locks.Lock("cluster/abc")
+				defer unlock()
+
+				n := atomic.AddInt32(&running, 1)
+				for {
+					m := atomic.LoadInt32(&maxRunning)
+					if n <= m || atomic.CompareAndSwapInt32(&maxRunning, m, n) {
+						break
+					}
+				}
+				time.Sleep(time.Millisecond)
+				atomic.AddInt32(&running, -1)
+			}()
+		}
+		wg.Wait()
+
+		assert.Equal(t, int32(1), maxRunning)
+		assert.Empty(t, locks.locks, "unused entries are released")
+	})
+
+	t.Run("different keys do not block each other", func(t *testing.T) {
+		locks := newKeyLocker()
+		unlockA := locks.Lock("cluster/a")
+		defer unlockA()
+
+		done := make(chan struct{})
+		go func() {
+			unlock := locks.Lock("cluster/b")
+			unlock()
+			close(done)
+		}()
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("lock on a different key blocked")
+		}
+	})
+
+	t.Run("empty key is not serialized", func(t *testing.T) {
+		locks := newKeyLocker()
+		unlock1 := locks.Lock("")
+		unlock2 := locks.Lock("")
+		unlock1()
+		unlock2()
+		assert.Empty(t, locks.locks)
+	})
+}
+
+func TestEventDataKey(t *testing.T) {
+	assert.Equal(t, "cluster/abc", (&EventData{ID: "abc", Kind: "Cluster"}).Key())
+	assert.Equal(t, "", (&EventData{Kind: "Cluster"}).Key())
+	assert.Equal(t, "", (*EventData)(nil).Key())
+}
@@ -0,0 +1,43 @@
+package executor
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Event processing metrics, labeled with the adapter component and the task config name
+var (
+	eventsProcessedTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "hyperfleet_adapter_events_processed_total",
+			Help: "Number of events processed by a task config, by execution status",
+		},
+		[]string{"component", "task", "status"},
+	)
+
+	eventProcessingDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "hyperfleet_adapter_event_processing_duration_seconds",
+			Help:    "Time spent processing an event by a task config",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"component", "task"},
+	)
+
+	registerMetricsOnce sync.Once
+)
+
+// registerMetrics registers the executor metrics with the default Prometheus registry
+func registerMetrics() {
+	registerMetricsOnce.Do(func() {
+		prometheus.MustRegister(eventsProcessedTotal, eventProcessingDuration)
+	})
+}
+
+// recordExecution records the outcome and duration of an event execution
+func recordExecution(component, task string, status ExecutionStatus, duration time.Duration) {
+	eventsProcessedTotal.WithLabelValues(component, task, string(status)).Inc()
+	eventProcessingDuration.WithLabelValues(component, task).Observe(duration.Seconds())
+}
@@ -8,8 +8,14 @@ import (
 	"strings"
 
 	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/config_loader"
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/criteria"
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/pkg/logger"
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/pkg/utils"
 )
 
+// paramExpressionsStep is the step name used for errors not tied to a single expression param
+const paramExpressionsStep = "param_expressions"
+
 // ParamConfig interface allows extractConfigParams to work with both AdapterConfig and Config
 type ParamConfig interface {
 	GetParams() []config_loader.Parameter
@@ -17,56 +23,158 @@ type ParamConfig interface {
 }
 
 // extractConfigParams extracts all configured parameters and populates execCtx.Params
+// Source params are extracted first; expression params are then evaluated with CEL
+// in dependency order, with access to earlier params, "event" and "env".
 // This is a pure function that directly modifies execCtx for simplicity
-func extractConfigParams(config ParamConfig, execCtx *ExecutionContext) error {
+func extractConfigParams(config ParamConfig, execCtx *ExecutionContext, log logger.Logger) error {
 	for _, param := range config.GetParams() {
-		value, err := extractParam(param, execCtx.EventData)
-		if err != nil {
-			if param.Required {
-				return NewExecutorError(PhaseParamExtraction, param.Name,
-					fmt.Sprintf("failed to extract required parameter '%s' from source '%s'", param.Name, param.Source), err)
-			}
-			// Use default for non-required params if extraction fails
-			if param.Default != nil {
-				execCtx.Params[param.Name] = param.Default
-			}
+		if param.IsExpression() {
 			continue
 		}
+		value, err := extractParam(param, execCtx.EventData)
+		if err := setParamValue(param, value, err, execCtx); err != nil {
+			return err
+		}
+	}
+
+	return extractExpressionParams(config, execCtx, log)
+}
+
+// extractExpressionParams evaluates expression params in dependency order and populates execCtx.Params
+func extractExpressionParams(config ParamConfig, execCtx *ExecutionContext, log logger.Logger) error {
+	ordered, err := config_loader.OrderExpressionParams(config.GetParams())
+	if err != nil {
+		return NewExecutorError(PhaseParamExtraction, paramExpressionsStep,
+			"failed to resolve parameter expression order", err)
+	}
+	if len(ordered) == 0 {
+		return nil
+	}
+
+	evalCtx := criteria.NewEvaluationContext()
+	evalCtx.SetVariablesFromMap(execCtx.Params)
+	evalCtx.Set("event", execCtx.EventData)
+	evalCtx.Set("env", environmentMap(config_loader.ExpressionEnvVars(ordered)))
+
+	evaluator, err := criteria.NewEvaluator(execCtx.Ctx, evalCtx, log)
+	if err != nil {
+		return NewExecutorError(PhaseParamExtraction, paramExpressionsStep, "failed to create evaluator", err)
+	}
 
-		// Apply default if value is nil or (for strings) empty
-		isEmpty := value == nil
-		if s, ok := value.(string); ok && s == "" {
-			isEmpty = true
+	for _, param := range ordered {
+		celResult, err := evaluator.EvaluateCEL(param.Expression)
+		if err != nil {
+			// Parse/program errors indicate a bug in the config, fail regardless of Required
+			return NewExecutorError(PhaseParamExtraction, param.Name,
+				fmt.Sprintf("failed to evaluate expression for parameter '%s'", param.Name), err)
+		}
+		if err := setParamValue(param, celResult.Value, celResult.Error, execCtx); err != nil {
+			return err
 		}
-		if isEmpty && param.Default != nil {
-			value = param.Default
+		if value, ok := execCtx.Params[param.Name]; ok {
+			evalCtx.Set(param.Name, value)
 		}
+	}
 
-		// Apply type conversion if specified
-		if value != nil && param.Type != "" {
-			converted, convErr := convertParamType(value, param.Type)
-			if convErr != nil {
-				if param.Required {
-					return NewExecutorError(PhaseParamExtraction, param.Name,
-						fmt.Sprintf("failed to convert parameter '%s' to type '%s'", param.Name, param.Type), convErr)
-				}
-				// Use default for non-required params if conversion fails
-				if param.Default != nil {
-					execCtx.Params[param.Name] = param.Default
-				}
-				continue
-			}
-			value = converted
+	return nil
+}
+
+// setParamValue applies defaults, type conversion and schema validation to an extracted value and
+// stores it in execCtx.Params. extractErr is the error returned while extracting the value from its
+// source or expression.
+// A required param fails when its value cannot be extracted, converted or validated. An optional
+// param never fails on its value: it falls back to its default, and is left unset when it has none.
+// The default goes through the same conversion and validation; an invalid default is a config
+// error and fails regardless of Required.
+func setParamValue(param config_loader.Parameter, value interface{}, extractErr error, execCtx *ExecutionContext) error {
+	if extractErr != nil {
+		if param.Required {
+			return NewExecutorError(PhaseParamExtraction, param.Name,
+				fmt.Sprintf("failed to extract required parameter '%s' from %s", param.Name, describeParamOrigin(param)), extractErr)
 		}
+		// Use default for non-required params if extraction fails
+		return setParamDefault(param, execCtx)
+	}
 
-		if value != nil {
-			execCtx.Params[param.Name] = value
+	// Apply default if value is nil or (for strings) empty
+	isEmpty := value == nil
+	if s, ok := value.(string); ok && s == "" {
+		isEmpty = true
+	}
+	if isEmpty && param.Default != nil {
+		return setParamDefault(param, execCtx)
+	}
+	if value == nil {
+		return nil
+	}
+
+	resolved, err := resolveParamValue(param, value)
+	if err != nil {
+		if param.Required {
+			return err
 		}
+		// Use default for non-required params if conversion or validation fails
+		return setParamDefault(param, execCtx)
 	}
+	execCtx.Params[param.Name] = resolved
+	return nil
+}
 
+// setParamDefault stores the converted and validated default of a param in execCtx.Params.
+// Nothing is stored when the param has no default.
+func setParamDefault(param config_loader.Parameter, execCtx *ExecutionContext) error {
+	if param.Default == nil {
+		return nil
+	}
+	resolved, err := resolveParamValue(param, param.Default)
+	if err != nil {
+		return NewExecutorError(PhaseParamExtraction, param.Name,
+			fmt.Sprintf("invalid default for parameter '%s'", param.Name), err)
+	}
+	execCtx.Params[param.Name] = resolved
 	return nil
 }
 
+// resolveParamValue converts a param value to the param type and validates it against the param schema
+func resolveParamValue(param config_loader.Parameter, value interface{}) (interface{}, error) {
+	if param.Type != "" {
+		converted, err := convertParamType(value, param.Type)
+		if err != nil {
+			return nil, NewExecutorError(PhaseParamExtraction, param.Name,
+				fmt.Sprintf("failed to convert parameter '%s' to type '%s'", param.Name, param.Type), err)
+		}
+		value = converted
+	}
+
+	if param.Schema != nil {
+		if err := utils.ValidateJSONSchema(param.Schema, value); err != nil {
+			return nil, NewExecutorError(PhaseParamExtraction, param.Name,
+				fmt.Sprintf("parameter '%s' does not match its schema", param.Name), err)
+		}
+	}
+	return value, nil
+}
+
+// describeParamOrigin returns a human readable description of where a param value comes from
+func describeParamOrigin(param config_loader.Parameter) string {
+	if param.IsExpression() {
+		return fmt.Sprintf("expression '%s'", param.Expression)
+	}
+	return fmt.Sprintf("source '%s'", param.Source)
+}
+
+// environmentMap returns the named environment variables that are set, as a map for CEL evaluation.
+// Only variables referenced by expressions are exposed, never the whole process environment.
+func environmentMap(names []string) map[string]interface{} {
+	env := make(map[string]interface{}, len(names))
+	for _, name := range names {
+		if value, ok := os.LookupEnv(name); ok {
+			env[name] = value
+		}
+	}
+	return env
+}
+
 // extractParam extracts a single parameter based on its source
 func extractParam(param config_loader.Parameter, eventData map[string]interface{}) (interface{}, error) {
 	source := param.Source
@@ -133,7 +241,7 @@ func addMetadataParams(config ParamConfig, execCtx *ExecutionContext) {
 }
 
 // convertParamType converts a value to the specified type
-// Supported types: string, int, int64, float, float64, bool
+// Supported types: string, int, int64, float, float64, bool, list, map, duration, quantity, json
 func convertParamType(value interface{}, targetType string) (interface{}, error) {
 	// If value is already the target type, return as-is
 	switch targetType {
@@ -145,8 +253,10 @@ func convertParamType(value interface{}, targetType string) (interface{}, error)
 		return convertToFloat64(value)
 	case "bool":
 		return convertToBool(value)
+	case "list", "map", "duration", "quantity", "json":
+		return utils.ConvertToType(value, targetType)
 	default:
-		return nil, fmt.Errorf("unsupported type: %s (supported: string, int, int64, float, float64, bool)", targetType)
+		return nil, fmt.Errorf("unsupported type: %s (supported: %s)", targetType, strings.Join(utils.SupportedTypes, ", "))
 	}
 }
 
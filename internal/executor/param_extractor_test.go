@@ -1,8 +1,12 @@
 package executor
 
 import (
+	"context"
 	"testing"
+	"time"
 
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/config_loader"
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/pkg/logger"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -52,6 +56,35 @@ func TestConvertParamType(t *testing.T) {
 		{name: "float non-zero to bool", value: 3.14, targetType: "bool", want: true},
 		{name: "invalid string to bool", value: "maybe", targetType: "bool", wantErr: true},
 
+		// List conversions
+		{name: "list passthrough", value: []interface{}{"a", "b"}, targetType: "list", want: []interface{}{"a", "b"}},
+		{name: "JSON string to list", value: `["a", 1]`, targetType: "list", want: []interface{}{"a", float64(1)}},
+		{name: "typed slice to list", value: []string{"a", "b"}, targetType: "list", want: []interface{}{"a", "b"}},
+		{name: "invalid string to list", value: "a,b", targetType: "list", wantErr: true},
+
+		// Map conversions
+		{name: "map passthrough", value: map[string]interface{}{"k": "v"}, targetType: "map", want: map[string]interface{}{"k": "v"}},
+		{name: "JSON string to map", value: `{"k": "v"}`, targetType: "map", want: map[string]interface{}{"k": "v"}},
+		{name: "typed map to map", value: map[string]string{"k": "v"}, targetType: "map", want: map[string]interface{}{"k": "v"}},
+		{name: "JSON null to map", value: "null", targetType: "map", wantErr: true},
+		{name: "int to map", value: 42, targetType: "map", wantErr: true},
+
+		// Duration conversions
+		{name: "string to duration", value: "1h30m", targetType: "duration", want: 90 * time.Minute},
+		{name: "int seconds to duration", value: 30, targetType: "duration", want: 30 * time.Second},
+		{name: "invalid string to duration", value: "soon", targetType: "duration", wantErr: true},
+
+		// Quantity conversions
+		{name: "string to quantity", value: "1024Mi", targetType: "quantity", want: "1Gi"},
+		{name: "float to quantity", value: 0.5, targetType: "quantity", want: "500m"},
+		{name: "invalid quantity", value: "lots", targetType: "quantity", wantErr: true},
+
+		// JSON conversions
+		{name: "JSON object", value: `{"replicas": 3}`, targetType: "json", want: map[string]interface{}{"replicas": float64(3)}},
+		{name: "JSON scalar", value: `true`, targetType: "json", want: true},
+		{name: "already decoded", value: map[string]interface{}{"a": 1}, targetType: "json", want: map[string]interface{}{"a": 1}},
+		{name: "invalid JSON", value: `{"a":`, targetType: "json", wantErr: true},
+
 		// Unsupported type
 		{name: "unsupported type", value: "test", targetType: "unknown", wantErr: true},
 	}
@@ -200,3 +233,266 @@ func TestConvertToBool(t *testing.T) {
 		})
 	}
 }
+
+func TestExtractExpressionParams(t *testing.T) {
+	t.Setenv("TEST_REGION", "us-east-1")
+
+	eventData := map[string]interface{}{
+		"id":   "ABCDEF123456",
+		"kind": "Cluster",
+	}
+
+	tests := []struct {
+		name        string
+		params      []config_loader.Parameter
+		expectKey   string
+		expectValue interface{}
+		expectError bool
+	}{
+		{
+			name: "expression using source param",
+			params: []config_loader.Parameter{
+				{Name: "clusterId", Source: "event.id"},
+				{Name: "namespace", Expression: `"ns-" + clusterId`},
+			},
+			expectKey:   "namespace",
+			expectValue: "ns-ABCDEF123456",
+		},
+		{
+			name: "expression using event and env",
+			params: []config_loader.Parameter{
+				{Name: "label", Expression: `event.kind + "-" + env.TEST_REGION`},
+			},
+			expectKey:   "label",
+			expectValue: "Cluster-us-east-1",
+		},
+		{
+			name: "expression params evaluated in dependency order",
+			params: []config_loader.Parameter{
+				{Name: "fullName", Expression: `prefix + "-" + event.id`},
+				{Name: "prefix", Expression: `event.kind == "Cluster" ? "cls" : "np"`},
+			},
+			expectKey:   "fullName",
+			expectValue: "cls-ABCDEF123456",
+		},
+		{
+			name: "expression with type conversion",
+			params: []config_loader.Parameter{
+				{Name: "idLength", Expression: `string(size(event.id))`, Type: "int"},
+			},
+			expectKey:   "idLength",
+			expectValue: int64(12),
+		},
+		{
+			name: "default used when optional expression fails",
+			params: []config_loader.Parameter{
+				{Name: "region", Expression: `event.spec.region`, Default: "global"},
+			},
+			expectKey:   "region",
+			expectValue: "global",
+		},
+		{
+			name: "fail when required expression fails",
+			params: []config_loader.Parameter{
+				{Name: "region", Expression: `event.spec.region`, Required: true},
+			},
+			expectError: true,
+		},
+		{
+			name: "default used when referenced env variable is unset",
+			params: []config_loader.Parameter{
+				{Name: "zone", Expression: `env.TEST_UNSET_ZONE`, Default: "zone-a"},
+			},
+			expectKey:   "zone",
+			expectValue: "zone-a",
+		},
+		{
+			name: "fail on circular dependency",
+			params: []config_loader.Parameter{
+				{Name: "a", Expression: `b`},
+				{Name: "b", Expression: `a`},
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			execCtx := NewExecutionContext(context.Background(), eventData, nil)
+			config := &config_loader.Config{
+				Metadata: config_loader.Metadata{Name: "test"},
+				Spec:     config_loader.ConfigSpec{Params: tt.params},
+			}
+
+			err := extractConfigParams(config, execCtx, logger.NewTestLogger())
+			if tt.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectValue, execCtx.Params[tt.expectKey])
+		})
+	}
+}
+
+func TestEnvironmentMap(t *testing.T) {
+	t.Setenv("TEST_REGION", "us-east-1")
+	t.Setenv("TEST_SECRET", "s3cr3t")
+
+	env := environmentMap([]string{"TEST_REGION", "TEST_UNSET_ZONE"})
+
+	assert.Equal(t, map[string]interface{}{"TEST_REGION": "us-east-1"}, env)
+	assert.NotContains(t, env, "TEST_SECRET", "unreferenced variables must not be exposed")
+}
+
+func TestParamSchemaValidation(t *testing.T) {
+	eventData := map[string]interface{}{
+		"name": "my-cluster",
+		"spec": map[string]interface{}{
+			"labels": map[string]interface{}{"team": "core", "tier": "gold"},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		param       config_loader.Parameter
+		expectValue interface{}
+		expectUnset bool
+		expectError bool
+	}{
+		{
+			name: "map matches schema",
+			param: config_loader.Parameter{
+				Name: "labels", Source: "event.spec.labels", Type: "map",
+				Schema: map[string]interface{}{
+					"type":                 "object",
+					"additionalProperties": map[string]interface{}{"type": "string"},
+				},
+			},
+		},
+		{
+			name: "string exceeds maxLength",
+			param: config_loader.Parameter{
+				Name: "clusterName", Source: "event.name", Required: true,
+				Schema: map[string]interface{}{"type": "string", "maxLength": 5},
+			},
+			expectError: true,
+		},
+		{
+			name: "optional param without default is left unset on schema mismatch",
+			param: config_loader.Parameter{
+				Name: "clusterName", Source: "event.name",
+				Schema: map[string]interface{}{"type": "string", "pattern": "^[0-9]+$"},
+			},
+			expectUnset: true,
+		},
+		{
+			name: "optional param with default falls back on schema mismatch",
+			param: config_loader.Parameter{
+				Name: "clusterName", Source: "event.name", Default: "12345",
+				Schema: map[string]interface{}{"type": "string", "pattern": "^[0-9]+$"},
+			},
+			expectValue: "12345",
+		},
+		{
+			name: "required param with default fails schema",
+			param: config_loader.Parameter{
+				Name: "clusterName", Source: "event.name", Default: "12345", Required: true,
+				Schema: map[string]interface{}{"type": "string", "pattern": "^[0-9]+$"},
+			},
+			expectError: true,
+		},
+		{
+			name: "missing optional param skips schema",
+			param: config_loader.Parameter{
+				Name: "region", Source: "event.spec.region",
+				Schema: map[string]interface{}{"type": "string", "minLength": 1},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			execCtx := NewExecutionContext(context.Background(), eventData, nil)
+			config := &config_loader.Config{
+				Spec: config_loader.ConfigSpec{Params: []config_loader.Parameter{tt.param}},
+			}
+
+			err := extractConfigParams(config, execCtx, logger.NewTestLogger())
+			if tt.expectError {
+				require.Error(t, err)
+				var execErr *ExecutorError
+				require.ErrorAs(t, err, &execErr)
+				assert.Equal(t, PhaseParamExtraction, execErr.Phase)
+				assert.Contains(t, err.Error(), "does not match its schema")
+				return
+			}
+			require.NoError(t, err)
+			if tt.expectValue != nil {
+				assert.Equal(t, tt.expectValue, execCtx.Params[tt.param.Name])
+			}
+			if tt.expectUnset {
+				assert.NotContains(t, execCtx.Params, tt.param.Name)
+			}
+		})
+	}
+}
+
+func TestParamDefaultConversion(t *testing.T) {
+	eventData := map[string]interface{}{"timeout": "soon"}
+
+	tests := []struct {
+		name        string
+		param       config_loader.Parameter
+		expectValue interface{}
+		expectError bool
+	}{
+		{
+			name: "default of a missing param is converted",
+			param: config_loader.Parameter{
+				Name: "timeout", Source: "event.spec.timeout", Type: "duration", Default: "5m",
+			},
+			expectValue: 5 * time.Minute,
+		},
+		{
+			name: "default replacing an unconvertible value is converted",
+			param: config_loader.Parameter{
+				Name: "timeout", Source: "event.timeout", Type: "duration", Default: "30s",
+			},
+			expectValue: 30 * time.Second,
+		},
+		{
+			name: "invalid default fails",
+			param: config_loader.Parameter{
+				Name: "timeout", Source: "event.spec.timeout", Type: "duration", Default: "later",
+			},
+			expectError: true,
+		},
+		{
+			name: "default not matching the schema fails",
+			param: config_loader.Parameter{
+				Name: "replicas", Source: "event.spec.replicas", Type: "int", Default: 10,
+				Schema: map[string]interface{}{"type": "integer", "maximum": 5},
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			execCtx := NewExecutionContext(context.Background(), eventData, nil)
+			config := &config_loader.Config{
+				Spec: config_loader.ConfigSpec{Params: []config_loader.Parameter{tt.param}},
+			}
+
+			err := extractConfigParams(config, execCtx, logger.NewTestLogger())
+			if tt.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "invalid default")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectValue, execCtx.Params[tt.param.Name])
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/criteria"
 	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/hyperfleet_api"
 	"github.com/openshift-hyperfleet/hyperfleet-adapter/pkg/logger"
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/pkg/utils"
 )
 
 // PostActionExecutor executes post-processing actions
@@ -101,7 +102,7 @@ func (pae *PostActionExecutor) buildPostPayloads(ctx context.Context, payloads [
 		}
 
 		// Build the payload
-		builtPayload, err := pae.buildPayload(ctx, buildDef, evaluator, execCtx.Params)
+		builtPayload, err := pae.buildPayload(ctx, buildDef, evaluator, execCtx.Params, execCtx.Config.TemplateOptions())
 		if err != nil {
 			return fmt.Errorf("failed to build payload '%s': %w", payload.Name, err)
 		}
@@ -121,31 +122,31 @@ func (pae *PostActionExecutor) buildPostPayloads(ctx context.Context, payloads [
 
 // buildPayload builds a payload from a build definition
 // The build definition can contain expressions that need to be evaluated
-func (pae *PostActionExecutor) buildPayload(ctx context.Context, build any, evaluator *criteria.Evaluator, params map[string]any) (any, error) {
+func (pae *PostActionExecutor) buildPayload(ctx context.Context, build any, evaluator *criteria.Evaluator, params map[string]any, opts utils.TemplateOptions) (any, error) {
 	switch v := build.(type) {
 	case map[string]any:
-		return pae.buildMapPayload(ctx, v, evaluator, params)
+		return pae.buildMapPayload(ctx, v, evaluator, params, opts)
 	case map[any]any:
 		converted := convertToStringKeyMap(v)
-		return pae.buildMapPayload(ctx, converted, evaluator, params)
+		return pae.buildMapPayload(ctx, converted, evaluator, params, opts)
 	default:
 		return build, nil
 	}
 }
 
 // buildMapPayload builds a map payload, evaluating expressions as needed
-func (pae *PostActionExecutor) buildMapPayload(ctx context.Context, m map[string]any, evaluator *criteria.Evaluator, params map[string]any) (map[string]any, error) {
+func (pae *PostActionExecutor) buildMapPayload(ctx context.Context, m map[string]any, evaluator *criteria.Evaluator, params map[string]any, opts utils.TemplateOptions) (map[string]any, error) {
 	result := make(map[string]any)
 
 	for k, v := range m {
 		// Render the key
-		renderedKey, err := renderTemplate(k, params)
+		renderedKey, err := renderTemplate(k, params, opts)
 		if err != nil {
 			return nil, fmt.Errorf("failed to render key '%s': %w", k, err)
 		}
 
 		// Process the value
-		processedValue, err := pae.processValue(ctx, v, evaluator, params)
+		processedValue, err := pae.processValue(ctx, v, evaluator, params, opts)
 		if err != nil {
 			return nil, fmt.Errorf("failed to process value for key '%s': %w", k, err)
 		}
@@ -157,7 +158,7 @@ func (pae *PostActionExecutor) buildMapPayload(ctx context.Context, m map[string
 }
 
 // processValue processes a value, evaluating expressions as needed
-func (pae *PostActionExecutor) processValue(ctx context.Context, v any, evaluator *criteria.Evaluator, params map[string]any) (any, error) {
+func (pae *PostActionExecutor) processValue(ctx context.Context, v any, evaluator *criteria.Evaluator, params map[string]any, opts utils.TemplateOptions) (any, error) {
 	switch val := v.(type) {
 	case map[string]any:
 		// Check if this is a value definition: { field: "...", default: ... } or { expression: "...", default: ... }
@@ -178,16 +179,16 @@ func (pae *PostActionExecutor) processValue(ctx context.Context, v any, evaluato
 		}
 
 		// Recursively process nested maps
-		return pae.buildMapPayload(ctx, val, evaluator, params)
+		return pae.buildMapPayload(ctx, val, evaluator, params, opts)
 
 	case map[any]any:
 		converted := convertToStringKeyMap(val)
-		return pae.processValue(ctx, converted, evaluator, params)
+		return pae.processValue(ctx, converted, evaluator, params, opts)
 
 	case []any:
 		result := make([]any, len(val))
 		for i, item := range val {
-			processed, err := pae.processValue(ctx, item, evaluator, params)
+			processed, err := pae.processValue(ctx, item, evaluator, params, opts)
 			if err != nil {
 				return nil, err
 			}
@@ -196,7 +197,7 @@ func (pae *PostActionExecutor) processValue(ctx context.Context, v any, evaluato
 		return result, nil
 
 	case string:
-		return renderTemplate(val, params)
+		return renderTemplate(val, params, opts)
 
 	default:
 		return v, nil
@@ -10,6 +10,7 @@ import (
 	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/criteria"
 	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/hyperfleet_api"
 	"github.com/openshift-hyperfleet/hyperfleet-adapter/pkg/logger"
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/pkg/utils"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -98,7 +99,7 @@ func TestBuildPayload(t *testing.T) {
 			evaluator, err := criteria.NewEvaluator(context.Background(), evalCtx, pae.log)
 			assert.NoError(t, err)
 
-			result, err := pae.buildPayload(context.Background(), tt.build, evaluator, tt.params)
+			result, err := pae.buildPayload(context.Background(), tt.build, evaluator, tt.params, utils.DefaultTemplateOptions)
 
 			if tt.expectError {
 				assert.Error(t, err)
@@ -177,7 +178,7 @@ func TestBuildMapPayload(t *testing.T) {
 			}
 			evaluator, err := criteria.NewEvaluator(context.Background(), evalCtx, pae.log)
 			require.NoError(t, err)
-			result, err := pae.buildMapPayload(context.Background(), tt.input, evaluator, tt.params)
+			result, err := pae.buildMapPayload(context.Background(), tt.input, evaluator, tt.params, utils.DefaultTemplateOptions)
 
 			if tt.expectError {
 				assert.Error(t, err)
@@ -287,7 +288,7 @@ func TestProcessValue(t *testing.T) {
 			}
 			evaluator, err := criteria.NewEvaluator(context.Background(), evalCtx, pae.log)
 			require.NoError(t, err)
-			result, err := pae.processValue(context.Background(), tt.value, evaluator, tt.params)
+			result, err := pae.processValue(context.Background(), tt.value, evaluator, tt.params, utils.DefaultTemplateOptions)
 
 			if tt.expectError {
 				assert.Error(t, err)
@@ -4,17 +4,27 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"slices"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/config_loader"
 	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/criteria"
 	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/hyperfleet_api"
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/transport_client"
 	"github.com/openshift-hyperfleet/hyperfleet-adapter/pkg/logger"
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/pkg/utils"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 // PreconditionExecutor evaluates preconditions
 type PreconditionExecutor struct {
 	apiClient hyperfleet_api.Client
+	client    transport_client.TransportClient
+	clients   map[string]transport_client.TransportClient
 	log       logger.Logger
 }
 
@@ -23,42 +33,55 @@ type PreconditionExecutor struct {
 func newPreconditionExecutor(config *ExecutorConfig) *PreconditionExecutor {
 	return &PreconditionExecutor{
 		apiClient: config.APIClient,
+		client:    config.TransportClient,
+		clients:   config.TransportClients,
 		log:       config.Logger,
 	}
 }
 
-// ExecuteAll executes all preconditions in sequence
+// ExecuteAll executes all preconditions in sequence. Consecutive preconditions sharing a
+// parallel group form a single stage whose members are executed concurrently.
 // Returns a high-level outcome with match status and individual results
 func (pe *PreconditionExecutor) ExecuteAll(ctx context.Context, preconditions []config_loader.Precondition, execCtx *ExecutionContext) *PreconditionsOutcome {
 	results := make([]PreconditionResult, 0, len(preconditions))
 
-	for _, precond := range preconditions {
-		result, err := pe.executePrecondition(ctx, precond, execCtx)
-		results = append(results, result)
-
-		if err != nil {
-			// Execution error (API call failed, parse error, etc.)
-			errCtx := logger.WithErrorField(ctx, err)
-			pe.log.Errorf(errCtx, "Precondition[%s] evaluated: FAILED", precond.Name)
-			return &PreconditionsOutcome{
-				AllMatched: false,
-				Results:    results,
-				Error:      err,
+	for _, stage := range preconditionStages(preconditions) {
+		stageResults, stageErrs := pe.executeStage(ctx, stage, execCtx)
+		results = append(results, stageResults...)
+
+		// Outcomes are reported in config order, so the first failing member decides
+		for i, precond := range stage {
+			result, err := stageResults[i], stageErrs[i]
+			if err != nil {
+				// Execution error (API call failed, parse error, etc.)
+				errCtx := logger.WithErrorField(ctx, err)
+				pe.log.Errorf(errCtx, "Precondition[%s] evaluated: FAILED", precond.Name)
+				return &PreconditionsOutcome{
+					AllMatched: false,
+					Results:    results,
+					Error:      err,
+				}
 			}
-		}
 
-		if !result.Matched {
-			// Business outcome: precondition not satisfied
-			pe.log.Infof(ctx, "Precondition[%s] evaluated: NOT_MET - %s", precond.Name, formatConditionDetails(result))
-			return &PreconditionsOutcome{
-				AllMatched:   false,
-				Results:      results,
-				Error:        nil,
-				NotMetReason: fmt.Sprintf("precondition '%s' not met: %s", precond.Name, formatConditionDetails(result)),
+			if !result.Matched {
+				// Business outcome: precondition not satisfied
+				pe.log.Infof(ctx, "Precondition[%s] evaluated: NOT_MET - %s", precond.Name, formatConditionDetails(result))
+				execCtx.Adapter.EvaluationTrace = result.Trace
+				notMetReason := fmt.Sprintf("precondition '%s' not met: %s", precond.Name, formatConditionDetails(result))
+				if len(result.Attempts) > 1 {
+					notMetReason = fmt.Sprintf("precondition '%s' not met after %d attempts: %s",
+						precond.Name, len(result.Attempts), formatConditionDetails(result))
+				}
+				return &PreconditionsOutcome{
+					AllMatched:   false,
+					Results:      results,
+					Error:        nil,
+					NotMetReason: notMetReason,
+				}
 			}
-		}
 
-		pe.log.Infof(ctx, "Precondition[%s] evaluated: MET", precond.Name)
+			pe.log.Infof(ctx, "Precondition[%s] evaluated: MET", precond.Name)
+		}
 	}
 
 	// All preconditions matched
@@ -69,6 +92,98 @@ func (pe *PreconditionExecutor) ExecuteAll(ctx context.Context, preconditions []
 	}
 }
 
+// preconditionStages splits preconditions into stages: each consecutive run of preconditions
+// sharing a parallel group is one stage, every other precondition is a stage of its own
+func preconditionStages(preconditions []config_loader.Precondition) [][]config_loader.Precondition {
+	var stages [][]config_loader.Precondition
+	for start := 0; start < len(preconditions); {
+		end := start + 1
+		group := preconditions[start].ParallelGroup
+		for group != "" && end < len(preconditions) && preconditions[end].ParallelGroup == group {
+			end++
+		}
+		stages = append(stages, preconditions[start:end])
+		start = end
+	}
+	return stages
+}
+
+// executeStage executes the preconditions of a stage and returns their results in config order.
+// The members of a parallel group run concurrently, each on a fork of execCtx; once all of them
+// are done, their responses, captures and evaluations are merged into execCtx in config order.
+// A failing member does not cancel its siblings.
+func (pe *PreconditionExecutor) executeStage(ctx context.Context, stage []config_loader.Precondition, execCtx *ExecutionContext) ([]PreconditionResult, []error) {
+	results := make([]PreconditionResult, len(stage))
+	errs := make([]error, len(stage))
+	if len(stage) == 1 {
+		results[0], errs[0] = pe.executeWithRetry(ctx, stage[0], execCtx)
+		return results, errs
+	}
+
+	pe.log.Debugf(ctx, "Executing %d preconditions of parallel group %s concurrently", len(stage), stage[0].ParallelGroup)
+	forks := make([]*ExecutionContext, len(stage))
+	var wg sync.WaitGroup
+	for i, precond := range stage {
+		forks[i] = execCtx.fork()
+		wg.Go(func() {
+			results[i], errs[i] = pe.executeWithRetry(ctx, precond, forks[i])
+		})
+	}
+	wg.Wait()
+
+	for i, precond := range stage {
+		execCtx.mergePrecondition(forks[i], precond)
+	}
+	return results, errs
+}
+
+// executeWithRetry executes a precondition, executing it again after the retry delay while it
+// is not met, attempts remain and the next attempt would start within the retry time budget.
+// Errors end the retries; so does the cancellation of ctx, which fails the precondition.
+func (pe *PreconditionExecutor) executeWithRetry(ctx context.Context, precond config_loader.Precondition, execCtx *ExecutionContext) (PreconditionResult, error) {
+	maxAttempts := precond.MaxAttempts()
+	var attempts []PreconditionAttempt
+	retryStart := time.Now()
+
+	for attempt := 1; ; attempt++ {
+		startedAt := time.Now()
+		result, err := pe.executePrecondition(ctx, precond, execCtx)
+		attempts = append(attempts, PreconditionAttempt{
+			Attempt:          attempt,
+			StartedAt:        startedAt,
+			Matched:          result.Matched,
+			CapturedFields:   result.CapturedFields,
+			ConditionResults: result.ConditionResults,
+			CELResult:        result.CELResult,
+			Error:            result.Error,
+		})
+		result.Attempts = attempts
+
+		if err != nil || result.Matched || attempt >= maxAttempts {
+			return result, err
+		}
+
+		delay := precond.Retry.Delay(attempt)
+		if maxElapsed := precond.Retry.MaxElapsedTime(); time.Since(retryStart)+delay > maxElapsed {
+			pe.log.Infof(ctx, "Precondition[%s] not met (attempt %d/%d): %s - retry time budget %v exhausted",
+				precond.Name, attempt, maxAttempts, formatConditionDetails(result), maxElapsed)
+			return result, nil
+		}
+		pe.log.Infof(ctx, "Precondition[%s] not met (attempt %d/%d): %s - retrying in %v",
+			precond.Name, attempt, maxAttempts, formatConditionDetails(result), delay)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			result.Status = StatusFailed
+			result.Error = fmt.Errorf("retry canceled after attempt %d/%d: %w", attempt, maxAttempts, ctx.Err())
+			return result, NewExecutorError(PhasePreconditions, precond.Name, "precondition retry canceled", result.Error)
+		case <-timer.C:
+		}
+	}
+}
+
 // executePrecondition executes a single precondition
 func (pe *PreconditionExecutor) executePrecondition(ctx context.Context, precond config_loader.Precondition, execCtx *ExecutionContext) (PreconditionResult, error) {
 	result := PreconditionResult{
@@ -82,7 +197,9 @@ func (pe *PreconditionExecutor) executePrecondition(ctx context.Context, precond
 		ExecuteLogAction(ctx, precond.Log, execCtx, pe.log)
 	}
 
-	// Step 2: Make API call if configured
+	// Step 2: Make API call or resource lookup if configured
+	var responseData map[string]interface{}
+	fetched := false
 	if precond.APICall != nil {
 		apiResult, err := pe.executeAPICall(ctx, precond.APICall, execCtx)
 		if err != nil {
@@ -102,7 +219,6 @@ func (pe *PreconditionExecutor) executePrecondition(ctx context.Context, precond
 		result.APIResponse = apiResult
 
 		// Parse response as JSON
-		var responseData map[string]interface{}
 		if err := json.Unmarshal(apiResult, &responseData); err != nil {
 			result.Status = StatusFailed
 			result.Error = fmt.Errorf("failed to parse API response as JSON: %w", err)
@@ -116,39 +232,44 @@ func (pe *PreconditionExecutor) executePrecondition(ctx context.Context, precond
 
 			return result, NewExecutorError(PhasePreconditions, precond.Name, "failed to parse API response", err)
 		}
+		fetched = true
+	} else if precond.ResourceLookup != nil {
+		obj, err := pe.lookupResource(ctx, precond, execCtx)
+		if err != nil {
+			result.Status = StatusFailed
+			result.Error = err
+
+			// Set ExecutionError for lookup failure
+			execCtx.Adapter.ExecutionError = &ExecutionError{
+				Phase:   string(PhasePreconditions),
+				Step:    precond.Name,
+				Message: err.Error(),
+			}
 
+			return result, NewExecutorError(PhasePreconditions, precond.Name, "resource lookup failed", err)
+		}
+		if obj == nil {
+			// A missing object is a valid outcome: conditions decide whether it is required
+			pe.log.Debugf(ctx, "Resource lookup found no %s object", precond.ResourceLookup.Kind)
+			execCtx.Params[precond.Name] = nil
+		} else {
+			result.Resource = obj
+			responseData = obj.Object
+			fetched = true
+		}
+	}
+
+	if fetched {
 		// Store full response under precondition name for condition digging
 		// e.g., conditions can access "check-cluster.status.conditions"
 		execCtx.Params[precond.Name] = responseData
+	}
 
-		// Capture fields from response
-		if len(precond.Capture) > 0 {
-			pe.log.Debugf(ctx, "Capturing %d fields from API response", len(precond.Capture))
-
-			// Create evaluator with response data only
-			// Both field (JSONPath) and expression (CEL) work on the same source
-			captureCtx := criteria.NewEvaluationContext()
-			captureCtx.SetVariablesFromMap(responseData)
-
-			captureEvaluator, evalErr := criteria.NewEvaluator(ctx, captureCtx, pe.log)
-			if evalErr != nil {
-				pe.log.Warnf(ctx, "Failed to create capture evaluator: %v", evalErr)
-			} else {
-				for _, capture := range precond.Capture {
-					extractResult, err := captureEvaluator.ExtractValue(capture.Field, capture.Expression)
-					if err != nil {
-						return result, err
-					}
-					// Error is not nil when there is field missing that is not a bug, but a valid use case
-					if extractResult.Error != nil {
-						pe.log.Warnf(ctx, "Failed to capture '%s' with error: %v", capture.Name, extractResult.Error)
-						continue
-					}
-					result.CapturedFields[capture.Name] = extractResult.Value
-					execCtx.Params[capture.Name] = extractResult.Value
-					pe.log.Debugf(ctx, "Captured %s = %v (from %s)", capture.Name, extractResult.Value, extractResult.Source)
-				}
-			}
+	// Capture fields from the response or the looked up object. A missing object is captured
+	// as an empty result: required captures fail, optional ones fall back to null, [] or 0.
+	if len(precond.Capture) > 0 && (fetched || precond.ResourceLookup != nil) {
+		if err := pe.captureFields(ctx, precond, responseData, &result, execCtx); err != nil {
+			return result, err
 		}
 	}
 
@@ -164,11 +285,21 @@ func (pe *PreconditionExecutor) executePrecondition(ctx context.Context, precond
 		result.Error = err
 		return result, NewExecutorError(PhasePreconditions, precond.Name, "failed to create evaluator", err)
 	}
+	debug := pe.log.DebugEnabled(ctx)
 
 	// Evaluate using structured conditions or CEL expression
 	if len(precond.Conditions) > 0 {
-		pe.log.Debugf(ctx, "Evaluating %d structured conditions", len(precond.Conditions))
 		condDefs := ToConditionDefs(precond.Conditions)
+		// The CEL equivalent is only built for debug logging
+		celExpr := ""
+		if debug {
+			celExpr, _ = criteria.ConditionsToCEL(condDefs)
+		}
+		if celExpr != "" {
+			pe.log.Debugf(ctx, "Evaluating %d structured conditions: %s", len(precond.Conditions), celExpr)
+		} else {
+			pe.log.Debugf(ctx, "Evaluating %d structured conditions", len(precond.Conditions))
+		}
 
 		condResult, err := evaluator.EvaluateConditions(condDefs)
 		if err != nil {
@@ -183,22 +314,26 @@ func (pe *PreconditionExecutor) executePrecondition(ctx context.Context, precond
 		// Log individual condition results
 		for _, cr := range condResult.Results {
 			if cr.Matched {
-				pe.log.Debugf(ctx, "Condition: %s %s %v = %v (matched)", cr.Field, cr.Operator, cr.ExpectedValue, cr.FieldValue)
+				pe.log.Debugf(ctx, "Condition: %s = %v (matched)", describeCondition(cr), cr.FieldValue)
 			} else {
-				pe.log.Debugf(ctx, "Condition: %s %s %v = %v (not matched)", cr.Field, cr.Operator, cr.ExpectedValue, cr.FieldValue)
+				pe.log.Debugf(ctx, "Condition: %s = %v (not matched)", describeCondition(cr), cr.FieldValue)
 			}
 		}
 
 		// Record evaluation in execution context - reuse criteria.EvaluationResult directly
 		fieldResults := make(map[string]criteria.EvaluationResult, len(condResult.Results))
 		for _, cr := range condResult.Results {
-			fieldResults[cr.Field] = cr
+			fieldResults[conditionResultKey(cr)] = cr
 		}
 		execCtx.AddConditionsEvaluation(PhasePreconditions, precond.Name, condResult.Matched, fieldResults)
 	} else if precond.Expression != "" {
-		// Evaluate CEL expression
+		// Evaluate CEL expression, traced when the trace is logged
 		pe.log.Debugf(ctx, "Evaluating CEL expression: %s", strings.TrimSpace(precond.Expression))
-		celResult, err := evaluator.EvaluateCEL(strings.TrimSpace(precond.Expression))
+		evaluateCEL := evaluator.EvaluateCEL
+		if debug {
+			evaluateCEL = evaluator.EvaluateCELTraced
+		}
+		celResult, err := evaluateCEL(strings.TrimSpace(precond.Expression))
 		if err != nil {
 			result.Status = StatusFailed
 			result.Error = err
@@ -217,9 +352,73 @@ func (pe *PreconditionExecutor) executePrecondition(ctx context.Context, precond
 		result.Matched = true
 	}
 
+	// The trace is only built when it is logged or explains a precondition not met
+	if !debug && result.Matched {
+		return result, nil
+	}
+	result.Trace = evaluationTrace(&result, evaluator)
+	execCtx.SetEvaluationTrace(PhasePreconditions, precond.Name, result.Trace)
+	if debug {
+		if traceJSON, err := json.Marshal(result.Trace); err == nil {
+			pe.log.Debugf(ctx, "Precondition[%s] evaluation trace: %s", precond.Name, traceJSON)
+		}
+	}
+
 	return result, nil
 }
 
+// evaluationTrace builds the trace of an evaluated precondition. A CEL expression evaluated
+// without tracing is evaluated again with tracing.
+func evaluationTrace(result *PreconditionResult, evaluator *criteria.Evaluator) *criteria.EvaluationTrace {
+	trace := &criteria.EvaluationTrace{
+		Precondition: result.Name,
+		Matched:      result.Matched,
+		Captured:     result.CapturedFields,
+	}
+	if len(result.ConditionResults) > 0 {
+		trace.Conditions = conditionTraces(result.ConditionResults)
+	}
+
+	celResult := result.CELResult
+	if celResult == nil {
+		return trace
+	}
+	if celResult.Trace == nil {
+		if traced, err := evaluator.EvaluateCELTraced(celResult.Expression); err == nil {
+			celResult = traced
+		}
+	}
+	trace.Expression = celResult.Expression
+	trace.SubExpressions = celResult.Trace
+	if celResult.HasError() {
+		trace.Error = celResult.Error.Error()
+	}
+	return trace
+}
+
+// conditionTraces converts condition results into evaluation trace entries
+func conditionTraces(results []criteria.EvaluationResult) []criteria.ConditionTrace {
+	traces := make([]criteria.ConditionTrace, len(results))
+	for i, cr := range results {
+		var errMsg string
+		if cr.Error != nil {
+			errMsg = cr.Error.Error()
+		}
+		traces[i] = criteria.ConditionTrace{
+			Path:          config_loader.FieldConditions + cr.Path,
+			Field:         cr.Field,
+			Operator:      string(cr.Operator),
+			Expected:      cr.ExpectedValue,
+			Actual:        cr.FieldValue,
+			Matched:       cr.Matched,
+			Negated:       cr.Negated,
+			CausedFailure: cr.CausedFailure,
+			Error:         errMsg,
+		}
+	}
+	return traces
+}
+
 // executeAPICall executes an API call and returns the response body for field capture
 func (pe *PreconditionExecutor) executeAPICall(ctx context.Context, apiCall *config_loader.APICall, execCtx *ExecutionContext) ([]byte, error) {
 	resp, url, err := ExecuteAPICall(ctx, apiCall, execCtx, pe.apiClient, pe.log)
@@ -232,6 +431,131 @@ func (pe *PreconditionExecutor) executeAPICall(ctx context.Context, apiCall *con
 	return resp.Body, nil
 }
 
+// captureFields captures the fields of a precondition from the fetched data into the result
+// and the params. Both field (JSONPath) and expression (CEL) captures work on the same data.
+func (pe *PreconditionExecutor) captureFields(ctx context.Context, precond config_loader.Precondition, data map[string]interface{}, result *PreconditionResult, execCtx *ExecutionContext) error {
+	pe.log.Debugf(ctx, "Capturing %d fields from response", len(precond.Capture))
+
+	captureCtx := criteria.NewEvaluationContext()
+	captureCtx.SetVariablesFromMap(data)
+
+	captureEvaluator, err := criteria.NewEvaluator(ctx, captureCtx, pe.log)
+	if err != nil {
+		if !slices.ContainsFunc(precond.Capture, func(c config_loader.CaptureField) bool { return c.Required }) {
+			pe.log.Warnf(ctx, "Failed to create capture evaluator: %v", err)
+			return nil
+		}
+		return failCapture(result, execCtx, precond.Name, fmt.Errorf("failed to create capture evaluator: %w", err))
+	}
+
+	for _, capture := range precond.Capture {
+		extractResult, err := captureEvaluator.ExtractValue(capture.Field, capture.Expression)
+		if err != nil {
+			return err
+		}
+		value, captureErr := resolveCapture(extractResult, capture)
+		if captureErr != nil {
+			if capture.Required {
+				return failCapture(result, execCtx, precond.Name, fmt.Errorf("required capture '%s' failed: %w", capture.Name, captureErr))
+			}
+			// A missing field is not a bug, but a valid use case for an optional capture
+			if capture.Default == nil {
+				pe.log.Warnf(ctx, "Failed to capture '%s', left unset: %v", capture.Name, captureErr)
+				continue
+			}
+			value, err = captureDefault(capture)
+			if err != nil {
+				return failCapture(result, execCtx, precond.Name, err)
+			}
+			pe.log.Warnf(ctx, "Failed to capture '%s', set to default %v: %v", capture.Name, value, captureErr)
+		}
+		result.CapturedFields[capture.Name] = value
+		execCtx.Params[capture.Name] = value
+		pe.log.Debugf(ctx, "Captured %s = %v (from %s)", capture.Name, value, extractResult.Source)
+	}
+	return nil
+}
+
+// failCapture marks a precondition failed because a required capture could not be resolved
+func failCapture(result *PreconditionResult, execCtx *ExecutionContext, name string, err error) error {
+	result.Status = StatusFailed
+	result.Error = err
+
+	// Set ExecutionError for required capture failure
+	execCtx.Adapter.ExecutionError = &ExecutionError{
+		Phase:   string(PhasePreconditions),
+		Step:    name,
+		Message: err.Error(),
+	}
+
+	return NewExecutorError(PhasePreconditions, name, "required capture failed", err)
+}
+
+// resolveCapture selects the value of a capture by its mode and converts it to its type.
+// Returns an error when nothing was extracted or the conversion failed; the count mode always
+// extracts a value.
+func resolveCapture(extracted *criteria.ExtractValueResult, capture config_loader.CaptureField) (interface{}, error) {
+	mode := criteria.ExtractMode(capture.Mode)
+	value := extracted.Select(mode)
+	if mode != criteria.ExtractModeCount && len(extracted.Values) == 0 {
+		if extracted.Error != nil {
+			return nil, extracted.Error
+		}
+		return nil, fmt.Errorf("no value extracted from %s", extracted.Source)
+	}
+
+	if capture.Type == "" {
+		return value, nil
+	}
+	converted, err := utils.ConvertToType(value, capture.Type)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert %v to %s: %w", value, capture.Type, err)
+	}
+	return converted, nil
+}
+
+// captureDefault returns the default of an optional capture converted to its type
+func captureDefault(capture config_loader.CaptureField) (interface{}, error) {
+	if capture.Type == "" {
+		return capture.Default, nil
+	}
+	converted, err := utils.ConvertToType(capture.Default, capture.Type)
+	if err != nil {
+		return nil, fmt.Errorf("invalid default for capture '%s': failed to convert %v to %s: %w",
+			capture.Name, capture.Default, capture.Type, err)
+	}
+	return converted, nil
+}
+
+// lookupResource reads the object of a precondition's resource lookup through the transport
+// client of its transport. Returns nil without error when the object does not exist.
+func (pe *PreconditionExecutor) lookupResource(ctx context.Context, precond config_loader.Precondition, execCtx *ExecutionContext) (*unstructured.Unstructured, error) {
+	lookup := precond.ResourceLookup
+	resource := precond.LookupResource()
+
+	client := transportClientFor(*resource, pe.clients, pe.client)
+	if client == nil {
+		return nil, fmt.Errorf("no transport client configured for %s transport", resource.GetTransportClient())
+	}
+
+	gv, err := schema.ParseGroupVersion(lookup.APIVersion)
+	if err != nil {
+		return nil, fmt.Errorf("invalid apiVersion %q: %w", lookup.APIVersion, err)
+	}
+
+	transportTarget, err := buildTransportTarget(*resource, execCtx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build transport target: %w", err)
+	}
+
+	pe.log.Debugf(ctx, "Looking up %s/%s via %s transport", lookup.APIVersion, lookup.Kind, resource.GetTransportClient())
+	obj, err := discoverObject(ctx, client, gv.WithKind(lookup.Kind), lookup.Discovery, execCtx, transportTarget)
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	return obj, err
+}
+
 // formatConditionDetails formats condition evaluation details for error messages
 func formatConditionDetails(result PreconditionResult) string {
 	var details []string
@@ -241,9 +565,11 @@ func formatConditionDetails(result PreconditionResult) string {
 	}
 
 	for _, condResult := range result.ConditionResults {
-		if !condResult.Matched {
-			details = append(details, fmt.Sprintf("%s %s %v (actual: %v)",
-				condResult.Field, condResult.Operator, condResult.ExpectedValue, condResult.FieldValue))
+		switch {
+		case condResult.CausedFailure && condResult.Error != nil:
+			details = append(details, fmt.Sprintf("%s (error: %v)", describeCondition(condResult), condResult.Error))
+		case condResult.CausedFailure:
+			details = append(details, fmt.Sprintf("%s (actual: %v)", describeCondition(condResult), condResult.FieldValue))
 		}
 	}
 
@@ -253,3 +579,32 @@ func formatConditionDetails(result PreconditionResult) string {
 
 	return strings.Join(details, "; ")
 }
+
+// isNestedCondition reports whether a condition result comes from an anyOf, allOf or not group
+func isNestedCondition(condResult criteria.EvaluationResult) bool {
+	return strings.Contains(condResult.Path, ".")
+}
+
+// describeCondition formats a condition result as "field operator value", prefixed with its
+// path when nested in a group and wrapped in not(...) when negated,
+// e.g. "conditions[1].anyOf[0].not: not(region startsWith us-gov-)"
+func describeCondition(condResult criteria.EvaluationResult) string {
+	desc := fmt.Sprintf("%s %s %v", condResult.Field, condResult.Operator, condResult.ExpectedValue)
+	if condResult.Negated {
+		desc = "not(" + desc + ")"
+	}
+	if isNestedCondition(condResult) {
+		desc = config_loader.FieldConditions + condResult.Path + ": " + desc
+	}
+	return desc
+}
+
+// conditionResultKey returns the key of a condition result in the evaluation record:
+// the field for top-level conditions, the condition path for conditions nested in groups
+// (e.g. "conditions[1].anyOf[0]"), so each branch of a group is recorded
+func conditionResultKey(condResult criteria.EvaluationResult) string {
+	if isNestedCondition(condResult) {
+		return config_loader.FieldConditions + condResult.Path
+	}
+	return condResult.Field
+}
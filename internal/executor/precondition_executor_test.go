@@ -0,0 +1,502 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/config_loader"
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/hyperfleet_api"
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/k8s_client"
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/transport_client"
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// sequenceAPIClient returns the GET response bodies in sequence, repeating the last one
+type sequenceAPIClient struct {
+	*hyperfleet_api.MockClient
+	mu     sync.Mutex
+	bodies []string
+	calls  int
+}
+
+func (c *sequenceAPIClient) Get(ctx context.Context, url string, opts ...hyperfleet_api.RequestOption) (*hyperfleet_api.Response, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	body := c.bodies[min(c.calls, len(c.bodies)-1)]
+	c.calls++
+	return &hyperfleet_api.Response{StatusCode: 200, Status: "200 OK", Body: []byte(body)}, nil
+}
+
+func TestPreconditionRetry(t *testing.T) {
+	newPrecondition := func(retry *config_loader.PreconditionRetry) config_loader.Precondition {
+		return config_loader.Precondition{
+			ActionBase: config_loader.ActionBase{
+				Name:    "waitForLandingZone",
+				APICall: &config_loader.APICall{Method: "GET", URL: "http://api.example.com/status"},
+			},
+			Capture: []config_loader.CaptureField{
+				{Name: "lzPhase", FieldExpressionDef: config_loader.FieldExpressionDef{Field: "phase"}},
+			},
+			Conditions: []config_loader.Condition{
+				{Field: "lzPhase", Operator: "equals", Value: "Ready"},
+			},
+			Retry: retry,
+		}
+	}
+	newExecutor := func(bodies ...string) (*PreconditionExecutor, *sequenceAPIClient) {
+		client := &sequenceAPIClient{MockClient: hyperfleet_api.NewMockClient(), bodies: bodies}
+		return newPreconditionExecutor(&ExecutorConfig{APIClient: client, Logger: logger.NewTestLogger()}), client
+	}
+	newExecCtx := func(ctx context.Context) *ExecutionContext {
+		return NewExecutionContext(ctx, map[string]interface{}{}, &config_loader.Config{})
+	}
+
+	t.Run("retries until met", func(t *testing.T) {
+		pe, client := newExecutor(`{"phase":"Provisioning"}`, `{"phase":"Provisioning"}`, `{"phase":"Ready"}`)
+		precond := newPrecondition(&config_loader.PreconditionRetry{Attempts: 5, Interval: "1ms", Backoff: "exponential"})
+
+		ctx := context.Background()
+		outcome := pe.ExecuteAll(ctx, []config_loader.Precondition{precond}, newExecCtx(ctx))
+
+		require.NoError(t, outcome.Error)
+		assert.True(t, outcome.AllMatched)
+		assert.Equal(t, 3, client.calls)
+		require.Len(t, outcome.Results, 1)
+		attempts := outcome.Results[0].Attempts
+		require.Len(t, attempts, 3)
+		for i, attempt := range attempts {
+			assert.Equal(t, i+1, attempt.Attempt)
+			assert.Equal(t, i == 2, attempt.Matched)
+		}
+		assert.Equal(t, "Provisioning", attempts[0].CapturedFields["lzPhase"])
+		assert.Equal(t, "Ready", attempts[2].CapturedFields["lzPhase"])
+	})
+
+	t.Run("not met when attempts run out", func(t *testing.T) {
+		pe, client := newExecutor(`{"phase":"Provisioning"}`)
+		precond := newPrecondition(&config_loader.PreconditionRetry{Attempts: 2, Interval: "1ms"})
+
+		ctx := context.Background()
+		outcome := pe.ExecuteAll(ctx, []config_loader.Precondition{precond}, newExecCtx(ctx))
+
+		require.NoError(t, outcome.Error)
+		assert.False(t, outcome.AllMatched)
+		assert.Equal(t, 2, client.calls)
+		assert.Len(t, outcome.Results[0].Attempts, 2)
+		assert.Contains(t, outcome.NotMetReason, "not met after 2 attempts")
+	})
+
+	t.Run("not met when the time budget runs out", func(t *testing.T) {
+		pe, client := newExecutor(`{"phase":"Provisioning"}`)
+		precond := newPrecondition(&config_loader.PreconditionRetry{Attempts: 100, Interval: "20ms", MaxElapsed: "50ms"})
+
+		ctx := context.Background()
+		outcome := pe.ExecuteAll(ctx, []config_loader.Precondition{precond}, newExecCtx(ctx))
+
+		require.NoError(t, outcome.Error)
+		assert.False(t, outcome.AllMatched)
+		// The third attempt starts at 40ms at the earliest; a fourth would start after 50ms
+		assert.LessOrEqual(t, client.calls, 3)
+		assert.Len(t, outcome.Results[0].Attempts, client.calls)
+	})
+
+	t.Run("single attempt without retry", func(t *testing.T) {
+		pe, client := newExecutor(`{"phase":"Provisioning"}`)
+
+		ctx := context.Background()
+		outcome := pe.ExecuteAll(ctx, []config_loader.Precondition{newPrecondition(nil)}, newExecCtx(ctx))
+
+		assert.False(t, outcome.AllMatched)
+		assert.Equal(t, 1, client.calls)
+		assert.Len(t, outcome.Results[0].Attempts, 1)
+		assert.NotContains(t, outcome.NotMetReason, "attempts")
+	})
+
+	t.Run("canceled while waiting", func(t *testing.T) {
+		pe, client := newExecutor(`{"phase":"Provisioning"}`)
+		precond := newPrecondition(&config_loader.PreconditionRetry{Attempts: 3, Interval: "1h", MaxElapsed: "3h"})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		start := time.Now()
+		outcome := pe.ExecuteAll(ctx, []config_loader.Precondition{precond}, newExecCtx(ctx))
+
+		assert.Less(t, time.Since(start), time.Minute)
+		require.Error(t, outcome.Error)
+		assert.Contains(t, outcome.Error.Error(), "retry canceled after attempt 1/3")
+		assert.ErrorIs(t, outcome.Error, context.DeadlineExceeded)
+		assert.Equal(t, 1, client.calls)
+		assert.Equal(t, StatusFailed, outcome.Results[0].Status)
+	})
+}
+
+// barrierAPIClient answers GET requests by URL once the expected number of requests are in flight
+type barrierAPIClient struct {
+	*hyperfleet_api.MockClient
+	bodies  map[string]string
+	arrived sync.WaitGroup
+}
+
+func (c *barrierAPIClient) Get(ctx context.Context, url string, opts ...hyperfleet_api.RequestOption) (*hyperfleet_api.Response, error) {
+	c.arrived.Done()
+	done := make(chan struct{})
+	go func() {
+		c.arrived.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		return nil, context.DeadlineExceeded
+	}
+	body, ok := c.bodies[url]
+	if !ok {
+		return &hyperfleet_api.Response{StatusCode: 404, Status: "404 Not Found"}, nil
+	}
+	return &hyperfleet_api.Response{StatusCode: 200, Status: "200 OK", Body: []byte(body)}, nil
+}
+
+func TestPreconditionParallelGroup(t *testing.T) {
+	member := func(name, url, capture string) config_loader.Precondition {
+		return config_loader.Precondition{
+			ActionBase: config_loader.ActionBase{
+				Name:    name,
+				APICall: &config_loader.APICall{Method: "GET", URL: url},
+			},
+			Capture: []config_loader.CaptureField{
+				{Name: capture, FieldExpressionDef: config_loader.FieldExpressionDef{Field: "phase"}},
+			},
+			Conditions: []config_loader.Condition{
+				{Field: capture, Operator: "exists"},
+			},
+			ParallelGroup: "fetch",
+		}
+	}
+	preconditions := []config_loader.Precondition{
+		member("cluster", "http://api.example.com/cluster", "clusterPhase"),
+		member("nodePool", "http://api.example.com/nodepool", "nodePoolPhase"),
+		member("network", "http://api.example.com/network", "networkPhase"),
+		{
+			ActionBase: config_loader.ActionBase{Name: "allReady"},
+			Expression: "clusterPhase == 'Ready' && nodePoolPhase == 'Ready' && network.phase == 'Ready'",
+		},
+	}
+	newExecutor := func(bodies map[string]string) *PreconditionExecutor {
+		client := &barrierAPIClient{MockClient: hyperfleet_api.NewMockClient(), bodies: bodies}
+		// Every member has to be waiting on its request before any of them gets a response
+		client.arrived.Add(3)
+		return newPreconditionExecutor(&ExecutorConfig{APIClient: client, Logger: logger.NewTestLogger()})
+	}
+
+	t.Run("members run concurrently and merge in order", func(t *testing.T) {
+		pe := newExecutor(map[string]string{
+			"http://api.example.com/cluster":  `{"phase":"Ready"}`,
+			"http://api.example.com/nodepool": `{"phase":"Ready"}`,
+			"http://api.example.com/network":  `{"phase":"Ready"}`,
+		})
+
+		ctx := context.Background()
+		execCtx := NewExecutionContext(ctx, map[string]interface{}{}, &config_loader.Config{})
+		outcome := pe.ExecuteAll(ctx, preconditions, execCtx)
+
+		require.NoError(t, outcome.Error)
+		assert.True(t, outcome.AllMatched)
+		require.Len(t, outcome.Results, 4)
+		for i, name := range []string{"cluster", "nodePool", "network", "allReady"} {
+			assert.Equal(t, name, outcome.Results[i].Name)
+		}
+		assert.Equal(t, "Ready", execCtx.Params["clusterPhase"])
+		assert.Equal(t, "Ready", execCtx.Params["nodePoolPhase"])
+		assert.Equal(t, map[string]interface{}{"phase": "Ready"}, execCtx.Params["network"])
+
+		evaluations := execCtx.GetEvaluationsByPhase(PhasePreconditions)
+		require.Len(t, evaluations, 4)
+		for i, name := range []string{"cluster", "nodePool", "network", "allReady"} {
+			assert.Equal(t, name, evaluations[i].Name)
+		}
+	})
+
+	t.Run("first failing member in config order decides", func(t *testing.T) {
+		pe := newExecutor(map[string]string{
+			"http://api.example.com/cluster": `{"phase":"Ready"}`,
+			"http://api.example.com/network": `{"phase":"Ready"}`,
+		})
+
+		ctx := context.Background()
+		execCtx := NewExecutionContext(ctx, map[string]interface{}{}, &config_loader.Config{})
+		outcome := pe.ExecuteAll(ctx, preconditions, execCtx)
+
+		require.Error(t, outcome.Error)
+		assert.False(t, outcome.AllMatched)
+		// Siblings of the failing member still complete
+		require.Len(t, outcome.Results, 3)
+		assert.Equal(t, StatusFailed, outcome.Results[1].Status)
+		assert.True(t, outcome.Results[2].Matched)
+		require.NotNil(t, execCtx.Adapter.ExecutionError)
+		assert.Equal(t, "nodePool", execCtx.Adapter.ExecutionError.Step)
+		assert.Equal(t, "Ready", execCtx.Params["networkPhase"])
+	})
+}
+
+func TestPreconditionResourceLookup(t *testing.T) {
+	pullSecret := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Secret",
+		"metadata":   map[string]interface{}{"name": "pull-secret", "namespace": "openshift-config"},
+		"type":       "kubernetes.io/dockerconfigjson",
+	}}
+	lookupPrecondition := func(lookup *config_loader.ResourceLookup) config_loader.Precondition {
+		return config_loader.Precondition{
+			ActionBase:     config_loader.ActionBase{Name: "pullSecret"},
+			ResourceLookup: lookup,
+			Capture: []config_loader.CaptureField{
+				{Name: "secretType", FieldExpressionDef: config_loader.FieldExpressionDef{Field: "type"}},
+			},
+			Conditions: []config_loader.Condition{
+				{Field: "pullSecret.metadata.name", Operator: "exists"},
+			},
+		}
+	}
+	secretLookup := &config_loader.ResourceLookup{
+		APIVersion: "v1",
+		Kind:       "Secret",
+		Discovery:  &config_loader.DiscoveryConfig{Namespace: "openshift-config", ByName: "{{ .secretName }}"},
+	}
+	newExecCtx := func() *ExecutionContext {
+		execCtx := NewExecutionContext(context.Background(), map[string]interface{}{}, &config_loader.Config{})
+		execCtx.Params["secretName"] = "pull-secret"
+		execCtx.Params["clusterName"] = "cluster-1"
+		return execCtx
+	}
+	newExecutor := func(client transport_client.TransportClient, clients map[string]transport_client.TransportClient) *PreconditionExecutor {
+		return newPreconditionExecutor(&ExecutorConfig{
+			TransportClient:  client,
+			TransportClients: clients,
+			Logger:           logger.NewTestLogger(),
+		})
+	}
+
+	t.Run("object found by name", func(t *testing.T) {
+		k8sClient := k8s_client.NewMockK8sClient()
+		k8sClient.Resources["openshift-config/pull-secret"] = pullSecret
+		pe := newExecutor(k8sClient, nil)
+
+		execCtx := newExecCtx()
+		outcome := pe.ExecuteAll(context.Background(), []config_loader.Precondition{lookupPrecondition(secretLookup)}, execCtx)
+
+		require.NoError(t, outcome.Error)
+		assert.True(t, outcome.AllMatched)
+		assert.Equal(t, pullSecret, outcome.Results[0].Resource)
+		assert.False(t, outcome.Results[0].APICallMade)
+		assert.Equal(t, "kubernetes.io/dockerconfigjson", execCtx.Params["secretType"])
+		assert.Equal(t, pullSecret.Object, execCtx.Params["pullSecret"])
+	})
+
+	t.Run("missing object is not met", func(t *testing.T) {
+		pe := newExecutor(k8s_client.NewMockK8sClient(), nil)
+
+		execCtx := newExecCtx()
+		outcome := pe.ExecuteAll(context.Background(), []config_loader.Precondition{lookupPrecondition(secretLookup)}, execCtx)
+
+		require.NoError(t, outcome.Error)
+		assert.False(t, outcome.AllMatched)
+		assert.Contains(t, outcome.NotMetReason, "precondition 'pullSecret' not met")
+		assert.Nil(t, outcome.Results[0].Resource)
+		assert.Contains(t, execCtx.Params, "pullSecret")
+		assert.Nil(t, execCtx.Params["pullSecret"])
+		assert.NotContains(t, execCtx.Params, "secretType")
+	})
+
+	t.Run("missing object captures an empty result", func(t *testing.T) {
+		pe := newExecutor(k8s_client.NewMockK8sClient(), nil)
+		precond := lookupPrecondition(secretLookup)
+		precond.Capture = []config_loader.CaptureField{
+			{Name: "secretType", FieldExpressionDef: config_loader.FieldExpressionDef{Field: "type"}},
+			{Name: "secretKeys", Mode: "all", FieldExpressionDef: config_loader.FieldExpressionDef{Field: "{.data.*}"}},
+			{Name: "secretKeyCount", Mode: "count", FieldExpressionDef: config_loader.FieldExpressionDef{Field: "{.data.*}"}},
+		}
+		precond.Conditions = []config_loader.Condition{{Field: "secretKeyCount", Operator: "equals", Value: int64(0)}}
+
+		execCtx := newExecCtx()
+		outcome := pe.ExecuteAll(context.Background(), []config_loader.Precondition{precond}, execCtx)
+
+		require.NoError(t, outcome.Error)
+		assert.True(t, outcome.AllMatched)
+		assert.NotContains(t, execCtx.Params, "secretType")
+		assert.NotContains(t, execCtx.Params, "secretKeys")
+		assert.Equal(t, int64(0), execCtx.Params["secretKeyCount"])
+	})
+
+	t.Run("missing object fails a required capture", func(t *testing.T) {
+		pe := newExecutor(k8s_client.NewMockK8sClient(), nil)
+		precond := lookupPrecondition(secretLookup)
+		precond.Capture[0].Required = true
+
+		execCtx := newExecCtx()
+		outcome := pe.ExecuteAll(context.Background(), []config_loader.Precondition{precond}, execCtx)
+
+		require.Error(t, outcome.Error)
+		assert.Contains(t, outcome.Error.Error(), "required capture 'secretType' failed")
+		assert.Equal(t, StatusFailed, outcome.Results[0].Status)
+		require.NotNil(t, execCtx.Adapter.ExecutionError)
+		assert.Equal(t, "pullSecret", execCtx.Adapter.ExecutionError.Step)
+	})
+
+	t.Run("lookup error fails the precondition", func(t *testing.T) {
+		k8sClient := k8s_client.NewMockK8sClient()
+		k8sClient.GetResourceError = errors.New("connection refused")
+		pe := newExecutor(k8sClient, nil)
+
+		execCtx := newExecCtx()
+		outcome := pe.ExecuteAll(context.Background(), []config_loader.Precondition{lookupPrecondition(secretLookup)}, execCtx)
+
+		require.Error(t, outcome.Error)
+		assert.Contains(t, outcome.Error.Error(), "resource lookup failed")
+		assert.Equal(t, StatusFailed, outcome.Results[0].Status)
+		require.NotNil(t, execCtx.Adapter.ExecutionError)
+		assert.Equal(t, "pullSecret", execCtx.Adapter.ExecutionError.Step)
+	})
+
+	t.Run("selector through the transport client of the lookup", func(t *testing.T) {
+		maestroClient := k8s_client.NewMockK8sClient()
+		maestroClient.DiscoverResult = &unstructured.UnstructuredList{Items: []unstructured.Unstructured{*pullSecret}}
+		pe := newExecutor(k8s_client.NewMockK8sClient(), map[string]transport_client.TransportClient{
+			config_loader.TransportClientMaestro: maestroClient,
+		})
+
+		lookup := &config_loader.ResourceLookup{
+			APIVersion: "v1",
+			Kind:       "Secret",
+			Transport: &config_loader.TransportConfig{
+				Client:  config_loader.TransportClientMaestro,
+				Maestro: &config_loader.MaestroTransportConfig{TargetCluster: "{{ .clusterName }}"},
+			},
+			Discovery: &config_loader.DiscoveryConfig{
+				BySelectors: &config_loader.SelectorConfig{LabelSelector: map[string]string{"app": "pull-secret"}},
+			},
+		}
+		execCtx := newExecCtx()
+		outcome := pe.ExecuteAll(context.Background(), []config_loader.Precondition{lookupPrecondition(lookup)}, execCtx)
+
+		require.NoError(t, outcome.Error)
+		assert.True(t, outcome.AllMatched)
+		assert.Equal(t, "kubernetes.io/dockerconfigjson", execCtx.Params["secretType"])
+	})
+
+	t.Run("kubernetes cluster rendering empty fails the precondition", func(t *testing.T) {
+		k8sClient := k8s_client.NewMockK8sClient()
+		k8sClient.Resources["openshift-config/pull-secret"] = pullSecret
+		pe := newExecutor(k8sClient, nil)
+
+		lookup := *secretLookup
+		lookup.Transport = &config_loader.TransportConfig{
+			Client:     config_loader.TransportClientKubernetes,
+			Kubernetes: &config_loader.KubernetesTransportConfig{Cluster: "{{ .hostedCluster }}"},
+		}
+		execCtx := newExecCtx()
+		execCtx.Params["hostedCluster"] = ""
+		outcome := pe.ExecuteAll(context.Background(), []config_loader.Precondition{lookupPrecondition(&lookup)}, execCtx)
+
+		require.Error(t, outcome.Error, "the lookup must not fall back to the adapter's own cluster")
+		assert.Contains(t, outcome.Error.Error(), "empty cluster name")
+		assert.Equal(t, StatusFailed, outcome.Results[0].Status)
+	})
+}
+
+func TestPreconditionCaptureModes(t *testing.T) {
+	statuses := `{"items":[
+		{"adapter":"dns","ready":true,"replicas":"3"},
+		{"adapter":"network","ready":true,"replicas":"2"},
+		{"adapter":"storage","ready":false,"replicas":"many"}
+	]}`
+	capture := func(name, field, mode, typ string, required bool) config_loader.CaptureField {
+		return config_loader.CaptureField{
+			Name:               name,
+			FieldExpressionDef: config_loader.FieldExpressionDef{Field: field},
+			Mode:               mode,
+			Type:               typ,
+			Required:           required,
+		}
+	}
+	execute := func(captures ...config_loader.CaptureField) (*PreconditionsOutcome, *ExecutionContext) {
+		client := &sequenceAPIClient{MockClient: hyperfleet_api.NewMockClient(), bodies: []string{statuses}}
+		pe := newPreconditionExecutor(&ExecutorConfig{APIClient: client, Logger: logger.NewTestLogger()})
+		precond := config_loader.Precondition{
+			ActionBase: config_loader.ActionBase{
+				Name:    "adapterStatuses",
+				APICall: &config_loader.APICall{Method: "GET", URL: "http://api.example.com/statuses"},
+			},
+			Capture: captures,
+		}
+		execCtx := NewExecutionContext(context.Background(), map[string]interface{}{}, &config_loader.Config{})
+		return pe.ExecuteAll(context.Background(), []config_loader.Precondition{precond}, execCtx), execCtx
+	}
+
+	t.Run("modes and types", func(t *testing.T) {
+		outcome, execCtx := execute(
+			capture("readyAdapters", "{.items[?(@.ready==true)].adapter}", "all", "", false),
+			capture("firstReady", "{.items[?(@.ready==true)].adapter}", "first", "", false),
+			capture("readyCount", "{.items[?(@.ready==true)].adapter}", "count", "", false),
+			capture("dnsReplicas", "{.items[?(@.adapter=='dns')].replicas}", "first", "int", true),
+			capture("computeAdapters", "{.items[?(@.adapter=='compute')].adapter}", "all", "", false),
+			capture("computeCount", "{.items[?(@.adapter=='compute')].adapter}", "count", "", true),
+		)
+
+		require.NoError(t, outcome.Error)
+		assert.True(t, outcome.AllMatched)
+		assert.Equal(t, []interface{}{"dns", "network"}, execCtx.Params["readyAdapters"])
+		assert.Equal(t, "dns", execCtx.Params["firstReady"])
+		assert.Equal(t, int64(2), execCtx.Params["readyCount"])
+		assert.Equal(t, int64(3), execCtx.Params["dnsReplicas"])
+		assert.NotContains(t, execCtx.Params, "computeAdapters")
+		assert.Equal(t, int64(0), execCtx.Params["computeCount"])
+	})
+
+	t.Run("optional capture failures are left unset", func(t *testing.T) {
+		outcome, execCtx := execute(
+			capture("phase", "status.phase", "", "", false),
+			capture("storageReplicas", "{.items[?(@.adapter=='storage')].replicas}", "first", "int", false),
+		)
+
+		require.NoError(t, outcome.Error)
+		assert.NotContains(t, execCtx.Params, "phase")
+		assert.NotContains(t, execCtx.Params, "storageReplicas")
+		assert.NotContains(t, outcome.Results[0].CapturedFields, "phase")
+	})
+
+	t.Run("optional capture failures fall back to the default", func(t *testing.T) {
+		phase := capture("phase", "status.phase", "", "", false)
+		phase.Default = "Unknown"
+		storageReplicas := capture("storageReplicas", "{.items[?(@.adapter=='storage')].replicas}", "first", "int", false)
+		storageReplicas.Default = "1"
+		outcome, execCtx := execute(phase, storageReplicas)
+
+		require.NoError(t, outcome.Error)
+		assert.Equal(t, "Unknown", execCtx.Params["phase"])
+		assert.Equal(t, int64(1), execCtx.Params["storageReplicas"])
+		assert.Equal(t, int64(1), outcome.Results[0].CapturedFields["storageReplicas"])
+	})
+
+	t.Run("required capture without value fails", func(t *testing.T) {
+		outcome, execCtx := execute(capture("computeAdapter", "{.items[?(@.adapter=='compute')].adapter}", "first", "", true))
+
+		require.Error(t, outcome.Error)
+		assert.Contains(t, outcome.Error.Error(), "required capture 'computeAdapter' failed")
+		assert.Equal(t, StatusFailed, outcome.Results[0].Status)
+		require.NotNil(t, execCtx.Adapter.ExecutionError)
+		assert.Equal(t, "adapterStatuses", execCtx.Adapter.ExecutionError.Step)
+	})
+
+	t.Run("required capture conversion failure fails", func(t *testing.T) {
+		outcome, _ := execute(capture("storageReplicas", "{.items[?(@.adapter=='storage')].replicas}", "first", "int", true))
+
+		require.Error(t, outcome.Error)
+		assert.Contains(t, outcome.Error.Error(), "failed to convert many to int")
+	})
+}
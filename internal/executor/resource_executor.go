@@ -4,33 +4,57 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/mitchellh/copystructure"
 	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/config_loader"
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/criteria"
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/k8s_client"
 	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/maestro_client"
 	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/manifest"
 	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/transport_client"
 	"github.com/openshift-hyperfleet/hyperfleet-adapter/pkg/logger"
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/pkg/utils"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	workv1 "open-cluster-management.io/api/work/v1"
 )
 
 // ResourceExecutor creates and updates Kubernetes resources
 type ResourceExecutor struct {
-	client transport_client.TransportClient
-	log    logger.Logger
+	client  transport_client.TransportClient
+	clients map[string]transport_client.TransportClient
+	log     logger.Logger
 }
 
 // newResourceExecutor creates a new resource executor
 // NOTE: Caller (NewExecutor) is responsible for config validation
 func newResourceExecutor(config *ExecutorConfig) *ResourceExecutor {
 	return &ResourceExecutor{
-		client: config.TransportClient,
-		log:    config.Logger,
+		client:  config.TransportClient,
+		clients: config.TransportClients,
+		log:     config.Logger,
 	}
 }
 
+// clientFor returns the transport client for a resource's transport client type,
+// falling back to the default transport client. Returns nil when none is configured.
+func (re *ResourceExecutor) clientFor(resource config_loader.Resource) transport_client.TransportClient {
+	return transportClientFor(resource, re.clients, re.client)
+}
+
+// transportClientFor returns the client of clients registered for a resource's transport client
+// type, falling back to the default client
+func transportClientFor(resource config_loader.Resource, clients map[string]transport_client.TransportClient,
+	fallback transport_client.TransportClient) transport_client.TransportClient {
+	if client, ok := clients[resource.GetTransportClient()]; ok {
+		return client
+	}
+	return fallback
+}
+
 // ExecuteAll creates/updates all resources in sequence
 // Returns results for each resource and updates the execution context
 func (re *ResourceExecutor) ExecuteAll(ctx context.Context, resources []config_loader.Resource, execCtx *ExecutionContext) ([]ResourceResult, error) {
@@ -40,6 +64,15 @@ func (re *ResourceExecutor) ExecuteAll(ctx context.Context, resources []config_l
 	results := make([]ResourceResult, 0, len(resources))
 
 	for _, resource := range resources {
+		if resource.ManifestFrom != nil {
+			fromResults, err := re.executeManifestFrom(ctx, resource, execCtx)
+			results = append(results, fromResults...)
+			if err != nil {
+				return results, err
+			}
+			continue
+		}
+
 		result, err := re.executeResource(ctx, resource, execCtx)
 		results = append(results, result)
 
@@ -60,7 +93,7 @@ func (re *ResourceExecutor) executeResource(ctx context.Context, resource config
 		Status: StatusSuccess,
 	}
 
-	transportClient := re.client
+	transportClient := re.clientFor(resource)
 	if transportClient == nil {
 		result.Status = StatusFailed
 		result.Error = fmt.Errorf("transport client not configured for %s", resource.GetTransportClient())
@@ -77,70 +110,402 @@ func (re *ResourceExecutor) executeResource(ctx context.Context, resource config
 	}
 
 	// Step 2: Prepare apply options
-	var applyOpts *transport_client.ApplyOptions
-	if resource.RecreateOnChange {
-		applyOpts = &transport_client.ApplyOptions{RecreateOnChange: true}
-	}
+	applyOpts := buildApplyOptions(resource)
 
 	// Step 3: Build transport context (nil for k8s, *maestro_client.TransportContext for maestro)
-	var transportTarget transport_client.TransportContext
-	if resource.IsMaestroTransport() && resource.Transport.Maestro != nil {
-		targetCluster, tplErr := renderTemplate(resource.Transport.Maestro.TargetCluster, execCtx.Params)
-		if tplErr != nil {
+	transportTarget, tplErr := buildTransportTarget(resource, execCtx)
+	if tplErr != nil {
+		result.Status = StatusFailed
+		result.Error = tplErr
+		return result, NewExecutorError(PhaseResources, resource.Name, "failed to render targetCluster template", tplErr)
+	}
+
+	// Step 4: Delete the resource instead of applying it when its delete condition holds
+	if resource.Delete != nil {
+		deleting, err := re.evaluateDeleteCondition(ctx, resource, execCtx)
+		if err != nil {
 			result.Status = StatusFailed
-			result.Error = tplErr
-			return result, NewExecutorError(PhaseResources, resource.Name, "failed to render targetCluster template", tplErr)
+			result.Error = err
+			return result, NewExecutorError(PhaseResources, resource.Name, "failed to evaluate delete condition", err)
 		}
-		transportTarget = &maestro_client.TransportContext{
-			ConsumerName: targetCluster,
+		if deleting {
+			return re.deleteResource(ctx, transportClient, resource, execCtx, renderedBytes, transportTarget)
 		}
 	}
 
-	// Step 4: Call transport client ApplyResource with rendered bytes
-	applyResult, err := transportClient.ApplyResource(ctx, renderedBytes, applyOpts, transportTarget)
+	// Step 5: Apply the rendered bytes, then discover the applied resource and store it in execCtx for CEL evaluation
+	discover := func() (*unstructured.Unstructured, error) {
+		return re.discoverResource(ctx, transportClient, resource, execCtx, transportTarget)
+	}
+	discovered, err := re.applyObject(ctx, transportClient, resource, execCtx, renderedBytes, applyOpts, transportTarget, "", &result, discover)
 	if err != nil {
-		result.Status = StatusFailed
-		result.Error = err
-		execCtx.Adapter.ExecutionError = &ExecutionError{
-			Phase:   string(PhaseResources),
-			Step:    resource.Name,
-			Message: err.Error(),
+		return result, err
+	}
+
+	if discovered != nil {
+		// Step 6: Nested discoveries — find sub-resources within the discovered parent (e.g., ManifestWork)
+		if len(resource.NestedDiscoveries) > 0 {
+			nestedResults := re.discoverNestedResources(ctx, resource, execCtx, discovered)
+			execCtx.Resources[resource.Name] = nestedResults
+			re.log.Debugf(ctx, "Resource[%s] discovered with %d nested resources", resource.Name, len(nestedResults))
+		} else {
+			execCtx.Resources[resource.Name] = discovered
+			re.log.Debugf(ctx, "Resource[%s] discovered and stored in context", resource.Name)
 		}
-		errCtx := logger.WithK8sResult(ctx, "FAILED")
-		errCtx = logger.WithErrorField(errCtx, err)
-		re.log.Errorf(errCtx, "Resource[%s] processed: FAILED", resource.Name)
-		return result, NewExecutorError(PhaseResources, resource.Name, "failed to apply resource", err)
 	}
 
-	// Step 5: Extract result
+	return result, nil
+}
+
+// applyObject applies one rendered object through the transport client and records the outcome in result.
+// objectKey identifies the object within a manifestFrom resource and is empty for a single manifest.
+// After a successful apply, discover (when set) reads the object back; a failed discovery is only logged
+// and yields a nil object. Apply failures set execCtx.Adapter.ExecutionError and return an ExecutorError.
+func (re *ResourceExecutor) applyObject(
+	ctx context.Context,
+	transportClient transport_client.TransportClient,
+	resource config_loader.Resource,
+	execCtx *ExecutionContext,
+	data []byte,
+	applyOpts *transport_client.ApplyOptions,
+	transportTarget transport_client.TransportContext,
+	objectKey string,
+	result *ResourceResult,
+	discover func() (*unstructured.Unstructured, error),
+) (*unstructured.Unstructured, error) {
+	subject, target := fmt.Sprintf("Resource[%s]", resource.Name), "resource"
+	if objectKey != "" {
+		subject, target = fmt.Sprintf("Resource[%s] object %s", resource.Name, objectKey), objectKey
+	}
+
+	applyResult, err := transportClient.ApplyResource(ctx, data, applyOpts, transportTarget)
+	if err != nil {
+		return nil, re.recordFailure(ctx, execCtx, resource, result, subject+" processed", err, "failed to apply "+target)
+	}
+
 	result.Operation = applyResult.Operation
 	result.OperationReason = applyResult.Reason
+	if result.Operation == manifest.OperationCorrectDrift {
+		execCtx.Adapter.recordDriftCorrected(resource.Name)
+	}
+
+	successCtx := logger.WithK8sResult(ctx, "SUCCESS")
+	re.log.Infof(successCtx, "%s processed: operation=%s reason=%s", subject, result.Operation, result.OperationReason)
+
+	if discover == nil {
+		return nil, nil
+	}
+	discovered, err := discover()
+	if err != nil {
+		re.log.Warnf(ctx, "%s discovery after apply failed: %v", subject, err)
+		return nil, nil
+	}
+	return discovered, nil
+}
+
+// recordFailure marks a resource result as failed, records the error as the execution error of the
+// adapter, logs it for subject and returns the ExecutorError to report with msg
+func (re *ResourceExecutor) recordFailure(
+	ctx context.Context,
+	execCtx *ExecutionContext,
+	resource config_loader.Resource,
+	result *ResourceResult,
+	subject string,
+	err error,
+	msg string,
+) error {
+	result.Status = StatusFailed
+	result.Error = err
+	execCtx.Adapter.ExecutionError = &ExecutionError{
+		Phase:   string(PhaseResources),
+		Step:    resource.Name,
+		Message: err.Error(),
+	}
+	errCtx := logger.WithK8sResult(ctx, "FAILED")
+	errCtx = logger.WithErrorField(errCtx, err)
+	re.log.Errorf(errCtx, "%s: FAILED", subject)
+	return NewExecutorError(PhaseResources, resource.Name, msg, err)
+}
+
+// evaluateDeleteCondition evaluates the delete.when condition of a resource
+func (re *ResourceExecutor) evaluateDeleteCondition(ctx context.Context, resource config_loader.Resource, execCtx *ExecutionContext) (bool, error) {
+	renderer, err := newManifestRenderer(ctx, execCtx, re.log)
+	if err != nil {
+		return false, err
+	}
+	return renderer.evaluateCondition(resource.Delete.When)
+}
+
+// deleteResource deletes the resource identified by its rendered manifest through the transport
+// client and, with delete.wait, waits until the transport reports it removed.
+// The resource is dropped from execCtx.Resources so post-actions see it as gone.
+func (re *ResourceExecutor) deleteResource(
+	ctx context.Context,
+	transportClient transport_client.TransportClient,
+	resource config_loader.Resource,
+	execCtx *ExecutionContext,
+	renderedBytes []byte,
+	transportTarget transport_client.TransportContext,
+) (ResourceResult, error) {
+	result := ResourceResult{
+		Name:      resource.Name,
+		Status:    StatusSuccess,
+		Operation: manifest.OperationDelete,
+	}
+	failed := func(err error, msg string) (ResourceResult, error) {
+		return result, re.recordFailure(ctx, execCtx, resource, &result, fmt.Sprintf("Resource[%s] delete", resource.Name), err, msg)
+	}
+
+	remover, ok := transportClient.(transport_client.ResourceRemover)
+	if !ok {
+		return failed(fmt.Errorf("transport client %s does not support delete", resource.GetTransportClient()),
+			"failed to delete resource")
+	}
+
+	obj := &unstructured.Unstructured{}
+	if err := obj.UnmarshalJSON(renderedBytes); err != nil {
+		return failed(err, "failed to parse rendered manifest")
+	}
+	result.Kind = obj.GetKind()
+	result.Namespace = obj.GetNamespace()
+	result.ResourceName = obj.GetName()
+
+	opts := &transport_client.DeleteOptions{Wait: resource.Delete.Wait}
+	if resource.Delete.Timeout != "" {
+		timeout, err := time.ParseDuration(resource.Delete.Timeout)
+		if err != nil {
+			return failed(fmt.Errorf("invalid delete timeout %q: %w", resource.Delete.Timeout, err), "failed to delete resource")
+		}
+		opts.Timeout = timeout
+	}
+
+	re.log.Infof(ctx, "Resource[%s] delete condition matched, deleting %s %s (wait=%t)",
+		resource.Name, result.Kind, result.ResourceName, opts.Wait)
+	if err := remover.RemoveResource(ctx, obj.GroupVersionKind(), obj.GetNamespace(), obj.GetName(), opts, transportTarget); err != nil {
+		return failed(err, "failed to delete resource")
+	}
+
+	result.OperationReason = "delete condition matched"
+	if opts.Wait {
+		result.OperationReason += ", removal confirmed"
+	}
+	delete(execCtx.Resources, resource.Name)
 
 	successCtx := logger.WithK8sResult(ctx, "SUCCESS")
 	re.log.Infof(successCtx, "Resource[%s] processed: operation=%s reason=%s",
 		resource.Name, result.Operation, result.OperationReason)
+	return result, nil
+}
 
-	// Step 6: Post-apply discovery — find the applied resource and store in execCtx for CEL evaluation
-	if resource.Discovery != nil {
-		discovered, discoverErr := re.discoverResource(ctx, resource, execCtx, transportTarget)
-		if discoverErr != nil {
-			re.log.Warnf(ctx, "Resource[%s] discovery after apply failed: %v", resource.Name, discoverErr)
-		} else if discovered != nil {
-			// Step 7: Nested discoveries — find sub-resources within the discovered parent (e.g., ManifestWork)
-			if len(resource.NestedDiscoveries) > 0 {
-				nestedResults := re.discoverNestedResources(ctx, resource, execCtx, discovered)
-				execCtx.Resources[resource.Name] = nestedResults
-				re.log.Debugf(ctx, "Resource[%s] discovered with %d nested resources", resource.Name, len(nestedResults))
-			} else {
-				execCtx.Resources[resource.Name] = discovered
-				re.log.Debugf(ctx, "Resource[%s] discovered and stored in context", resource.Name)
+// buildApplyOptions returns the apply options configured on a resource (nil uses the defaults)
+func buildApplyOptions(resource config_loader.Resource) *transport_client.ApplyOptions {
+	if !resource.RecreateOnChange && !resource.DriftDetection {
+		return nil
+	}
+	return &transport_client.ApplyOptions{
+		RecreateOnChange: resource.RecreateOnChange,
+		DriftDetection:   resource.DriftDetection,
+	}
+}
+
+// buildTransportTarget builds the per-request transport context for a resource.
+// Returns a *maestro_client.TransportContext for maestro transport, a *k8s_client.TransportContext
+// for k8s transport with a target cluster, and nil for the adapter's own cluster.
+func buildTransportTarget(resource config_loader.Resource, execCtx *ExecutionContext) (transport_client.TransportContext, error) {
+	if cluster := resource.GetTargetCluster(); cluster != "" && !resource.IsMaestroTransport() {
+		targetCluster, err := renderTemplate(cluster, execCtx.Params, execCtx.Config.TemplateOptions())
+		if err != nil {
+			return nil, err
+		}
+		// An empty cluster would silently target the adapter's own cluster
+		if strings.TrimSpace(targetCluster) == "" {
+			return nil, fmt.Errorf("transport.kubernetes.cluster %q rendered to an empty cluster name", cluster)
+		}
+		return &k8s_client.TransportContext{Cluster: targetCluster}, nil
+	}
+	if !resource.IsMaestroTransport() || resource.Transport.Maestro == nil {
+		return nil, nil
+	}
+	targetCluster, err := renderTemplate(resource.Transport.Maestro.TargetCluster, execCtx.Params, execCtx.Config.TemplateOptions())
+	if err != nil {
+		return nil, err
+	}
+	return &maestro_client.TransportContext{
+		ConsumerName: targetCluster,
+	}, nil
+}
+
+// executeManifestFrom applies every object produced by a resource's manifestFrom source.
+// One ResourceResult is returned per object; execution stops at the first failure.
+// Applied objects are discovered by their own GVK/namespace/name and stored in execCtx.Resources
+// under the resource name as a map keyed by "Kind/name".
+func (re *ResourceExecutor) executeManifestFrom(ctx context.Context, resource config_loader.Resource, execCtx *ExecutionContext) ([]ResourceResult, error) {
+	failed := func(err error, msg string) ([]ResourceResult, error) {
+		result := ResourceResult{Name: resource.Name}
+		err = re.recordFailure(ctx, execCtx, resource, &result, fmt.Sprintf("Resource[%s] manifestFrom", resource.Name), err, msg)
+		return []ResourceResult{result}, err
+	}
+
+	transportClient := re.clientFor(resource)
+	if transportClient == nil {
+		return failed(fmt.Errorf("transport client not configured for %s", resource.GetTransportClient()), "transport client not configured")
+	}
+
+	re.log.Debugf(ctx, "Rendering manifestFrom objects for resource %s", resource.Name)
+	objects, err := re.renderManifestFrom(ctx, resource, execCtx)
+	if err != nil {
+		return failed(err, "failed to render manifest")
+	}
+
+	transportTarget, err := buildTransportTarget(resource, execCtx)
+	if err != nil {
+		return failed(err, "failed to render targetCluster template")
+	}
+
+	applyOpts := buildApplyOptions(resource)
+
+	results := make([]ResourceResult, 0, len(objects))
+	discovered := make(map[string]*unstructured.Unstructured, len(objects))
+	for _, obj := range objects {
+		u := &unstructured.Unstructured{Object: obj}
+		result := ResourceResult{
+			Name:         resource.Name,
+			Kind:         u.GetKind(),
+			Namespace:    u.GetNamespace(),
+			ResourceName: u.GetName(),
+			Status:       StatusSuccess,
+		}
+		objectKey := u.GetKind() + "/" + u.GetName()
+
+		data, err := json.Marshal(obj)
+		if err != nil {
+			err = re.recordFailure(ctx, execCtx, resource, &result, fmt.Sprintf("Resource[%s] object %s render", resource.Name, objectKey),
+				fmt.Errorf("failed to marshal %s: %w", objectKey, err), "failed to render manifest")
+			return append(results, result), err
+		}
+
+		gvk, namespace, name := u.GroupVersionKind(), u.GetNamespace(), u.GetName()
+		discover := func() (*unstructured.Unstructured, error) {
+			return transportClient.GetResource(ctx, gvk, namespace, name, transportTarget)
+		}
+		current, err := re.applyObject(ctx, transportClient, resource, execCtx, data, applyOpts, transportTarget, objectKey, &result, discover)
+		results = append(results, result)
+		if err != nil {
+			return results, err
+		}
+		if current != nil {
+			discovered[objectKey] = current
+		}
+	}
+
+	execCtx.Resources[resource.Name] = discovered
+	re.log.Debugf(ctx, "Resource[%s] applied %d objects, discovered %d", resource.Name, len(objects), len(discovered))
+
+	return results, nil
+}
+
+// renderManifestFrom renders the objects of a manifestFrom source.
+// Loaded manifests are rendered like inline manifests; charts are rendered with their values
+// rendered first. Labels and annotations are added to every produced object.
+func (re *ResourceExecutor) renderManifestFrom(ctx context.Context, resource config_loader.Resource, execCtx *ExecutionContext) ([]map[string]interface{}, error) {
+	from := resource.ManifestFrom
+	opts := execCtx.Config.TemplateOptions()
+
+	renderer, err := newManifestRenderer(ctx, execCtx, re.log)
+	if err != nil {
+		return nil, err
+	}
+
+	var objects []map[string]interface{}
+	switch {
+	case from.Chart != nil:
+		if from.ChartContent == nil {
+			return nil, fmt.Errorf("chart %s is not loaded for resource %s", from.Chart.Path, resource.Name)
+		}
+		values, err := renderer.renderMap(deepCopyMap(ctx, from.Chart.Values, re.log))
+		if err != nil {
+			return nil, fmt.Errorf("failed to render chart values: %w", err)
+		}
+		releaseName := resource.Name
+		if from.Chart.ReleaseName != "" {
+			if releaseName, err = renderTemplate(from.Chart.ReleaseName, execCtx.Params, opts); err != nil {
+				return nil, fmt.Errorf("failed to render chart releaseName: %w", err)
 			}
 		}
+		namespace, err := renderTemplate(from.Chart.Namespace, execCtx.Params, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render chart namespace: %w", err)
+		}
+		objects, err = from.ChartContent.Render(manifest.ChartRelease{Name: releaseName, Namespace: namespace}, values)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		for i, m := range from.Manifests {
+			rendered, err := renderer.renderMap(deepCopyMap(ctx, m, re.log))
+			if err != nil {
+				return nil, fmt.Errorf("failed to render manifest %d: %w", i, err)
+			}
+			objects = append(objects, rendered)
+		}
+	}
+
+	if len(objects) == 0 {
+		return nil, fmt.Errorf("manifestFrom produced no objects for resource %s", resource.Name)
+	}
+
+	for i, obj := range objects {
+		if objects[i], err = renderer.applyPatches(ctx, resource.Patches, obj); err != nil {
+			return nil, err
+		}
+	}
+
+	labels, err := renderStringMap(from.Labels, execCtx.Params, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render labels: %w", err)
 	}
+	annotations, err := renderStringMap(from.Annotations, execCtx.Params, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render annotations: %w", err)
+	}
+	for _, obj := range objects {
+		u := &unstructured.Unstructured{Object: obj}
+		if len(labels) > 0 {
+			u.SetLabels(mergeStringMaps(u.GetLabels(), labels))
+		}
+		if len(annotations) > 0 {
+			u.SetAnnotations(mergeStringMaps(u.GetAnnotations(), annotations))
+		}
+	}
+
+	return objects, nil
+}
 
+// renderStringMap renders the values of a string map as templates
+func renderStringMap(m map[string]string, params map[string]interface{}, opts utils.TemplateOptions) (map[string]string, error) {
+	result := make(map[string]string, len(m))
+	for k, v := range m {
+		rendered, err := renderTemplate(v, params, opts)
+		if err != nil {
+			return nil, fmt.Errorf("key '%s': %w", k, err)
+		}
+		result[k] = rendered
+	}
 	return result, nil
 }
 
+// mergeStringMaps returns base with overrides applied; overrides win on conflicts
+func mergeStringMaps(base, overrides map[string]string) map[string]string {
+	result := make(map[string]string, len(base)+len(overrides))
+	for k, v := range base {
+		result[k] = v
+	}
+	for k, v := range overrides {
+		result[k] = v
+	}
+	return result
+}
+
 // renderToBytes renders the resource's manifest template to JSON bytes.
 // The manifest holds either a K8s resource or a ManifestWork depending on transport type.
 func (re *ResourceExecutor) renderToBytes(ctx context.Context, resource config_loader.Resource, execCtx *ExecutionContext) ([]byte, error) {
@@ -164,12 +529,34 @@ func (re *ResourceExecutor) renderToBytes(ctx context.Context, resource config_l
 	// Deep copy to avoid modifying the original
 	manifestData = deepCopyMap(ctx, manifestData, re.log)
 
-	// Render all template strings in the manifest
-	renderedData, err := renderManifestTemplates(manifestData, execCtx.Params)
+	// Render all template strings and !expr values in the manifest
+	renderer, err := newManifestRenderer(ctx, execCtx, re.log)
+	if err != nil {
+		return nil, err
+	}
+	renderedData, err := renderer.renderMap(manifestData)
 	if err != nil {
 		return nil, fmt.Errorf("failed to render manifest templates: %w", err)
 	}
 
+	// Apply patches to the rendered manifest (and matching ManifestWork workload manifests)
+	renderedData, err = renderer.applyPatches(ctx, resource.Patches, renderedData)
+	if err != nil {
+		return nil, err
+	}
+
+	// Request the status feedback of nested discoveries and set the deletion propagation in the ManifestWork
+	if resource.IsMaestroTransport() {
+		renderedData, err = re.applyFeedbackRules(ctx, resource, execCtx, renderedData)
+		if err != nil {
+			return nil, err
+		}
+		renderedData, err = re.applyDeleteOption(ctx, resource, execCtx, renderedData)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Marshal to JSON bytes
 	data, err := json.Marshal(renderedData)
 	if err != nil {
@@ -183,41 +570,44 @@ func (re *ResourceExecutor) renderToBytes(ctx context.Context, resource config_l
 // For k8s transport: discovers the K8s resource by name or label selector.
 // For maestro transport: discovers the ManifestWork by name or label selector.
 // The discovered resource is stored in execCtx.Resources for post-action CEL evaluation.
-func (re *ResourceExecutor) discoverResource(ctx context.Context, resource config_loader.Resource, execCtx *ExecutionContext, transportTarget transport_client.TransportContext) (*unstructured.Unstructured, error) {
-	discovery := resource.Discovery
-	if discovery == nil {
+func (re *ResourceExecutor) discoverResource(ctx context.Context, client transport_client.TransportClient, resource config_loader.Resource, execCtx *ExecutionContext, transportTarget transport_client.TransportContext) (*unstructured.Unstructured, error) {
+	if resource.Discovery == nil {
 		return nil, nil
 	}
+	// For maestro: use ManifestWork GVK
+	// For k8s: parse the rendered manifest to get GVK
+	return discoverObject(ctx, client, re.resolveGVK(resource), resource.Discovery, execCtx, transportTarget)
+}
 
+// discoverObject renders a discovery config and reads the matching object of the given GVK:
+// by name, or the latest generation among the objects matching the label selector.
+// Returns a NotFound error when no object matches.
+func discoverObject(ctx context.Context, client transport_client.TransportClient, gvk schema.GroupVersionKind, discovery *config_loader.DiscoveryConfig, execCtx *ExecutionContext, transportTarget transport_client.TransportContext) (*unstructured.Unstructured, error) {
 	// Render discovery namespace template
-	namespace, err := renderTemplate(discovery.Namespace, execCtx.Params)
+	namespace, err := renderTemplate(discovery.Namespace, execCtx.Params, execCtx.Config.TemplateOptions())
 	if err != nil {
 		return nil, fmt.Errorf("failed to render namespace template: %w", err)
 	}
 
 	// Discover by name
 	if discovery.ByName != "" {
-		name, err := renderTemplate(discovery.ByName, execCtx.Params)
+		name, err := renderTemplate(discovery.ByName, execCtx.Params, execCtx.Config.TemplateOptions())
 		if err != nil {
 			return nil, fmt.Errorf("failed to render byName template: %w", err)
 		}
 
-		// For maestro: use ManifestWork GVK
-		// For k8s: parse the rendered manifest to get GVK
-		gvk := re.resolveGVK(resource)
-
-		return re.client.GetResource(ctx, gvk, namespace, name, transportTarget)
+		return client.GetResource(ctx, gvk, namespace, name, transportTarget)
 	}
 
 	// Discover by label selector
 	if discovery.BySelectors != nil && len(discovery.BySelectors.LabelSelector) > 0 {
 		renderedLabels := make(map[string]string)
 		for k, v := range discovery.BySelectors.LabelSelector {
-			renderedK, err := renderTemplate(k, execCtx.Params)
+			renderedK, err := renderTemplate(k, execCtx.Params, execCtx.Config.TemplateOptions())
 			if err != nil {
 				return nil, fmt.Errorf("failed to render label key template: %w", err)
 			}
-			renderedV, err := renderTemplate(v, execCtx.Params)
+			renderedV, err := renderTemplate(v, execCtx.Params, execCtx.Config.TemplateOptions())
 			if err != nil {
 				return nil, fmt.Errorf("failed to render label value template: %w", err)
 			}
@@ -230,9 +620,7 @@ func (re *ResourceExecutor) discoverResource(ctx context.Context, resource confi
 			LabelSelector: labelSelector,
 		}
 
-		gvk := re.resolveGVK(resource)
-
-		list, err := re.client.DiscoverResources(ctx, gvk, discoveryConfig, transportTarget)
+		list, err := client.DiscoverResources(ctx, gvk, discoveryConfig, transportTarget)
 		if err != nil {
 			return nil, err
 		}
@@ -263,7 +651,7 @@ func (re *ResourceExecutor) discoverNestedResources(
 		}
 
 		// Build discovery config with rendered templates
-		discoveryConfig, err := re.buildNestedDiscoveryConfig(nd.Discovery, execCtx.Params)
+		discoveryConfig, err := re.buildNestedDiscoveryConfig(nd.Discovery, execCtx.Params, execCtx.Config.TemplateOptions())
 		if err != nil {
 			re.log.Warnf(ctx, "Resource[%s] nested discovery[%s] failed to build config: %v",
 				resource.Name, nd.Name, err)
@@ -287,6 +675,14 @@ func (re *ResourceExecutor) discoverNestedResources(
 		// Use the latest generation match
 		best := manifest.GetLatestGenerationFromList(list)
 		if best != nil {
+			// Map the status reported by the ManifestWork agent onto the manifest
+			withStatus, err := manifest.ApplyManifestStatus(parent, best, feedbackRules(nd.Feedback).JSONPaths)
+			if err != nil {
+				re.log.Warnf(ctx, "Resource[%s] nested discovery[%s] failed to map manifest status: %v",
+					resource.Name, nd.Name, err)
+			} else {
+				best = withStatus
+			}
 			nestedResults[nd.Name] = best
 			re.log.Debugf(ctx, "Resource[%s] nested discovery[%s] found: %s/%s",
 				resource.Name, nd.Name, best.GetKind(), best.GetName())
@@ -296,18 +692,96 @@ func (re *ResourceExecutor) discoverNestedResources(
 	return nestedResults
 }
 
+// applyFeedbackRules adds the status feedback requested by the resource's nested discoveries
+// to the rendered ManifestWork's spec.manifestConfigs.
+func (re *ResourceExecutor) applyFeedbackRules(
+	ctx context.Context,
+	resource config_loader.Resource,
+	execCtx *ExecutionContext,
+	work map[string]interface{},
+) (map[string]interface{}, error) {
+	for _, nd := range resource.NestedDiscoveries {
+		if nd.Feedback == nil || nd.Discovery == nil {
+			continue
+		}
+
+		discoveryConfig, err := re.buildNestedDiscoveryConfig(nd.Discovery, execCtx.Params, execCtx.Config.TemplateOptions())
+		if err != nil {
+			return nil, fmt.Errorf("nested discovery[%s] feedback: %w", nd.Name, err)
+		}
+
+		var matched int
+		work, matched, err = manifest.SetFeedbackRules(work, discoveryConfig, feedbackRules(nd.Feedback))
+		if err != nil {
+			return nil, fmt.Errorf("nested discovery[%s] feedback: %w", nd.Name, err)
+		}
+		if matched == 0 {
+			re.log.Warnf(ctx, "Resource[%s] nested discovery[%s] feedback matched no workload manifest",
+				resource.Name, nd.Name)
+		}
+	}
+	return work, nil
+}
+
+// applyDeleteOption sets the rendered ManifestWork's spec.deleteOption from the resource's
+// transport.maestro.deleteOption, orphaning the manifests matched by nested discoveries marked orphan.
+func (re *ResourceExecutor) applyDeleteOption(
+	ctx context.Context,
+	resource config_loader.Resource,
+	execCtx *ExecutionContext,
+	work map[string]interface{},
+) (map[string]interface{}, error) {
+	deleteOption := resource.GetDeleteOption()
+	if deleteOption == nil {
+		return work, nil
+	}
+
+	var orphans []manifest.Discovery
+	for _, nd := range resource.NestedDiscoveries {
+		if !nd.Orphan || nd.Discovery == nil {
+			continue
+		}
+		discoveryConfig, err := re.buildNestedDiscoveryConfig(nd.Discovery, execCtx.Params, execCtx.Config.TemplateOptions())
+		if err != nil {
+			return nil, fmt.Errorf("nested discovery[%s] orphan: %w", nd.Name, err)
+		}
+		orphans = append(orphans, discoveryConfig)
+	}
+
+	work, orphaned, err := manifest.SetDeleteOption(work, workv1.DeletePropagationPolicyType(deleteOption.PropagationPolicy), orphans)
+	if err != nil {
+		return nil, fmt.Errorf("deleteOption: %w", err)
+	}
+	re.log.Debugf(ctx, "Resource[%s] deleteOption %s set with %d orphaned manifests",
+		resource.Name, deleteOption.PropagationPolicy, orphaned)
+	return work, nil
+}
+
+// feedbackRules converts a nested discovery feedback config to manifest.FeedbackRules
+func feedbackRules(feedback *config_loader.FeedbackConfig) manifest.FeedbackRules {
+	if feedback == nil {
+		return manifest.FeedbackRules{}
+	}
+	rules := manifest.FeedbackRules{WellKnownStatus: feedback.WellKnownStatus}
+	for _, p := range feedback.JSONPaths {
+		rules.JSONPaths = append(rules.JSONPaths, manifest.FeedbackJSONPath{Name: p.Name, Path: p.Path})
+	}
+	return rules
+}
+
 // buildNestedDiscoveryConfig renders templates in a discovery config and returns a manifest.DiscoveryConfig.
 func (re *ResourceExecutor) buildNestedDiscoveryConfig(
 	discovery *config_loader.DiscoveryConfig,
 	params map[string]interface{},
+	opts utils.TemplateOptions,
 ) (*manifest.DiscoveryConfig, error) {
-	namespace, err := renderTemplate(discovery.Namespace, params)
+	namespace, err := renderTemplate(discovery.Namespace, params, opts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to render namespace template: %w", err)
 	}
 
 	if discovery.ByName != "" {
-		name, err := renderTemplate(discovery.ByName, params)
+		name, err := renderTemplate(discovery.ByName, params, opts)
 		if err != nil {
 			return nil, fmt.Errorf("failed to render byName template: %w", err)
 		}
@@ -320,11 +794,11 @@ func (re *ResourceExecutor) buildNestedDiscoveryConfig(
 	if discovery.BySelectors != nil && len(discovery.BySelectors.LabelSelector) > 0 {
 		renderedLabels := make(map[string]string)
 		for k, v := range discovery.BySelectors.LabelSelector {
-			renderedK, err := renderTemplate(k, params)
+			renderedK, err := renderTemplate(k, params, opts)
 			if err != nil {
 				return nil, fmt.Errorf("failed to render label key template: %w", err)
 			}
-			renderedV, err := renderTemplate(v, params)
+			renderedV, err := renderTemplate(v, params, opts)
 			if err != nil {
 				return nil, fmt.Errorf("failed to render label value template: %w", err)
 			}
@@ -426,17 +900,44 @@ func deepCopyMap(ctx context.Context, m map[string]interface{}, log logger.Logge
 	return result
 }
 
-// renderManifestTemplates recursively renders all template strings in a manifest
-func renderManifestTemplates(data map[string]interface{}, params map[string]interface{}) (map[string]interface{}, error) {
+// manifestRenderer renders a manifest: template strings are rendered with Go templates and
+// !expr values are replaced by the native result of their CEL expression.
+type manifestRenderer struct {
+	params    map[string]interface{}
+	opts      utils.TemplateOptions
+	evaluator *criteria.Evaluator
+	log       logger.Logger
+}
+
+// newManifestRenderer creates a manifest renderer with the CEL variables of the execution context
+func newManifestRenderer(ctx context.Context, execCtx *ExecutionContext, log logger.Logger) (*manifestRenderer, error) {
+	evalCtx := criteria.NewEvaluationContext()
+	evalCtx.SetVariablesFromMap(execCtx.GetCELVariables())
+
+	evaluator, err := criteria.NewEvaluator(ctx, evalCtx, log)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create evaluator: %w", err)
+	}
+
+	return &manifestRenderer{
+		params:    execCtx.Params,
+		opts:      execCtx.Config.TemplateOptions(),
+		evaluator: evaluator,
+		log:       log,
+	}, nil
+}
+
+// renderMap recursively renders all template strings and expressions in a manifest map
+func (r *manifestRenderer) renderMap(data map[string]interface{}) (map[string]interface{}, error) {
 	result := make(map[string]interface{})
 
 	for k, v := range data {
-		renderedKey, err := renderTemplate(k, params)
+		renderedKey, err := renderTemplate(k, r.params, r.opts)
 		if err != nil {
 			return nil, fmt.Errorf("failed to render key '%s': %w", k, err)
 		}
 
-		renderedValue, err := renderValue(v, params)
+		renderedValue, err := r.renderValue(v)
 		if err != nil {
 			return nil, fmt.Errorf("failed to render value for key '%s': %w", k, err)
 		}
@@ -448,19 +949,22 @@ func renderManifestTemplates(data map[string]interface{}, params map[string]inte
 }
 
 // renderValue renders a value recursively
-func renderValue(v interface{}, params map[string]interface{}) (interface{}, error) {
+func (r *manifestRenderer) renderValue(v interface{}) (interface{}, error) {
 	switch val := v.(type) {
 	case string:
-		return renderTemplate(val, params)
+		return renderTemplate(val, r.params, r.opts)
 	case map[string]interface{}:
-		return renderManifestTemplates(val, params)
+		if expr, ok := config_loader.ParseManifestExpression(val); ok {
+			return r.evaluateExpression(expr)
+		}
+		return r.renderMap(val)
 	case map[interface{}]interface{}:
 		converted := convertToStringKeyMap(val)
-		return renderManifestTemplates(converted, params)
+		return r.renderValue(converted)
 	case []interface{}:
 		result := make([]interface{}, len(val))
 		for i, item := range val {
-			rendered, err := renderValue(item, params)
+			rendered, err := r.renderValue(item)
 			if err != nil {
 				return nil, err
 			}
@@ -472,6 +976,76 @@ func renderValue(v interface{}, params map[string]interface{}) (interface{}, err
 	}
 }
 
+// applyPatches applies the resource patches in order to a rendered object.
+// Patches whose when condition is false are skipped; patch documents are rendered like manifests.
+func (r *manifestRenderer) applyPatches(ctx context.Context, patches []config_loader.Patch, obj map[string]interface{}) (map[string]interface{}, error) {
+	for i, patch := range patches {
+		name := patch.Name
+		if name == "" {
+			name = fmt.Sprintf("#%d", i)
+		}
+
+		if patch.When != "" {
+			matched, err := r.evaluateCondition(patch.When)
+			if err != nil {
+				return nil, fmt.Errorf("failed to evaluate when condition of patch %s: %w", name, err)
+			}
+			if !matched {
+				r.log.Debugf(ctx, "Patch %s skipped: condition %q is false", name, patch.When)
+				continue
+			}
+		}
+
+		doc, err := r.renderValue(patch.Patch)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render patch %s: %w", name, err)
+		}
+
+		var target manifest.PatchTarget
+		if patch.Target != nil {
+			targetName, err := renderTemplate(patch.Target.Name, r.params, r.opts)
+			if err != nil {
+				return nil, fmt.Errorf("failed to render target name of patch %s: %w", name, err)
+			}
+			target = manifest.PatchTarget{Kind: patch.Target.Kind, Name: targetName}
+		}
+
+		patched, count, err := manifest.PatchManifests(obj, target, manifest.PatchType(patch.Type), doc)
+		if err != nil {
+			return nil, fmt.Errorf("failed to apply patch %s: %w", name, err)
+		}
+		r.log.Debugf(ctx, "Patch %s applied to %d objects", name, count)
+		obj = patched
+	}
+	return obj, nil
+}
+
+// evaluateCondition evaluates a boolean CEL condition. Evaluation errors and non-boolean results are errors.
+func (r *manifestRenderer) evaluateCondition(expr string) (bool, error) {
+	value, err := r.evaluateExpression(expr)
+	if err != nil {
+		return false, err
+	}
+	matched, ok := value.(bool)
+	if !ok {
+		return false, fmt.Errorf("condition %q must evaluate to a bool, got %T", expr, value)
+	}
+	return matched, nil
+}
+
+// evaluateExpression evaluates a !expr value and returns its native result.
+// Unlike conditions, evaluation errors are not tolerated: the manifest cannot be applied without the value.
+func (r *manifestRenderer) evaluateExpression(expr string) (interface{}, error) {
+	result, err := r.evaluator.EvaluateCEL(expr)
+	if err != nil {
+		return nil, err
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return result.Value, nil
+}
+
 // GetResourceAsMap converts an unstructured resource to a map for CEL evaluation
 func GetResourceAsMap(resource *unstructured.Unstructured) map[string]interface{} {
 	if resource == nil {
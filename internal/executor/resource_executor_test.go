@@ -2,10 +2,20 @@ package executor
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/config_loader"
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/k8s_client"
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/maestro_client"
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/manifest"
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/transport_client"
 	"github.com/openshift-hyperfleet/hyperfleet-adapter/pkg/logger"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 func TestDeepCopyMap_BasicTypes(t *testing.T) {
@@ -236,3 +246,654 @@ func TestDeepCopyMap_RealWorldContext(t *testing.T) {
 	originalMetadata := manifest["metadata"].(map[string]interface{})
 	assert.Equal(t, "{{ .namespace }}", originalMetadata["name"])
 }
+
+func TestRenderToBytes_ManifestExpressions(t *testing.T) {
+	expr := func(e string) map[string]interface{} {
+		return map[string]interface{}{config_loader.ManifestExpressionTag: e}
+	}
+
+	re := &ResourceExecutor{log: logger.NewTestLogger()}
+	execCtx := NewExecutionContext(context.Background(), map[string]interface{}{}, nil)
+	execCtx.Params["clusterId"] = "abc"
+	execCtx.Params["nodeCount"] = int64(3)
+	execCtx.Params["zones"] = []interface{}{"us-east-1a", "us-east-1b"}
+	execCtx.Params["labels"] = map[string]interface{}{"team": "core"}
+
+	tests := []struct {
+		name        string
+		manifest    map[string]interface{}
+		expected    string
+		expectError string
+	}{
+		{
+			name: "native types in a K8s resource",
+			manifest: map[string]interface{}{
+				"apiVersion": "apps/v1",
+				"kind":       "Deployment",
+				"metadata": map[string]interface{}{
+					"name":   "app-{{ .clusterId }}",
+					"labels": expr("labels"),
+				},
+				"spec": map[string]interface{}{
+					"replicas": expr("nodeCount"),
+					"paused":   expr("nodeCount > 5"),
+					"zones":    expr("zones"),
+					"ports":    expr("[{'port': 8080}, {'port': 8443}]"),
+					"quoted":   "{{ .nodeCount }}",
+				},
+			},
+			expected: `{
+				"apiVersion": "apps/v1",
+				"kind": "Deployment",
+				"metadata": {"name": "app-abc", "labels": {"team": "core"}},
+				"spec": {
+					"replicas": 3,
+					"paused": false,
+					"zones": ["us-east-1a", "us-east-1b"],
+					"ports": [{"port": 8080}, {"port": 8443}],
+					"quoted": "3"
+				}
+			}`,
+		},
+		{
+			name: "expressions inside ManifestWork workload",
+			manifest: map[string]interface{}{
+				"apiVersion": "work.open-cluster-management.io/v1",
+				"kind":       "ManifestWork",
+				"metadata":   map[string]interface{}{"name": "mw-{{ .clusterId }}"},
+				"spec": map[string]interface{}{
+					"workload": map[string]interface{}{
+						"manifests": []interface{}{
+							map[string]interface{}{
+								"apiVersion": "v1",
+								"kind":       "ConfigMap",
+								"metadata":   map[string]interface{}{"name": "cfg"},
+								"data":       expr("{'nodes': string(nodeCount)}"),
+							},
+						},
+					},
+				},
+			},
+			expected: `{
+				"apiVersion": "work.open-cluster-management.io/v1",
+				"kind": "ManifestWork",
+				"metadata": {"name": "mw-abc"},
+				"spec": {"workload": {"manifests": [
+					{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "cfg"}, "data": {"nodes": "3"}}
+				]}}
+			}`,
+		},
+		{
+			name: "evaluation error fails rendering",
+			manifest: map[string]interface{}{
+				"spec": map[string]interface{}{"replicas": expr("missingParam")},
+			},
+			expectError: "missingParam",
+		},
+		{
+			name: "parse error fails rendering",
+			manifest: map[string]interface{}{
+				"spec": map[string]interface{}{"replicas": expr("nodeCount +")},
+			},
+			expectError: "replicas",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resource := config_loader.Resource{Name: "test", Manifest: tt.manifest}
+			data, err := re.renderToBytes(context.Background(), resource, execCtx)
+			if tt.expectError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectError)
+				return
+			}
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.expected, string(data))
+		})
+	}
+}
+
+func TestRenderToBytes_Patches(t *testing.T) {
+	re := &ResourceExecutor{log: logger.NewTestLogger()}
+	execCtx := NewExecutionContext(context.Background(), map[string]interface{}{}, nil)
+	execCtx.Params["platform"] = "aws"
+	execCtx.Params["nodeCount"] = int64(3)
+
+	deployment := func() map[string]interface{} {
+		return map[string]interface{}{
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
+			"metadata":   map[string]interface{}{"name": "app"},
+			"spec": map[string]interface{}{
+				"replicas": 1,
+				"template": map[string]interface{}{"spec": map[string]interface{}{
+					"containers": []interface{}{
+						map[string]interface{}{"name": "app", "image": "app:v1"},
+						map[string]interface{}{"name": "proxy", "image": "proxy:v1"},
+					},
+				}},
+			},
+		}
+	}
+	awsPatch := config_loader.Patch{
+		Name: "aws",
+		Type: "strategic",
+		When: `platform == "aws"`,
+		Patch: map[string]interface{}{
+			"spec": map[string]interface{}{
+				"replicas": map[string]interface{}{config_loader.ManifestExpressionTag: "nodeCount"},
+				"template": map[string]interface{}{"spec": map[string]interface{}{
+					"containers": []interface{}{
+						map[string]interface{}{"name": "app", "image": "app-{{ .platform }}:v1"},
+					},
+				}},
+			},
+		},
+	}
+	gcpPatch := config_loader.Patch{
+		Name:  "gcp",
+		Type:  "json",
+		When:  `platform == "gcp"`,
+		Patch: []interface{}{map[string]interface{}{"op": "remove", "path": "/spec/template"}},
+	}
+
+	tests := []struct {
+		name        string
+		manifest    map[string]interface{}
+		patches     []config_loader.Patch
+		expected    string
+		expectError string
+	}{
+		{
+			name:     "patches gated by when",
+			manifest: deployment(),
+			patches:  []config_loader.Patch{awsPatch, gcpPatch},
+			expected: `{
+				"apiVersion": "apps/v1",
+				"kind": "Deployment",
+				"metadata": {"name": "app"},
+				"spec": {
+					"replicas": 3,
+					"template": {"spec": {"containers": [
+						{"name": "app", "image": "app-aws:v1"},
+						{"name": "proxy", "image": "proxy:v1"}
+					]}}
+				}
+			}`,
+		},
+		{
+			name: "targeted patch inside ManifestWork workload",
+			manifest: map[string]interface{}{
+				"apiVersion": "work.open-cluster-management.io/v1",
+				"kind":       "ManifestWork",
+				"metadata":   map[string]interface{}{"name": "mw"},
+				"spec": map[string]interface{}{"workload": map[string]interface{}{
+					"manifests": []interface{}{deployment()},
+				}},
+			},
+			patches: []config_loader.Patch{{
+				Type:   "merge",
+				Target: &config_loader.PatchTarget{Kind: "Deployment", Name: "app"},
+				Patch:  map[string]interface{}{"spec": map[string]interface{}{"replicas": 5}},
+			}},
+			expected: `{
+				"apiVersion": "work.open-cluster-management.io/v1",
+				"kind": "ManifestWork",
+				"metadata": {"name": "mw"},
+				"spec": {"workload": {"manifests": [{
+					"apiVersion": "apps/v1",
+					"kind": "Deployment",
+					"metadata": {"name": "app"},
+					"spec": {
+						"replicas": 5,
+						"template": {"spec": {"containers": [
+							{"name": "app", "image": "app:v1"},
+							{"name": "proxy", "image": "proxy:v1"}
+						]}}
+					}
+				}]}}
+			}`,
+		},
+		{
+			name:        "non-boolean when fails rendering",
+			manifest:    deployment(),
+			patches:     []config_loader.Patch{{Name: "bad", Type: "merge", When: "platform", Patch: map[string]interface{}{}}},
+			expectError: "must evaluate to a bool",
+		},
+		{
+			name:     "failing patch fails rendering",
+			manifest: deployment(),
+			patches: []config_loader.Patch{{
+				Name:  "broken",
+				Type:  "json",
+				Patch: []interface{}{map[string]interface{}{"op": "remove", "path": "/spec/missing"}},
+			}},
+			expectError: "failed to apply patch broken",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resource := config_loader.Resource{Name: "test", Manifest: tt.manifest, Patches: tt.patches}
+			data, err := re.renderToBytes(context.Background(), resource, execCtx)
+			if tt.expectError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectError)
+				return
+			}
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.expected, string(data))
+		})
+	}
+}
+
+func TestExecuteAll_ManifestFrom(t *testing.T) {
+	chart := &manifest.Chart{
+		Name:   "addon",
+		Values: map[string]interface{}{"replicas": 1},
+		Templates: map[string]string{
+			"templates/cm.yaml": `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: {{ .Release.Name }}
+  namespace: {{ .Release.Namespace }}
+data:
+  replicas: "{{ .Values.replicas }}"
+`,
+			"templates/sa.yaml": `apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: {{ .Release.Name }}
+  namespace: {{ .Release.Namespace }}
+`,
+		},
+	}
+
+	tests := []struct {
+		name         string
+		manifestFrom *config_loader.ManifestFrom
+		expected     map[string]string
+	}{
+		{
+			name: "loaded manifests",
+			manifestFrom: &config_loader.ManifestFrom{
+				Path:   "manifests",
+				Labels: map[string]string{"cluster": "{{ .clusterId }}"},
+				Manifests: []map[string]interface{}{
+					{
+						"apiVersion": "v1",
+						"kind":       "ConfigMap",
+						"metadata":   map[string]interface{}{"name": "cm-{{ .clusterId }}", "namespace": "ns"},
+					},
+					{
+						"apiVersion": "v1",
+						"kind":       "Secret",
+						"metadata":   map[string]interface{}{"name": "secret-{{ .clusterId }}", "namespace": "ns"},
+					},
+				},
+			},
+			expected: map[string]string{"ConfigMap": "cm-abc", "Secret": "secret-abc"},
+		},
+		{
+			name: "local chart",
+			manifestFrom: &config_loader.ManifestFrom{
+				Labels: map[string]string{"cluster": "{{ .clusterId }}"},
+				Chart: &config_loader.ChartSource{
+					Path:        "charts/addon",
+					ReleaseName: "addon-{{ .clusterId }}",
+					Namespace:   "ns",
+					Values:      map[string]interface{}{"replicas": map[string]interface{}{config_loader.ManifestExpressionTag: "nodeCount"}},
+				},
+				ChartContent: chart,
+			},
+			expected: map[string]string{"ConfigMap": "addon-abc", "ServiceAccount": "addon-abc"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := k8s_client.NewMockK8sClient()
+			re := &ResourceExecutor{client: client, log: logger.NewTestLogger()}
+			execCtx := NewExecutionContext(context.Background(), map[string]interface{}{}, nil)
+			execCtx.Params["clusterId"] = "abc"
+			execCtx.Params["nodeCount"] = int64(3)
+
+			results, err := re.ExecuteAll(context.Background(), []config_loader.Resource{
+				{Name: "addon", ManifestFrom: tt.manifestFrom},
+			}, execCtx)
+			require.NoError(t, err)
+			require.Len(t, results, len(tt.expected), "one result per produced object")
+
+			discovered, ok := execCtx.Resources["addon"].(map[string]*unstructured.Unstructured)
+			require.True(t, ok)
+			for _, result := range results {
+				assert.Equal(t, "addon", result.Name)
+				assert.Equal(t, StatusSuccess, result.Status)
+				assert.Equal(t, tt.expected[result.Kind], result.ResourceName)
+				assert.Equal(t, "ns", result.Namespace)
+
+				obj := discovered[result.Kind+"/"+result.ResourceName]
+				require.NotNil(t, obj, "applied object should be discovered")
+				assert.Equal(t, "abc", obj.GetLabels()["cluster"])
+			}
+		})
+	}
+
+	t.Run("apply failure stops execution", func(t *testing.T) {
+		client := k8s_client.NewMockK8sClient()
+		client.ApplyResourceError = errors.New("apply failed")
+		re := &ResourceExecutor{client: client, log: logger.NewTestLogger()}
+		execCtx := NewExecutionContext(context.Background(), map[string]interface{}{}, nil)
+		execCtx.Params["clusterId"] = "abc"
+
+		results, err := re.ExecuteAll(context.Background(), []config_loader.Resource{
+			{Name: "addon", ManifestFrom: tests[0].manifestFrom},
+		}, execCtx)
+		require.Error(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, StatusFailed, results[0].Status)
+		assert.Equal(t, "ConfigMap", results[0].Kind)
+		require.NotNil(t, execCtx.Adapter.ExecutionError)
+	})
+
+	t.Run("transport client failure is recorded", func(t *testing.T) {
+		re := &ResourceExecutor{log: logger.NewTestLogger()}
+		execCtx := NewExecutionContext(context.Background(), map[string]interface{}{}, nil)
+		execCtx.Params["clusterId"] = "abc"
+
+		results, err := re.ExecuteAll(context.Background(), []config_loader.Resource{
+			{Name: "addon", ManifestFrom: tests[0].manifestFrom},
+		}, execCtx)
+		require.Error(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, StatusFailed, results[0].Status)
+		require.NotNil(t, execCtx.Adapter.ExecutionError)
+		assert.Equal(t, "addon", execCtx.Adapter.ExecutionError.Step)
+	})
+}
+
+func TestExecuteAll_DriftCorrected(t *testing.T) {
+	client := k8s_client.NewMockK8sClient()
+	client.ApplyResourceResult = &k8s_client.ApplyResult{
+		Operation: manifest.OperationCorrectDrift,
+		Reason:    "generation 1 unchanged, live object drifted from desired spec",
+	}
+	re := &ResourceExecutor{client: client, log: logger.NewTestLogger()}
+	execCtx := NewExecutionContext(context.Background(), map[string]interface{}{}, nil)
+
+	resource := config_loader.Resource{
+		Name:           "configMap",
+		DriftDetection: true,
+		Manifest: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata":   map[string]interface{}{"name": "cfg", "namespace": "ns"},
+		},
+	}
+	results, err := re.ExecuteAll(context.Background(), []config_loader.Resource{resource, resource}, execCtx)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, manifest.OperationCorrectDrift, results[0].Operation)
+	assert.Equal(t, []string{"configMap"}, execCtx.Adapter.DriftCorrected, "each resource is recorded once")
+
+	adapter := execCtx.GetCELVariables()["adapter"].(map[string]interface{})
+	assert.Equal(t, []interface{}{"configMap"}, adapter["driftCorrected"])
+}
+
+func TestExecuteAll_TransportRegistry(t *testing.T) {
+	namespace := config_loader.Resource{
+		Name: "namespace",
+		Manifest: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "Namespace",
+			"metadata":   map[string]interface{}{"name": "cluster-abc"},
+		},
+	}
+	work := config_loader.Resource{
+		Name: "work",
+		Transport: &config_loader.TransportConfig{
+			Client:  config_loader.TransportClientMaestro,
+			Maestro: &config_loader.MaestroTransportConfig{TargetCluster: "cluster-abc"},
+		},
+		Manifest: map[string]interface{}{
+			"apiVersion": "work.open-cluster-management.io/v1",
+			"kind":       "ManifestWork",
+			"metadata":   map[string]interface{}{"name": "work-abc", "namespace": "cluster-abc"},
+		},
+	}
+
+	t.Run("dispatches each resource to its transport client", func(t *testing.T) {
+		k8sClient := k8s_client.NewMockK8sClient()
+		maestroClient := k8s_client.NewMockK8sClient()
+		re := newResourceExecutor(&ExecutorConfig{
+			TransportClients: map[string]transport_client.TransportClient{
+				config_loader.TransportClientKubernetes: k8sClient,
+				config_loader.TransportClientMaestro:    maestroClient,
+			},
+			Logger: logger.NewTestLogger(),
+		})
+		execCtx := NewExecutionContext(context.Background(), map[string]interface{}{}, nil)
+
+		results, err := re.ExecuteAll(context.Background(), []config_loader.Resource{namespace, work}, execCtx)
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		assert.Contains(t, k8sClient.Resources, "/cluster-abc")
+		assert.NotContains(t, k8sClient.Resources, "cluster-abc/work-abc")
+		assert.Contains(t, maestroClient.Resources, "cluster-abc/work-abc")
+		assert.NotContains(t, maestroClient.Resources, "/cluster-abc")
+	})
+
+	t.Run("unregistered transport fails the resource", func(t *testing.T) {
+		re := newResourceExecutor(&ExecutorConfig{
+			TransportClients: map[string]transport_client.TransportClient{
+				config_loader.TransportClientKubernetes: k8s_client.NewMockK8sClient(),
+			},
+			Logger: logger.NewTestLogger(),
+		})
+		execCtx := NewExecutionContext(context.Background(), map[string]interface{}{}, nil)
+
+		results, err := re.ExecuteAll(context.Background(), []config_loader.Resource{namespace, work}, execCtx)
+		require.Error(t, err)
+		require.Len(t, results, 2)
+		assert.Equal(t, StatusSuccess, results[0].Status)
+		assert.Equal(t, StatusFailed, results[1].Status)
+		assert.Contains(t, results[1].Error.Error(), "transport client not configured for maestro")
+	})
+}
+
+func TestExecuteAll_ManifestWorkFeedback(t *testing.T) {
+	work := config_loader.Resource{
+		Name: "work",
+		Transport: &config_loader.TransportConfig{
+			Client:  config_loader.TransportClientMaestro,
+			Maestro: &config_loader.MaestroTransportConfig{TargetCluster: "cluster-abc"},
+		},
+		Manifest: map[string]interface{}{
+			"apiVersion": "work.open-cluster-management.io/v1",
+			"kind":       "ManifestWork",
+			"metadata":   map[string]interface{}{"name": "work-abc", "namespace": "cluster-abc"},
+			"spec": map[string]interface{}{"workload": map[string]interface{}{"manifests": []interface{}{
+				map[string]interface{}{
+					"apiVersion": "apps/v1",
+					"kind":       "Deployment",
+					"metadata":   map[string]interface{}{"name": "web", "namespace": "apps"},
+				},
+			}}},
+		},
+		Discovery: &config_loader.DiscoveryConfig{Namespace: "cluster-abc", ByName: "work-abc"},
+		NestedDiscoveries: []config_loader.NestedDiscovery{{
+			Name:      "deployment",
+			Discovery: &config_loader.DiscoveryConfig{Namespace: "apps", ByName: "web"},
+			Feedback: &config_loader.FeedbackConfig{
+				JSONPaths: []config_loader.FeedbackJSONPath{{Name: "readyReplicas", Path: ".status.readyReplicas"}},
+			},
+		}},
+	}
+
+	maestroClient := k8s_client.NewMockK8sClient()
+	re := newResourceExecutor(&ExecutorConfig{TransportClient: maestroClient, Logger: logger.NewTestLogger()})
+	execCtx := NewExecutionContext(context.Background(), map[string]interface{}{}, nil)
+
+	_, err := re.ExecuteAll(context.Background(), []config_loader.Resource{work}, execCtx)
+	require.NoError(t, err)
+
+	// The feedback rules are requested in the applied ManifestWork
+	applied := maestroClient.Resources["cluster-abc/work-abc"]
+	require.NotNil(t, applied)
+	configs, found, err := unstructured.NestedSlice(applied.Object, "spec", "manifestConfigs")
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Len(t, configs, 1)
+	assert.Equal(t, map[string]interface{}{
+		"group": "apps", "resource": "deployments", "namespace": "apps", "name": "web",
+	}, configs[0].(map[string]interface{})["resourceIdentifier"])
+
+	// The reported status is mapped onto the nested manifest
+	applied.Object["status"] = map[string]interface{}{"resourceStatus": map[string]interface{}{"manifests": []interface{}{
+		map[string]interface{}{
+			"resourceMeta": map[string]interface{}{"group": "apps", "kind": "Deployment", "namespace": "apps", "name": "web"},
+			"statusFeedback": map[string]interface{}{"values": []interface{}{
+				map[string]interface{}{"name": "readyReplicas", "fieldValue": map[string]interface{}{"type": "Integer", "integer": int64(3)}},
+			}},
+			"conditions": []interface{}{map[string]interface{}{"type": "Available", "status": "True"}},
+		},
+	}}}
+	nested := re.discoverNestedResources(context.Background(), work, execCtx, applied)
+	require.Contains(t, nested, "deployment")
+	deployment := GetResourceAsMap(nested["deployment"])
+	assert.Equal(t, int64(3), deployment["status"].(map[string]interface{})["readyReplicas"])
+	assert.Equal(t, true, deployment[manifest.ManifestStatusField].(map[string]interface{})["available"])
+}
+
+// removingClient adds transport_client.ResourceRemover to the mock client
+type removingClient struct {
+	*k8s_client.MockK8sClient
+	opts *transport_client.DeleteOptions
+}
+
+func (c *removingClient) RemoveResource(ctx context.Context, gvk schema.GroupVersionKind, namespace, name string,
+	opts *transport_client.DeleteOptions, _ transport_client.TransportContext) error {
+	c.opts = opts
+	return c.DeleteResource(ctx, gvk, namespace, name)
+}
+
+func TestExecuteAll_ManifestWorkDelete(t *testing.T) {
+	work := config_loader.Resource{
+		Name: "work",
+		Transport: &config_loader.TransportConfig{
+			Client: config_loader.TransportClientMaestro,
+			Maestro: &config_loader.MaestroTransportConfig{
+				TargetCluster: "cluster-abc",
+				DeleteOption:  &config_loader.MaestroDeleteOption{PropagationPolicy: config_loader.DeletePropagationSelectivelyOrphan},
+			},
+		},
+		Manifest: map[string]interface{}{
+			"apiVersion": "work.open-cluster-management.io/v1",
+			"kind":       "ManifestWork",
+			"metadata":   map[string]interface{}{"name": "work-abc", "namespace": "cluster-abc"},
+			"spec": map[string]interface{}{"workload": map[string]interface{}{"manifests": []interface{}{
+				map[string]interface{}{
+					"apiVersion": "v1",
+					"kind":       "Namespace",
+					"metadata":   map[string]interface{}{"name": "apps"},
+				},
+			}}},
+		},
+		Discovery: &config_loader.DiscoveryConfig{Namespace: "cluster-abc", ByName: "work-abc"},
+		NestedDiscoveries: []config_loader.NestedDiscovery{{
+			Name:      "namespace",
+			Discovery: &config_loader.DiscoveryConfig{ByName: "apps"},
+			Orphan:    true,
+		}},
+		Delete: &config_loader.DeleteConfig{When: "deleting", Wait: true, Timeout: "1m"},
+	}
+
+	client := &removingClient{MockK8sClient: k8s_client.NewMockK8sClient()}
+	re := newResourceExecutor(&ExecutorConfig{TransportClient: client, Logger: logger.NewTestLogger()})
+
+	// Applied with the orphaning rules while the delete condition does not hold
+	execCtx := NewExecutionContext(context.Background(), map[string]interface{}{}, nil)
+	execCtx.Params["deleting"] = false
+	results, err := re.ExecuteAll(context.Background(), []config_loader.Resource{work}, execCtx)
+	require.NoError(t, err)
+	assert.Equal(t, manifest.OperationCreate, results[0].Operation)
+
+	applied := client.Resources["cluster-abc/work-abc"]
+	require.NotNil(t, applied)
+	policy, _, _ := unstructured.NestedString(applied.Object, "spec", "deleteOption", "propagationPolicy")
+	assert.Equal(t, "SelectivelyOrphan", policy)
+	rules, _, _ := unstructured.NestedSlice(applied.Object, "spec", "deleteOption", "selectivelyOrphans", "orphaningRules")
+	assert.Equal(t, []interface{}{map[string]interface{}{
+		"group": "", "resource": "namespaces", "namespace": "", "name": "apps",
+	}}, rules)
+	assert.Contains(t, execCtx.Resources, "work")
+
+	// Deleted and waited for once the condition holds
+	execCtx.Params["deleting"] = true
+	results, err = re.ExecuteAll(context.Background(), []config_loader.Resource{work}, execCtx)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, manifest.OperationDelete, results[0].Operation)
+	assert.Equal(t, "work-abc", results[0].ResourceName)
+	assert.Equal(t, &transport_client.DeleteOptions{Wait: true, Timeout: time.Minute}, client.opts)
+	assert.NotContains(t, client.Resources, "cluster-abc/work-abc")
+	assert.NotContains(t, execCtx.Resources, "work")
+
+	// Transports without delete support fail
+	re = newResourceExecutor(&ExecutorConfig{TransportClient: k8s_client.NewMockK8sClient(), Logger: logger.NewTestLogger()})
+	results, err = re.ExecuteAll(context.Background(), []config_loader.Resource{work}, execCtx)
+	require.Error(t, err)
+	assert.Equal(t, StatusFailed, results[0].Status)
+	require.NotNil(t, execCtx.Adapter.ExecutionError, "delete failures are reported like apply failures")
+	assert.Equal(t, "work", execCtx.Adapter.ExecutionError.Step)
+	assert.Equal(t, string(PhaseResources), execCtx.Adapter.ExecutionError.Phase)
+}
+
+func TestBuildTransportTarget(t *testing.T) {
+	execCtx := NewExecutionContext(context.Background(), map[string]interface{}{}, nil)
+	execCtx.Params["clusterId"] = "abc"
+
+	target, err := buildTransportTarget(config_loader.Resource{Name: "local"}, execCtx)
+	require.NoError(t, err)
+	assert.Nil(t, target)
+
+	target, err = buildTransportTarget(config_loader.Resource{
+		Name: "remote",
+		Transport: &config_loader.TransportConfig{
+			Client:     config_loader.TransportClientKubernetes,
+			Kubernetes: &config_loader.KubernetesTransportConfig{Cluster: "{{ .clusterId }}"},
+		},
+	}, execCtx)
+	require.NoError(t, err)
+	assert.Equal(t, &k8s_client.TransportContext{Cluster: "abc"}, target)
+
+	execCtx.Params["emptyCluster"] = ""
+	_, err = buildTransportTarget(config_loader.Resource{
+		Name: "remote",
+		Transport: &config_loader.TransportConfig{
+			Client:     config_loader.TransportClientKubernetes,
+			Kubernetes: &config_loader.KubernetesTransportConfig{Cluster: "{{ .emptyCluster }}"},
+		},
+	}, execCtx)
+	require.Error(t, err, "an empty cluster must not fall back to the adapter's own cluster")
+	assert.Contains(t, err.Error(), "rendered to an empty cluster name")
+
+	target, err = buildTransportTarget(config_loader.Resource{
+		Name: "work",
+		Transport: &config_loader.TransportConfig{
+			Client:  config_loader.TransportClientMaestro,
+			Maestro: &config_loader.MaestroTransportConfig{TargetCluster: "{{ .clusterId }}"},
+		},
+	}, execCtx)
+	require.NoError(t, err)
+	assert.Equal(t, &maestro_client.TransportContext{ConsumerName: "abc"}, target)
+}
+
+func TestBuildApplyOptions(t *testing.T) {
+	assert.Nil(t, buildApplyOptions(config_loader.Resource{}))
+	assert.Equal(t, &transport_client.ApplyOptions{RecreateOnChange: true},
+		buildApplyOptions(config_loader.Resource{RecreateOnChange: true}))
+	assert.Equal(t, &transport_client.ApplyOptions{DriftDetection: true},
+		buildApplyOptions(config_loader.Resource{DriftDetection: true}))
+}
@@ -0,0 +1,242 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/cloudevents/sdk-go/v2/event"
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/config_loader"
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/criteria"
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/pkg/logger"
+	pkgotel "github.com/openshift-hyperfleet/hyperfleet-adapter/pkg/otel"
+)
+
+// Variables available to task match expressions
+const (
+	matchVarEvent     = "event"
+	matchVarEventType = "eventType"
+)
+
+// Router dispatches events to the executors of several task configs.
+// Each event is executed, in order, by every executor whose task config matches it
+// (see config_loader.TaskMatch).
+type Router struct {
+	executors []*Executor
+	log       logger.Logger
+}
+
+// NewRouter creates a Router over the given executors.
+// The executors share one key locker so events for the same resource stay serialized
+// across task configs.
+func NewRouter(log logger.Logger, executors ...*Executor) (*Router, error) {
+	if log == nil {
+		return nil, fmt.Errorf("logger is required")
+	}
+	if len(executors) == 0 {
+		return nil, fmt.Errorf("at least one executor is required")
+	}
+
+	keyLocks := executors[0].keyLocks
+	for _, exec := range executors[1:] {
+		exec.keyLocks = keyLocks
+	}
+	return &Router{executors: executors, log: log}, nil
+}
+
+// Execute runs the event data through every matching executor.
+// Used for synthesized events (e.g. resync) that have no CloudEvent type.
+// Returns the first failed result, otherwise the last result. When no task config
+// matches, the returned result is skipped with reason "NoMatchingTask".
+func (r *Router) Execute(ctx context.Context, data interface{}) *ExecutionResult {
+	return r.route(ctx, "", data)
+}
+
+// CreateHandler creates an event handler routing CloudEvents to the matching executors.
+// Like Executor.CreateHandler, failures are logged and the message is ACKed.
+func (r *Router) CreateHandler() func(ctx context.Context, evt *event.Event) error {
+	return func(ctx context.Context, evt *event.Event) error {
+		// Add event ID to context for logging correlation
+		ctx = logger.WithEventID(ctx, evt.ID())
+
+		// Extract W3C trace context from CloudEvent extensions (if present)
+		// This enables distributed tracing when upstream services (e.g., Sentinel)
+		// include traceparent/tracestate in the CloudEvent
+		ctx = pkgotel.ExtractTraceContextFromCloudEvent(ctx, evt)
+
+		// Log event metadata
+		r.log.Infof(ctx, "Event received: id=%s type=%s source=%s time=%s",
+			evt.ID(), evt.Type(), evt.Source(), evt.Time())
+
+		_ = r.route(ctx, evt.Type(), evt.Data())
+
+		r.log.Infof(ctx, "Event processed: type=%s source=%s time=%s",
+			evt.Type(), evt.Source(), evt.Time())
+
+		return nil
+	}
+}
+
+// Reload replaces the task configs of the executors, matched by task name.
+// The reloaded configs must cover exactly the current task configs: adding or removing
+// a task config requires a restart. So does changing deployment-level settings (metadata,
+// adapter, clients, resync) or using a transport client that was not created at startup;
+// such reloads are rejected. All configs are validated before any is applied, so nothing
+// is replaced when validation fails.
+// The CEL programs of the reloaded configs, compiled with their CEL limits, replace the
+// programs of the current configs together with the configs.
+// Events already executing keep the config they started with.
+func (r *Router) Reload(configs []*config_loader.Config) error {
+	if len(configs) != len(r.executors) {
+		return fmt.Errorf("reloaded configs define %d task configs, expected %d (adding or removing task configs requires a restart)",
+			len(configs), len(r.executors))
+	}
+
+	byTask := make(map[string]*config_loader.Config, len(configs))
+	for _, config := range configs {
+		if config == nil {
+			return fmt.Errorf("reloaded configs contain a nil config")
+		}
+		byTask[config.GetTaskName()] = config
+	}
+	for _, exec := range r.executors {
+		taskName := exec.Config().GetTaskName()
+		config := byTask[taskName]
+		if config == nil {
+			return fmt.Errorf("task config %q is missing from the reloaded configs (adding or removing task configs requires a restart)", taskName)
+		}
+		if err := exec.checkReload(config); err != nil {
+			return fmt.Errorf("task config %q cannot be reloaded: %w", taskName, err)
+		}
+	}
+
+	// The configs of a set share their CEL programs, compiled with the set's limits
+	programs, err := configs[0].CELPrograms()
+	if err != nil {
+		return fmt.Errorf("failed to create CEL program cache: %w", err)
+	}
+
+	criteria.UsePrograms(programs)
+	for _, exec := range r.executors {
+		exec.current.Store(byTask[exec.Config().GetTaskName()])
+	}
+	return nil
+}
+
+// checkReload reports why a reloaded config cannot replace the executor's config without a
+// restart: a changed deployment-level setting, or a resource whose transport client was not
+// created at startup
+func (e *Executor) checkReload(config *config_loader.Config) error {
+	current := e.Config()
+	deploymentSettings := []struct {
+		name             string
+		current, updated interface{}
+	}{
+		{"metadata", current.Metadata, config.Metadata},
+		{"spec.adapter", current.Spec.Adapter, config.Spec.Adapter},
+		{"spec.clients", current.Spec.Clients, config.Spec.Clients},
+		{"spec.resync", current.Spec.Resync, config.Spec.Resync},
+		{"spec.debugConfig", current.Spec.DebugConfig, config.Spec.DebugConfig},
+	}
+	for _, setting := range deploymentSettings {
+		if !reflect.DeepEqual(setting.current, setting.updated) {
+			return fmt.Errorf("%s changed (changing deployment settings requires a restart)", setting.name)
+		}
+	}
+
+	for _, client := range []string{config_loader.TransportClientKubernetes, config_loader.TransportClientMaestro} {
+		if _, ok := e.config.TransportClients[client]; ok || e.config.TransportClient != nil {
+			continue
+		}
+		if config.UsesTransportClient(client) {
+			return fmt.Errorf("resources use the %s transport client, which was not created at startup (requires a restart)", client)
+		}
+	}
+	if config.Spec.Clients.Kubernetes.ClusterKubeconfig == nil && config.UsesClusterTargets() {
+		return fmt.Errorf("resources target remote clusters but spec.clients.kubernetes.clusterKubeconfig is not set")
+	}
+	return nil
+}
+
+// route executes the event with every matching executor
+func (r *Router) route(ctx context.Context, eventType string, data interface{}) *ExecutionResult {
+	var result *ExecutionResult
+	for _, exec := range r.executors {
+		matched, err := exec.Matches(ctx, eventType, data)
+		if err != nil {
+			errCtx := logger.WithErrorField(logger.WithTask(ctx, exec.Config().GetTaskName()), err)
+			r.log.Warnf(errCtx, "Failed to evaluate task match, skipping task")
+			continue
+		}
+		if !matched {
+			continue
+		}
+
+		execResult := exec.Execute(ctx, data)
+		if result == nil || result.Status != StatusFailed {
+			result = execResult
+		}
+	}
+
+	if result == nil {
+		r.log.Infof(ctx, "Event skipped: no task config matches type=%q", eventType)
+		return &ExecutionResult{Status: StatusSuccess, ResourcesSkipped: true, SkipReason: "NoMatchingTask"}
+	}
+	return result
+}
+
+// Matches reports whether the event is handled by the executor's task config.
+// A task config without match handles every event. The eventTypes criterion is ignored
+// when eventType is empty (synthesized events). A match expression that fails to
+// evaluate is returned as an error.
+func (e *Executor) Matches(ctx context.Context, eventType string, data interface{}) (bool, error) {
+	match := e.Config().Spec.Match
+	if match == nil {
+		return true, nil
+	}
+
+	if eventType != "" && len(match.EventTypes) > 0 && !slices.Contains(match.EventTypes, eventType) {
+		return false, nil
+	}
+	if len(match.Kinds) == 0 && match.Expression == "" {
+		return true, nil
+	}
+
+	eventData, rawData, err := ParseEventData(data)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse event data: %w", err)
+	}
+
+	if len(match.Kinds) > 0 && !slices.ContainsFunc(match.Kinds, func(kind string) bool {
+		return strings.EqualFold(kind, eventData.Kind)
+	}) {
+		return false, nil
+	}
+
+	if match.Expression == "" {
+		return true, nil
+	}
+
+	evalCtx := criteria.NewEvaluationContext()
+	evalCtx.Set(matchVarEvent, rawData)
+	evalCtx.Set(matchVarEventType, eventType)
+	evaluator, err := criteria.NewEvaluator(ctx, evalCtx, e.log)
+	if err != nil {
+		return false, fmt.Errorf("failed to create evaluator: %w", err)
+	}
+
+	result, err := evaluator.EvaluateCEL(match.Expression)
+	if err != nil {
+		return false, fmt.Errorf("match expression %q failed: %w", match.Expression, err)
+	}
+	if result.Error != nil {
+		return false, fmt.Errorf("match expression %q failed: %w", match.Expression, result.Error)
+	}
+	matched, ok := result.Value.(bool)
+	if !ok {
+		return false, fmt.Errorf("match expression %q must evaluate to a bool, got %T", match.Expression, result.Value)
+	}
+	return matched, nil
+}
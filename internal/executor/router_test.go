@@ -0,0 +1,351 @@
+package executor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cloudevents/sdk-go/v2/event"
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/config_loader"
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/criteria"
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/hyperfleet_api"
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/k8s_client"
+	apperrors "github.com/openshift-hyperfleet/hyperfleet-adapter/pkg/errors"
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTaskExecutor builds an executor for a task config whose only precondition calls
+// GET /<task name>, so the mock API client records which tasks ran.
+func newTaskExecutor(t *testing.T, apiClient hyperfleet_api.Client, taskName string, match *config_loader.TaskMatch) *Executor {
+	t.Helper()
+
+	exec, err := NewBuilder().
+		WithConfig(&config_loader.Config{
+			Metadata:     config_loader.Metadata{Name: "test-adapter"},
+			TaskMetadata: config_loader.Metadata{Name: taskName},
+			Spec: config_loader.ConfigSpec{
+				Match: match,
+				Preconditions: []config_loader.Precondition{
+					{ActionBase: config_loader.ActionBase{
+						Name:    taskName,
+						APICall: &config_loader.APICall{Method: "GET", URL: "/" + taskName},
+					}},
+				},
+			},
+		}).
+		WithAPIClient(apiClient).
+		WithTransportClient(k8s_client.NewMockK8sClient()).
+		WithLogger(logger.NewTestLogger()).
+		Build()
+	require.NoError(t, err)
+	return exec
+}
+
+func requestedTasks(apiClient *hyperfleet_api.MockClient) []string {
+	var tasks []string
+	for _, req := range apiClient.Requests {
+		tasks = append(tasks, req.URL[1:])
+	}
+	return tasks
+}
+
+func TestExecutorMatches(t *testing.T) {
+	clusterEvent := map[string]interface{}{"id": "c1", "kind": "Cluster", "generation": 1}
+
+	tests := []struct {
+		name        string
+		match       *config_loader.TaskMatch
+		eventType   string
+		data        interface{}
+		expected    bool
+		expectError bool
+	}{
+		{
+			name:      "no match block handles every event",
+			eventType: "cluster.created",
+			data:      clusterEvent,
+			expected:  true,
+		},
+		{
+			name:      "event type matches",
+			match:     &config_loader.TaskMatch{EventTypes: []string{"cluster.deleted", "cluster.created"}},
+			eventType: "cluster.created",
+			data:      clusterEvent,
+			expected:  true,
+		},
+		{
+			name:      "event type does not match",
+			match:     &config_loader.TaskMatch{EventTypes: []string{"cluster.deleted"}},
+			eventType: "cluster.created",
+			data:      clusterEvent,
+			expected:  false,
+		},
+		{
+			name:      "event types are ignored for synthesized events",
+			match:     &config_loader.TaskMatch{EventTypes: []string{"cluster.deleted"}},
+			eventType: "",
+			data:      clusterEvent,
+			expected:  true,
+		},
+		{
+			name:      "kind matches case-insensitively",
+			match:     &config_loader.TaskMatch{Kinds: []string{"cluster"}},
+			eventType: "cluster.created",
+			data:      clusterEvent,
+			expected:  true,
+		},
+		{
+			name:      "kind does not match",
+			match:     &config_loader.TaskMatch{Kinds: []string{"NodePool"}},
+			eventType: "cluster.created",
+			data:      clusterEvent,
+			expected:  false,
+		},
+		{
+			name:      "expression over event data and type",
+			match:     &config_loader.TaskMatch{Expression: `event.generation == 1 && eventType.endsWith(".created")`},
+			eventType: "cluster.created",
+			data:      []byte(`{"id": "c1", "kind": "Cluster", "generation": 1}`),
+			expected:  true,
+		},
+		{
+			name:      "expression false",
+			match:     &config_loader.TaskMatch{Expression: `event.generation > 1`},
+			eventType: "cluster.created",
+			data:      clusterEvent,
+			expected:  false,
+		},
+		{
+			name:        "expression on missing field is an error",
+			match:       &config_loader.TaskMatch{Expression: `event.owned_reference.id == "c1"`},
+			eventType:   "cluster.created",
+			data:        clusterEvent,
+			expectError: true,
+		},
+		{
+			name:        "non-bool expression is an error",
+			match:       &config_loader.TaskMatch{Expression: `event.kind`},
+			eventType:   "cluster.created",
+			data:        clusterEvent,
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exec := newTaskExecutor(t, newMockAPIClient(), "task", tt.match)
+			matched, err := exec.Matches(context.Background(), tt.eventType, tt.data)
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, matched)
+		})
+	}
+}
+
+func TestRouter(t *testing.T) {
+	newRouter := func(t *testing.T) (*Router, *hyperfleet_api.MockClient) {
+		apiClient := newMockAPIClient()
+		router, err := NewRouter(logger.NewTestLogger(),
+			newTaskExecutor(t, apiClient, "clusterCreated", &config_loader.TaskMatch{
+				EventTypes: []string{"cluster.created"}, Kinds: []string{"Cluster"},
+			}),
+			newTaskExecutor(t, apiClient, "nodepoolCreated", &config_loader.TaskMatch{
+				EventTypes: []string{"nodepool.created"}, Kinds: []string{"NodePool"},
+			}),
+			newTaskExecutor(t, apiClient, "audit", nil),
+		)
+		require.NoError(t, err)
+		return router, apiClient
+	}
+
+	newEvent := func(t *testing.T, eventType, data string) *event.Event {
+		evt := event.New()
+		evt.SetID("evt-1")
+		evt.SetType(eventType)
+		evt.SetSource("test")
+		require.NoError(t, evt.SetData(event.ApplicationJSON, []byte(data)))
+		return &evt
+	}
+
+	tests := []struct {
+		name          string
+		eventType     string
+		data          string
+		expectedTasks []string
+	}{
+		{
+			name:          "cluster event",
+			eventType:     "cluster.created",
+			data:          `{"id": "c1", "kind": "Cluster"}`,
+			expectedTasks: []string{"clusterCreated", "audit"},
+		},
+		{
+			name:          "nodepool event",
+			eventType:     "nodepool.created",
+			data:          `{"id": "np1", "kind": "NodePool", "owned_reference": {"id": "c1", "kind": "Cluster"}}`,
+			expectedTasks: []string{"nodepoolCreated", "audit"},
+		},
+		{
+			name:          "event matching only the catch-all task",
+			eventType:     "cluster.deleted",
+			data:          `{"id": "c1", "kind": "Cluster"}`,
+			expectedTasks: []string{"audit"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, apiClient := newRouter(t)
+			err := router.CreateHandler()(context.Background(), newEvent(t, tt.eventType, tt.data))
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedTasks, requestedTasks(apiClient))
+		})
+	}
+
+	t.Run("synthesized events ignore event types", func(t *testing.T) {
+		router, apiClient := newRouter(t)
+		result := router.Execute(context.Background(), map[string]interface{}{"id": "c1", "kind": "Cluster"})
+		require.NotNil(t, result)
+		assert.Equal(t, []string{"clusterCreated", "audit"}, requestedTasks(apiClient))
+	})
+
+	t.Run("no matching task", func(t *testing.T) {
+		apiClient := newMockAPIClient()
+		router, err := NewRouter(logger.NewTestLogger(),
+			newTaskExecutor(t, apiClient, "nodepoolOnly", &config_loader.TaskMatch{Kinds: []string{"NodePool"}}))
+		require.NoError(t, err)
+
+		result := router.Execute(context.Background(), map[string]interface{}{"id": "c1", "kind": "Cluster"})
+		assert.Equal(t, StatusSuccess, result.Status)
+		assert.True(t, result.ResourcesSkipped)
+		assert.Equal(t, "NoMatchingTask", result.SkipReason)
+		assert.Empty(t, apiClient.Requests)
+	})
+
+	t.Run("executors share the key locker", func(t *testing.T) {
+		router, _ := newRouter(t)
+		for _, exec := range router.executors[1:] {
+			assert.Same(t, router.executors[0].keyLocks, exec.keyLocks)
+		}
+	})
+
+	t.Run("requires executors", func(t *testing.T) {
+		_, err := NewRouter(logger.NewTestLogger())
+		assert.Error(t, err)
+	})
+}
+
+func TestRouterReload(t *testing.T) {
+	newConfig := func(taskName string, match *config_loader.TaskMatch) *config_loader.Config {
+		return &config_loader.Config{
+			Metadata:     config_loader.Metadata{Name: "test-adapter"},
+			TaskMetadata: config_loader.Metadata{Name: taskName},
+			Spec:         config_loader.ConfigSpec{Match: match},
+		}
+	}
+	newRouter := func(t *testing.T) *Router {
+		apiClient := newMockAPIClient()
+		router, err := NewRouter(logger.NewTestLogger(),
+			newTaskExecutor(t, apiClient, "clusters", nil),
+			newTaskExecutor(t, apiClient, "nodepools", nil),
+		)
+		require.NoError(t, err)
+		return router
+	}
+
+	t.Run("replaces configs by task name", func(t *testing.T) {
+		router := newRouter(t)
+		nodepools := newConfig("nodepools", &config_loader.TaskMatch{Kinds: []string{"NodePool"}})
+		clusters := newConfig("clusters", &config_loader.TaskMatch{Kinds: []string{"Cluster"}})
+
+		require.NoError(t, router.Reload([]*config_loader.Config{nodepools, clusters}))
+		assert.Same(t, clusters, router.executors[0].Config())
+		assert.Same(t, nodepools, router.executors[1].Config())
+	})
+
+	t.Run("rejects added or removed task configs", func(t *testing.T) {
+		router := newRouter(t)
+		original := router.executors[0].Config()
+
+		err := router.Reload([]*config_loader.Config{newConfig("clusters", nil)})
+		assert.Error(t, err)
+		err = router.Reload([]*config_loader.Config{newConfig("clusters", nil), newConfig("other", nil)})
+		assert.Error(t, err)
+		assert.Same(t, original, router.executors[0].Config())
+	})
+
+	t.Run("rejects changed deployment settings", func(t *testing.T) {
+		router := newRouter(t)
+		original := router.executors[0].Config()
+
+		nodepools := newConfig("nodepools", nil)
+		nodepools.Spec.Clients.HyperfleetAPI.RetryAttempts = 5
+		err := router.Reload([]*config_loader.Config{newConfig("clusters", nil), nodepools})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "spec.clients")
+		assert.Same(t, original, router.executors[0].Config(), "no executor is swapped when any config is rejected")
+	})
+
+	t.Run("rejects transport clients not created at startup", func(t *testing.T) {
+		exec, err := NewBuilder().
+			WithConfig(newConfig("clusters", nil)).
+			WithAPIClient(newMockAPIClient()).
+			WithNamedTransportClient(config_loader.TransportClientKubernetes, k8s_client.NewMockK8sClient()).
+			WithLogger(logger.NewTestLogger()).
+			Build()
+		require.NoError(t, err)
+		router, err := NewRouter(logger.NewTestLogger(), exec)
+		require.NoError(t, err)
+
+		withMaestro := newConfig("clusters", nil)
+		withMaestro.Spec.Resources = []config_loader.Resource{{
+			Name:      "work",
+			Transport: &config_loader.TransportConfig{Client: config_loader.TransportClientMaestro},
+		}}
+		err = router.Reload([]*config_loader.Config{withMaestro})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "maestro")
+
+		withCluster := newConfig("clusters", nil)
+		withCluster.Spec.Resources = []config_loader.Resource{{
+			Name: "remote",
+			Transport: &config_loader.TransportConfig{
+				Client:     config_loader.TransportClientKubernetes,
+				Kubernetes: &config_loader.KubernetesTransportConfig{Cluster: "{{ .clusterName }}"},
+			},
+		}}
+		err = router.Reload([]*config_loader.Config{withCluster})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "clusterKubeconfig")
+	})
+
+	t.Run("applies CEL limits with accepted configs only", func(t *testing.T) {
+		t.Cleanup(func() { criteria.UsePrograms(nil) })
+		costExceeded := func() bool {
+			evalCtx := criteria.NewEvaluationContext()
+			evalCtx.Set("items", []interface{}{int64(1), int64(2), int64(3), int64(4)})
+			evaluator, err := criteria.NewEvaluator(context.Background(), evalCtx, logger.NewTestLogger())
+			require.NoError(t, err)
+			_, err = evaluator.EvaluateCEL(`items.all(i, items.all(j, i + j > 0))`)
+			celErr, ok := apperrors.IsCELError(err)
+			return ok && celErr.IsCostExceeded()
+		}
+		withLimits := func(taskName string) *config_loader.Config {
+			config := newConfig(taskName, nil)
+			config.Spec.CEL = &config_loader.CELConfig{CostLimit: 1}
+			return config
+		}
+		router := newRouter(t)
+
+		err := router.Reload([]*config_loader.Config{withLimits("clusters")})
+		require.Error(t, err)
+		assert.False(t, costExceeded(), "a rejected reload keeps the current limits")
+
+		require.NoError(t, router.Reload([]*config_loader.Config{withLimits("clusters"), withLimits("nodepools")}))
+		assert.True(t, costExceeded())
+	})
+}
@@ -3,6 +3,8 @@ package executor
 import (
 	"context"
 	"fmt"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/config_loader"
@@ -54,14 +56,27 @@ type EventData struct {
 	OwnedReference *ResourceRef `json:"owned_reference,omitempty"`
 }
 
+// Key returns the key used to serialize executions of the same resource ("kind/id").
+// Returns an empty string when the event carries no id.
+func (d *EventData) Key() string {
+	if d == nil || d.ID == "" {
+		return ""
+	}
+	return strings.ToLower(d.Kind) + "/" + d.ID
+}
+
 // ExecutorConfig holds configuration for the executor
 type ExecutorConfig struct {
 	// Config is the unified configuration (merged from deployment and task configs)
 	Config *config_loader.Config
 	// APIClient is the HyperFleet API client
 	APIClient hyperfleet_api.Client
-	// TransportClient is the transport client for applying resources (kubernetes or maestro)
+	// TransportClient is the transport client for applying resources (kubernetes or maestro).
+	// It is used for resources whose transport client type has no entry in TransportClients.
 	TransportClient transport_client.TransportClient
+	// TransportClients holds the transport clients keyed by transport client type
+	// (the resource transport.client value, e.g. "kubernetes" or "maestro")
+	TransportClients map[string]transport_client.TransportClient
 	// Logger is the logger instance
 	Logger logger.Logger
 }
@@ -72,7 +87,10 @@ type Executor struct {
 	precondExecutor    *PreconditionExecutor
 	resourceExecutor   *ResourceExecutor
 	postActionExecutor *PostActionExecutor
+	keyLocks           *keyLocker
 	log                logger.Logger
+	// current is the config used for new executions (initially config.Config, replaced on reload)
+	current atomic.Pointer[config_loader.Config]
 }
 
 // ExecutionResult contains the result of processing an event
@@ -111,16 +129,41 @@ type PreconditionResult struct {
 	APICallMade bool
 	// APIResponse contains the raw API response (if APICallMade)
 	APIResponse []byte
-	// CapturedFields contains fields captured from the API response
+	// Resource is the object read by the resource lookup (nil if not configured or not found)
+	Resource *unstructured.Unstructured
+	// CapturedFields contains fields captured from the API response or the looked up object
 	CapturedFields map[string]interface{}
 	// ConditionResults contains individual condition evaluation results
 	ConditionResults []criteria.EvaluationResult
 	// CELResult contains CEL evaluation result (if expression was used)
 	CELResult *criteria.CELResult
+	// Trace explains the evaluation; set when debug logging is on or the precondition was not met
+	Trace *criteria.EvaluationTrace
+	// Attempts records each evaluation of the precondition; the fields above are those of
+	// the last one. A precondition without retry has a single attempt.
+	Attempts []PreconditionAttempt
 	// Error is the error if Status is StatusFailed
 	Error error
 }
 
+// PreconditionAttempt records one evaluation of a precondition
+type PreconditionAttempt struct {
+	// Attempt is the 1-based number of the evaluation
+	Attempt int
+	// StartedAt is when the evaluation started
+	StartedAt time.Time
+	// Matched indicates if conditions were satisfied
+	Matched bool
+	// CapturedFields contains fields captured from the API response
+	CapturedFields map[string]interface{}
+	// ConditionResults contains individual condition evaluation results
+	ConditionResults []criteria.EvaluationResult
+	// CELResult contains CEL evaluation result (if expression was used)
+	CELResult *criteria.CELResult
+	// Error is the error of a failed evaluation
+	Error error
+}
+
 // ResourceResult contains the result of a single resource operation
 type ResourceResult struct {
 	// Name is the resource name from config
@@ -133,7 +176,7 @@ type ResourceResult struct {
 	ResourceName string
 	// Status is the result status
 	Status ExecutionStatus
-	// Operation is the operation performed (create, update, recreate, skip)
+	// Operation is the operation performed (create, update, recreate, skip, correct-drift, delete)
 	Operation manifest.Operation
 	// OperationReason explains why this operation was performed
 	// Examples: "resource not found", "generation changed from 1 to 2", "generation 1 unchanged", "recreateOnChange=true"
@@ -201,6 +244,8 @@ type EvaluationRecord struct {
 	FieldResults map[string]criteria.EvaluationResult
 	// Timestamp is when the evaluation occurred
 	Timestamp time.Time
+	// Trace explains the evaluation (set for preconditions when debug logging is on or the precondition was not met)
+	Trace *criteria.EvaluationTrace
 }
 
 // EvaluationType indicates the type of evaluation performed
@@ -227,6 +272,20 @@ type AdapterMetadata struct {
 	ResourcesSkipped bool `json:"resourcesSkipped,omitempty"`
 	// SkipReason is why resources were skipped (e.g., "precondition not met")
 	SkipReason string `json:"skipReason,omitempty"`
+	// DriftCorrected lists the resources reapplied because their live object drifted from the desired spec
+	DriftCorrected []string `json:"driftCorrected,omitempty"`
+	// EvaluationTrace explains the precondition that was not met (nil if all were met)
+	EvaluationTrace *criteria.EvaluationTrace `json:"evaluationTrace,omitempty"`
+}
+
+// recordDriftCorrected adds a resource to DriftCorrected once
+func (m *AdapterMetadata) recordDriftCorrected(name string) {
+	for _, n := range m.DriftCorrected {
+		if n == name {
+			return
+		}
+	}
+	m.DriftCorrected = append(m.DriftCorrected, name)
 }
 
 // ExecutionError represents a structured execution error
@@ -277,6 +336,45 @@ func (ec *ExecutionContext) AddConditionsEvaluation(phase ExecutionPhase, name s
 	ec.AddEvaluation(phase, name, EvaluationTypeConditions, "", matched, fieldResults)
 }
 
+// SetEvaluationTrace attaches a trace to the latest evaluation recorded for a step
+func (ec *ExecutionContext) SetEvaluationTrace(phase ExecutionPhase, name string, trace *criteria.EvaluationTrace) {
+	for i := len(ec.Evaluations) - 1; i >= 0; i-- {
+		if ec.Evaluations[i].Phase == phase && ec.Evaluations[i].Name == name {
+			ec.Evaluations[i].Trace = trace
+			return
+		}
+	}
+}
+
+// fork returns a copy of the context for a precondition of a parallel group: params are copied
+// so that the precondition can store its response and captures, evaluations start empty
+func (ec *ExecutionContext) fork() *ExecutionContext {
+	fork := *ec
+	fork.Params = make(map[string]interface{}, len(ec.Params))
+	for k, v := range ec.Params {
+		fork.Params[k] = v
+	}
+	fork.Evaluations = make([]EvaluationRecord, 0)
+	return &fork
+}
+
+// mergePrecondition copies what a precondition executed on a fork recorded: its response or object,
+// its captures, its evaluations and the first execution error
+func (ec *ExecutionContext) mergePrecondition(fork *ExecutionContext, precond config_loader.Precondition) {
+	if response, ok := fork.Params[precond.Name]; ok && (precond.APICall != nil || precond.ResourceLookup != nil) {
+		ec.Params[precond.Name] = response
+	}
+	for _, capture := range precond.Capture {
+		if value, ok := fork.Params[capture.Name]; ok {
+			ec.Params[capture.Name] = value
+		}
+	}
+	ec.Evaluations = append(ec.Evaluations, fork.Evaluations...)
+	if ec.Adapter.ExecutionError == nil {
+		ec.Adapter.ExecutionError = fork.Adapter.ExecutionError
+	}
+}
+
 // GetEvaluationsByPhase returns all evaluations for a specific phase
 func (ec *ExecutionContext) GetEvaluationsByPhase(phase ExecutionPhase) []EvaluationRecord {
 	var results []EvaluationRecord
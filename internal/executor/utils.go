@@ -1,15 +1,12 @@
 package executor
 
 import (
-	"bytes"
 	"context"
 	"fmt"
 	"net/http"
 	"net/url"
 	"path"
-	"strconv"
 	"strings"
-	"text/template"
 	"time"
 
 	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/config_loader"
@@ -17,19 +14,27 @@ import (
 	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/hyperfleet_api"
 	apierrors "github.com/openshift-hyperfleet/hyperfleet-adapter/pkg/errors"
 	"github.com/openshift-hyperfleet/hyperfleet-adapter/pkg/logger"
-	"golang.org/x/text/cases"
-	"golang.org/x/text/language"
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/pkg/utils"
 )
 
 // ToConditionDefs converts config_loader.Condition slice to criteria.ConditionDef slice.
 // This centralizes the conversion logic that was previously repeated in multiple places.
+// anyOf, allOf and not groups are converted recursively.
 func ToConditionDefs(conditions []config_loader.Condition) []criteria.ConditionDef {
+	if len(conditions) == 0 {
+		return nil
+	}
 	defs := make([]criteria.ConditionDef, len(conditions))
 	for i, cond := range conditions {
 		defs[i] = criteria.ConditionDef{
 			Field:    cond.Field,
 			Operator: criteria.Operator(cond.Operator),
 			Value:    cond.Value,
+			AnyOf:    ToConditionDefs(cond.AnyOf),
+			AllOf:    ToConditionDefs(cond.AllOf),
+		}
+		if cond.Not != nil {
+			defs[i].Not = &ToConditionDefs([]config_loader.Condition{*cond.Not})[0]
 		}
 	}
 	return defs
@@ -44,7 +49,7 @@ func ExecuteLogAction(ctx context.Context, logAction *config_loader.LogAction, e
 	}
 
 	// Render the message template
-	message, err := renderTemplate(logAction.Message, execCtx.Params)
+	message, err := renderTemplate(logAction.Message, execCtx.Params, execCtx.Config.TemplateOptions())
 	if err != nil {
 		errCtx := logger.WithErrorField(ctx, err)
 		log.Errorf(errCtx, "failed to render log message")
@@ -82,7 +87,7 @@ func ExecuteAPICall(ctx context.Context, apiCall *config_loader.APICall, execCtx
 	}
 
 	// First render the URL template to resolve variables like {{ .hyperfleetApiBaseUrl }}
-	renderedURL, err := renderTemplate(apiCall.URL, execCtx.Params)
+	renderedURL, err := renderTemplate(apiCall.URL, execCtx.Params, execCtx.Config.TemplateOptions())
 	if err != nil {
 		return nil, "", fmt.Errorf("failed to render URL template: %w", err)
 	}
@@ -98,7 +103,7 @@ func ExecuteAPICall(ctx context.Context, apiCall *config_loader.APICall, execCtx
 	// Add headers
 	headers := make(map[string]string)
 	for _, h := range apiCall.Headers {
-		headerValue, err := renderTemplate(h.Value, execCtx.Params)
+		headerValue, err := renderTemplate(h.Value, execCtx.Params, execCtx.Config.TemplateOptions())
 		if err != nil {
 			return nil, url, fmt.Errorf("failed to render header '%s' template: %w", h.Name, err)
 		}
@@ -135,7 +140,7 @@ func ExecuteAPICall(ctx context.Context, apiCall *config_loader.APICall, execCtx
 	case http.MethodPost:
 		body := []byte(apiCall.Body)
 		if apiCall.Body != "" {
-			body, err = renderTemplateBytes(apiCall.Body, execCtx.Params)
+			body, err = renderTemplateBytes(apiCall.Body, execCtx.Params, execCtx.Config.TemplateOptions())
 			if err != nil {
 				return nil, url, fmt.Errorf("failed to render body template: %w", err)
 			}
@@ -155,7 +160,7 @@ func ExecuteAPICall(ctx context.Context, apiCall *config_loader.APICall, execCtx
 	case http.MethodPut:
 		body := []byte(apiCall.Body)
 		if apiCall.Body != "" {
-			body, err = renderTemplateBytes(apiCall.Body, execCtx.Params)
+			body, err = renderTemplateBytes(apiCall.Body, execCtx.Params, execCtx.Config.TemplateOptions())
 			if err != nil {
 				return nil, "", fmt.Errorf("failed to render body template: %w", err)
 			}
@@ -164,7 +169,7 @@ func ExecuteAPICall(ctx context.Context, apiCall *config_loader.APICall, execCtx
 	case http.MethodPatch:
 		body := []byte(apiCall.Body)
 		if apiCall.Body != "" {
-			body, err = renderTemplateBytes(apiCall.Body, execCtx.Params)
+			body, err = renderTemplateBytes(apiCall.Body, execCtx.Params, execCtx.Config.TemplateOptions())
 			if err != nil {
 				return nil, "", fmt.Errorf("failed to render body template: %w", err)
 			}
@@ -331,113 +336,15 @@ func ValidateAPIResponse(resp *hyperfleet_api.Response, err error, method, url s
 	return nil
 }
 
-// renderTemplate renders a Go template string with the given data
-// templateFuncs provides common functions for Go templates
-var templateFuncs = template.FuncMap{
-	// Time functions
-	"now": time.Now,
-	"date": func(layout string, t time.Time) string {
-		return t.Format(layout)
-	},
-	"dateFormat": func(layout string, t time.Time) string {
-		return t.Format(layout)
-	},
-	// String functions
-	"lower": strings.ToLower,
-	"upper": strings.ToUpper,
-	"title": func(s string) string {
-		return cases.Title(language.English).String(s)
-	},
-	"trim":      strings.TrimSpace,
-	"replace":   strings.ReplaceAll,
-	"contains":  strings.Contains,
-	"hasPrefix": strings.HasPrefix,
-	"hasSuffix": strings.HasSuffix,
-	// Default value function
-	"default": func(defaultVal, val interface{}) interface{} {
-		if val == nil || val == "" {
-			return defaultVal
-		}
-		return val
-	},
-	// Quote function
-	"quote": func(s string) string {
-		return fmt.Sprintf("%q", s)
-	},
-	// Type conversion functions
-	"int": func(v interface{}) int {
-		switch val := v.(type) {
-		case int:
-			return val
-		case int64:
-			return int(val)
-		case float64:
-			return int(val)
-		case string:
-			i, _ := strconv.Atoi(val) //nolint:errcheck // returns 0 on error, which is acceptable
-			return i
-		default:
-			return 0
-		}
-	},
-	"int64": func(v interface{}) int64 {
-		switch val := v.(type) {
-		case int:
-			return int64(val)
-		case int64:
-			return val
-		case float64:
-			return int64(val)
-		case string:
-			i, _ := strconv.ParseInt(val, 10, 64) //nolint:errcheck // returns 0 on error, which is acceptable
-			return i
-		default:
-			return 0
-		}
-	},
-	"float64": func(v interface{}) float64 {
-		switch val := v.(type) {
-		case int:
-			return float64(val)
-		case int64:
-			return float64(val)
-		case float64:
-			return val
-		case string:
-			f, _ := strconv.ParseFloat(val, 64) //nolint:errcheck // returns 0 on error, which is acceptable
-			return f
-		default:
-			return 0
-		}
-	},
-	"string": func(v interface{}) string {
-		return fmt.Sprintf("%v", v)
-	},
-}
-
-// This is a shared utility used across preconditions, resources, and post-actions
-func renderTemplate(templateStr string, data map[string]interface{}) (string, error) {
-	// If no template delimiters, return as-is
-	if !strings.Contains(templateStr, "{{") {
-		return templateStr, nil
-	}
-
-	tmpl, err := template.New("template").Funcs(templateFuncs).Option("missingkey=error").Parse(templateStr)
-	if err != nil {
-		return "", fmt.Errorf("failed to parse template: %w", err)
-	}
-
-	var buf bytes.Buffer
-	if err := tmpl.Execute(&buf, data); err != nil {
-		return "", fmt.Errorf("failed to execute template: %w", err)
-	}
-
-	return buf.String(), nil
+// renderTemplate renders a Go template string with the given data and options.
+// This is a shared utility used across preconditions, resources, and post-actions.
+func renderTemplate(templateStr string, data map[string]interface{}, opts utils.TemplateOptions) (string, error) {
+	return utils.RenderTemplateWithOptions(templateStr, data, opts)
 }
 
 // renderTemplateBytes renders a Go template string and returns bytes
-func renderTemplateBytes(templateStr string, data map[string]interface{}) ([]byte, error) {
-	result, err := renderTemplate(templateStr, data)
+func renderTemplateBytes(templateStr string, data map[string]interface{}, opts utils.TemplateOptions) ([]byte, error) {
+	result, err := renderTemplate(templateStr, data, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -458,6 +365,15 @@ func executionErrorToMap(execErr *ExecutionError) interface{} {
 	}
 }
 
+// driftCorrectedToList converts the drift-corrected resource names to a CEL list (never nil)
+func driftCorrectedToList(names []string) []interface{} {
+	result := make([]interface{}, len(names))
+	for i, name := range names {
+		result[i] = name
+	}
+	return result
+}
+
 // adapterMetadataToMap converts AdapterMetadata struct to a map for CEL evaluation
 func adapterMetadataToMap(adapter *AdapterMetadata) map[string]interface{} {
 	if adapter == nil {
@@ -471,5 +387,7 @@ func adapterMetadataToMap(adapter *AdapterMetadata) map[string]interface{} {
 		"errorReason":      adapter.ErrorReason,
 		"errorMessage":     adapter.ErrorMessage,
 		"executionError":   executionErrorToMap(adapter.ExecutionError),
+		"driftCorrected":   driftCorrectedToList(adapter.DriftCorrected),
+		"evaluationTrace":  criteria.EvaluationTraceToMap(adapter.EvaluationTrace),
 	}
 }
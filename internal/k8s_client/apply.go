@@ -55,7 +55,8 @@ func (c *Client) ApplyResource(
 //
 // If the resource doesn't exist, it creates it.
 // If it exists and the generation differs, it updates (or recreates if RecreateOnChange=true).
-// If it exists and the generation matches, it skips the update (idempotent).
+// If it exists and the generation matches, it skips the update (idempotent), unless
+// opts.DriftDetection is set and the live object drifted from the desired spec.
 //
 // The manifest must have the hyperfleet.io/generation annotation set.
 func (c *Client) ApplyManifest(
@@ -89,10 +90,31 @@ func (c *Client) ApplyManifest(
 		Reason:    decision.Reason,
 	}
 
-	// Handle recreateOnChange override
-	if decision.Operation == manifest.OperationUpdate && opts.RecreateOnChange {
+	// Handle drift detection: record the desired spec hash and check unchanged resources for drift
+	if opts.DriftDetection {
+		if err := manifest.SetSpecHash(newManifest); err != nil {
+			return nil, fmt.Errorf("failed to compute spec hash: %w", err)
+		}
+		if decision.Operation == manifest.OperationSkip {
+			drift, err := manifest.DetectDrift(newManifest, existing)
+			if err != nil {
+				return nil, fmt.Errorf("failed to detect drift: %w", err)
+			}
+			switch {
+			case drift.SpecChanged:
+				result.Operation = manifest.OperationUpdate
+				result.Reason = fmt.Sprintf("%s, %s", decision.Reason, drift.Reason)
+			case drift.Drifted:
+				result.Operation = manifest.OperationCorrectDrift
+				result.Reason = fmt.Sprintf("%s, %s", decision.Reason, drift.Reason)
+			}
+		}
+	}
+
+	// Handle recreateOnChange override (also applies to spec changes found by drift detection)
+	if result.Operation == manifest.OperationUpdate && opts.RecreateOnChange {
 		result.Operation = manifest.OperationRecreate
-		result.Reason = fmt.Sprintf("%s, recreateOnChange=true", decision.Reason)
+		result.Reason = fmt.Sprintf("%s, recreateOnChange=true", result.Reason)
 	}
 
 	gvk := newManifest.GroupVersionKind()
@@ -107,7 +129,7 @@ func (c *Client) ApplyManifest(
 	case manifest.OperationCreate:
 		_, applyErr = c.CreateResource(ctx, newManifest)
 
-	case manifest.OperationUpdate:
+	case manifest.OperationUpdate, manifest.OperationCorrectDrift:
 		// Preserve resourceVersion and UID from existing for update
 		newManifest.SetResourceVersion(existing.GetResourceVersion())
 		newManifest.SetUID(existing.GetUID())
@@ -0,0 +1,133 @@
+package k8s_client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/manifest"
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/pkg/constants"
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newApplyTestConfigMap(generation, value string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata": map[string]interface{}{
+			"name":        "cfg",
+			"namespace":   "default",
+			"annotations": map[string]interface{}{constants.AnnotationGeneration: generation},
+		},
+		"data": map[string]interface{}{"key": value},
+	}}
+}
+
+func TestApplyManifestDriftDetection(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name              string
+		driftDetection    bool
+		modifyLive        func(live *unstructured.Unstructured)
+		desiredValue      string
+		expectedOperation manifest.Operation
+		expectedValue     string
+	}{
+		{
+			name:              "no drift",
+			driftDetection:    true,
+			desiredValue:      "v1",
+			expectedOperation: manifest.OperationSkip,
+			expectedValue:     "v1",
+		},
+		{
+			name:           "live object edited out of band",
+			driftDetection: true,
+			modifyLive: func(live *unstructured.Unstructured) {
+				live.Object["data"] = map[string]interface{}{"key": "edited"}
+			},
+			desiredValue:      "v1",
+			expectedOperation: manifest.OperationCorrectDrift,
+			expectedValue:     "v1",
+		},
+		{
+			name:              "desired spec changed without generation bump",
+			driftDetection:    true,
+			desiredValue:      "v2",
+			expectedOperation: manifest.OperationUpdate,
+			expectedValue:     "v2",
+		},
+		{
+			name:           "drift ignored without drift detection",
+			driftDetection: false,
+			modifyLive: func(live *unstructured.Unstructured) {
+				live.Object["data"] = map[string]interface{}{"key": "edited"}
+			},
+			desiredValue:      "v1",
+			expectedOperation: manifest.OperationSkip,
+			expectedValue:     "edited",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Client{client: fake.NewClientBuilder().Build(), log: logger.NewTestLogger()}
+			opts := &ApplyOptions{DriftDetection: tt.driftDetection}
+
+			// Initial apply creates the resource (with the spec hash when drift detection is enabled)
+			result, err := c.ApplyManifest(ctx, newApplyTestConfigMap("1", "v1"), nil, opts)
+			require.NoError(t, err)
+			assert.Equal(t, manifest.OperationCreate, result.Operation)
+
+			gvk := CommonResourceKinds.ConfigMap
+			live, err := c.GetResource(ctx, gvk, "default", "cfg", nil)
+			require.NoError(t, err)
+			if tt.driftDetection {
+				assert.NotEmpty(t, live.GetAnnotations()[constants.AnnotationSpecHash])
+			}
+			if tt.modifyLive != nil {
+				tt.modifyLive(live)
+				_, err = c.UpdateResource(ctx, live)
+				require.NoError(t, err)
+				live, err = c.GetResource(ctx, gvk, "default", "cfg", nil)
+				require.NoError(t, err)
+			}
+
+			result, err = c.ApplyManifest(ctx, newApplyTestConfigMap("1", tt.desiredValue), live, opts)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedOperation, result.Operation, result.Reason)
+
+			live, err = c.GetResource(ctx, gvk, "default", "cfg", nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedValue, live.Object["data"].(map[string]interface{})["key"])
+		})
+	}
+}
+
+func TestApplyManifestDriftDetectionWithRecreateOnChange(t *testing.T) {
+	ctx := context.Background()
+	c := &Client{client: fake.NewClientBuilder().Build(), log: logger.NewTestLogger()}
+	opts := &ApplyOptions{DriftDetection: true, RecreateOnChange: true}
+
+	result, err := c.ApplyManifest(ctx, newApplyTestConfigMap("1", "v1"), nil, opts)
+	require.NoError(t, err)
+	assert.Equal(t, manifest.OperationCreate, result.Operation)
+
+	gvk := CommonResourceKinds.ConfigMap
+	live, err := c.GetResource(ctx, gvk, "default", "cfg", nil)
+	require.NoError(t, err)
+
+	// Same generation, different desired spec: drift detection reports a spec change
+	result, err = c.ApplyManifest(ctx, newApplyTestConfigMap("1", "v2"), live, opts)
+	require.NoError(t, err)
+	assert.Equal(t, manifest.OperationRecreate, result.Operation, result.Reason)
+	assert.Contains(t, result.Reason, "recreateOnChange=true")
+
+	live, err = c.GetResource(ctx, gvk, "default", "cfg", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "v2", live.Object["data"].(map[string]interface{})["key"])
+}
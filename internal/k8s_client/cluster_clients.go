@@ -0,0 +1,282 @@
+package k8s_client
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"text/template"
+	"time"
+
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/manifest"
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/transport_client"
+	apperrors "github.com/openshift-hyperfleet/hyperfleet-adapter/pkg/errors"
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/pkg/logger"
+	"golang.org/x/sync/singleflight"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/cache"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// Defaults applied to unset ClusterClientsConfig fields
+const (
+	// DefaultClusterSecretName matches the Cluster API "<cluster>-kubeconfig" Secret
+	DefaultClusterSecretName = "{{ .cluster }}-kubeconfig"
+	// DefaultClusterSecretKey is the Cluster API kubeconfig Secret data key
+	DefaultClusterSecretKey   = "value"
+	DefaultClusterCacheSize   = 50
+	DefaultClusterIdleTimeout = 30 * time.Minute
+)
+
+// secretGVK is the GroupVersionKind of kubeconfig Secrets
+var secretGVK = schema.GroupVersionKind{Version: "v1", Kind: "Secret"}
+
+// TransportContext carries per-request routing information for the Kubernetes transport backend.
+// Pass it as the TransportContext (any) to ClusterClients to target a remote cluster.
+type TransportContext struct {
+	// Cluster is the target cluster whose kubeconfig is read from a Secret.
+	// Empty targets the adapter's own cluster.
+	Cluster string
+}
+
+// ClusterClientsConfig locates the kubeconfig Secrets of target clusters.
+// SecretNamespace and SecretName are Go templates over {{ .cluster }}.
+type ClusterClientsConfig struct {
+	SecretNamespace string
+	SecretName      string
+	SecretKey       string
+	// CacheSize is the maximum number of cached cluster clients
+	CacheSize int
+	// IdleTimeout evicts cluster clients unused for this long
+	IdleTimeout time.Duration
+	// QPS and Burst are the rate limits of the cluster clients (zero uses defaults)
+	QPS   float32
+	Burst int
+}
+
+// clientFactory builds a transport client from kubeconfig bytes
+type clientFactory func(ctx context.Context, kubeconfig []byte) (transport_client.TransportClient, error)
+
+// ClusterClients is a TransportClient applying resources either to the adapter's own cluster
+// or, when the target is a *TransportContext with a Cluster, to that cluster using a kubeconfig
+// read from a Secret in the adapter's own cluster.
+// Cluster clients are kept in a bounded LRU cache and evicted after IdleTimeout without use,
+// or right away when the cluster rejects their credentials, so rotated kubeconfigs are picked up.
+type ClusterClients struct {
+	local           K8sClient
+	secretNamespace *template.Template
+	secretName      *template.Template
+	secretKey       string
+	idleTimeout     time.Duration
+	newClient       clientFactory
+	log             logger.Logger
+
+	// building deduplicates client creation per cluster, so concurrent events for a cluster share
+	// one client while other clusters are served without waiting on it
+	building singleflight.Group
+	clients  *cache.LRUExpireCache
+}
+
+// Ensure ClusterClients implements TransportClient interface
+var _ transport_client.TransportClient = (*ClusterClients)(nil)
+
+// NewClusterClients creates a ClusterClients over the local client
+func NewClusterClients(local K8sClient, config ClusterClientsConfig, log logger.Logger) (*ClusterClients, error) {
+	factory := func(ctx context.Context, kubeconfig []byte) (transport_client.TransportClient, error) {
+		restConfig, err := clientcmd.RESTConfigFromKubeConfig(kubeconfig)
+		if err != nil {
+			return nil, apperrors.KubernetesError("failed to parse kubeconfig: %v", err)
+		}
+		if config.QPS > 0 {
+			restConfig.QPS = config.QPS
+		}
+		if config.Burst > 0 {
+			restConfig.Burst = config.Burst
+		}
+		client, err := NewClientFromConfig(ctx, restConfig, log)
+		if err != nil {
+			return nil, err
+		}
+		return client, nil
+	}
+	return newClusterClients(local, config, factory, log)
+}
+
+// newClusterClients creates a ClusterClients building cluster clients with the given factory
+func newClusterClients(local K8sClient, config ClusterClientsConfig, factory clientFactory, log logger.Logger) (*ClusterClients, error) {
+	if local == nil || log == nil {
+		return nil, fmt.Errorf("local client and logger are required")
+	}
+	if config.SecretNamespace == "" {
+		return nil, fmt.Errorf("kubeconfig secret namespace is required")
+	}
+
+	secretName := config.SecretName
+	if secretName == "" {
+		secretName = DefaultClusterSecretName
+	}
+	secretKey := config.SecretKey
+	if secretKey == "" {
+		secretKey = DefaultClusterSecretKey
+	}
+	cacheSize := config.CacheSize
+	if cacheSize <= 0 {
+		cacheSize = DefaultClusterCacheSize
+	}
+	idleTimeout := config.IdleTimeout
+	if idleTimeout <= 0 {
+		idleTimeout = DefaultClusterIdleTimeout
+	}
+
+	namespaceTmpl, err := template.New("secretNamespace").Option("missingkey=error").Parse(config.SecretNamespace)
+	if err != nil {
+		return nil, fmt.Errorf("invalid kubeconfig secret namespace template: %w", err)
+	}
+	nameTmpl, err := template.New("secretName").Option("missingkey=error").Parse(secretName)
+	if err != nil {
+		return nil, fmt.Errorf("invalid kubeconfig secret name template: %w", err)
+	}
+
+	return &ClusterClients{
+		local:           local,
+		secretNamespace: namespaceTmpl,
+		secretName:      nameTmpl,
+		secretKey:       secretKey,
+		idleTimeout:     idleTimeout,
+		newClient:       factory,
+		log:             log,
+		clients:         cache.NewLRUExpireCache(cacheSize),
+	}, nil
+}
+
+// ApplyResource applies the manifest to the target cluster
+func (c *ClusterClients) ApplyResource(ctx context.Context, manifestBytes []byte, opts *transport_client.ApplyOptions, target transport_client.TransportContext) (*transport_client.ApplyResult, error) {
+	cluster := resolveCluster(target)
+	client, err := c.clientFor(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+	result, err := client.ApplyResource(ctx, manifestBytes, opts, nil)
+	c.evictOnAuthError(ctx, cluster, err)
+	return result, err
+}
+
+// GetResource retrieves a resource from the target cluster
+func (c *ClusterClients) GetResource(ctx context.Context, gvk schema.GroupVersionKind, namespace, name string, target transport_client.TransportContext) (*unstructured.Unstructured, error) {
+	cluster := resolveCluster(target)
+	client, err := c.clientFor(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+	obj, err := client.GetResource(ctx, gvk, namespace, name, nil)
+	c.evictOnAuthError(ctx, cluster, err)
+	return obj, err
+}
+
+// DiscoverResources discovers resources in the target cluster
+func (c *ClusterClients) DiscoverResources(ctx context.Context, gvk schema.GroupVersionKind, discovery manifest.Discovery, target transport_client.TransportContext) (*unstructured.UnstructuredList, error) {
+	cluster := resolveCluster(target)
+	client, err := c.clientFor(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+	list, err := client.DiscoverResources(ctx, gvk, discovery, nil)
+	c.evictOnAuthError(ctx, cluster, err)
+	return list, err
+}
+
+// resolveCluster extracts the target cluster from the generic transport context.
+// Returns an empty string (the local cluster) if target is nil or of another type.
+func resolveCluster(target transport_client.TransportContext) string {
+	tc, ok := target.(*TransportContext)
+	if !ok || tc == nil {
+		return ""
+	}
+	return tc.Cluster
+}
+
+// clientFor returns the client of a cluster, building it from its kubeconfig Secret on a cache miss.
+// The empty cluster is served by the local client.
+func (c *ClusterClients) clientFor(ctx context.Context, cluster string) (transport_client.TransportClient, error) {
+	if cluster == "" {
+		return c.local, nil
+	}
+
+	if cached, ok := c.clients.Get(cluster); ok {
+		client := cached.(transport_client.TransportClient)
+		// Re-adding refreshes the idle timeout
+		c.clients.Add(cluster, client, c.idleTimeout)
+		return client, nil
+	}
+
+	// The client is shared with concurrent callers, so one caller's cancellation must not fail the others
+	buildCtx := context.WithoutCancel(ctx)
+	built, err, _ := c.building.Do(cluster, func() (interface{}, error) {
+		// A concurrent build may have finished after the cache miss above
+		if cached, ok := c.clients.Get(cluster); ok {
+			return cached, nil
+		}
+		kubeconfig, err := c.readKubeconfig(buildCtx, cluster)
+		if err != nil {
+			return nil, err
+		}
+		client, err := c.newClient(buildCtx, kubeconfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create client for cluster %q: %w", cluster, err)
+		}
+		c.clients.Add(cluster, client, c.idleTimeout)
+		c.log.Infof(buildCtx, "Created Kubernetes client for cluster %s", cluster)
+		return client, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return built.(transport_client.TransportClient), nil
+}
+
+// readKubeconfig reads the kubeconfig of a cluster from its Secret in the local cluster
+func (c *ClusterClients) readKubeconfig(ctx context.Context, cluster string) ([]byte, error) {
+	namespace, err := renderClusterTemplate(c.secretNamespace, cluster)
+	if err != nil {
+		return nil, err
+	}
+	name, err := renderClusterTemplate(c.secretName, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := c.local.GetResource(ctx, secretGVK, namespace, name, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get kubeconfig secret %s/%s for cluster %q: %w", namespace, name, cluster, err)
+	}
+	encoded, found, err := unstructured.NestedString(secret.Object, "data", c.secretKey)
+	if err != nil || !found || encoded == "" {
+		return nil, fmt.Errorf("kubeconfig secret %s/%s for cluster %q has no data key %q", namespace, name, cluster, c.secretKey)
+	}
+	kubeconfig, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("kubeconfig secret %s/%s for cluster %q: invalid data key %q: %w", namespace, name, cluster, c.secretKey, err)
+	}
+	return kubeconfig, nil
+}
+
+// evictOnAuthError drops the client of a cluster that rejected its credentials,
+// so the next request reads the (possibly rotated) kubeconfig Secret again
+func (c *ClusterClients) evictOnAuthError(ctx context.Context, cluster string, err error) {
+	if cluster == "" || err == nil || !apierrors.IsUnauthorized(err) {
+		return
+	}
+	c.clients.Remove(cluster)
+	c.log.Warnf(ctx, "Evicted Kubernetes client for cluster %s after authentication error", cluster)
+}
+
+// renderClusterTemplate renders a secret namespace or name template for a cluster
+func renderClusterTemplate(tmpl *template.Template, cluster string) (string, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, map[string]string{"cluster": cluster}); err != nil {
+		return "", fmt.Errorf("failed to render %s template for cluster %q: %w", tmpl.Name(), cluster, err)
+	}
+	return buf.String(), nil
+}
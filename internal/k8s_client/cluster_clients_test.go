@@ -0,0 +1,183 @@
+package k8s_client
+
+import (
+	"context"
+	"encoding/base64"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/transport_client"
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const testManifest = `{"apiVersion": "v1", "kind": "Namespace", "metadata": {"name": "day2"}}`
+
+// newKubeconfigSecret builds a kubeconfig Secret with the kubeconfig stored under key
+func newKubeconfigSecret(namespace, name, key, kubeconfig string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Secret",
+		"metadata":   map[string]interface{}{"name": name, "namespace": namespace},
+		"data":       map[string]interface{}{key: base64.StdEncoding.EncodeToString([]byte(kubeconfig))},
+	}}
+}
+
+// newTestClusterClients returns ClusterClients whose cluster clients are mocks, keyed by kubeconfig
+func newTestClusterClients(t *testing.T, local *MockK8sClient, config ClusterClientsConfig) (*ClusterClients, map[string]*MockK8sClient, *int) {
+	t.Helper()
+
+	remotes := map[string]*MockK8sClient{}
+	created := 0
+	factory := func(_ context.Context, kubeconfig []byte) (transport_client.TransportClient, error) {
+		created++
+		remote := NewMockK8sClient()
+		remotes[string(kubeconfig)] = remote
+		return remote, nil
+	}
+	clients, err := newClusterClients(local, config, factory, logger.NewTestLogger())
+	require.NoError(t, err)
+	return clients, remotes, &created
+}
+
+func TestClusterClients(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("local cluster without target", func(t *testing.T) {
+		local := NewMockK8sClient()
+		clients, remotes, _ := newTestClusterClients(t, local, ClusterClientsConfig{SecretNamespace: "clusters"})
+
+		_, err := clients.ApplyResource(ctx, []byte(testManifest), nil, nil)
+		require.NoError(t, err)
+		_, err = clients.ApplyResource(ctx, []byte(testManifest), nil, &TransportContext{})
+		require.NoError(t, err)
+		assert.Contains(t, local.Resources, "/day2")
+		assert.Empty(t, remotes)
+	})
+
+	t.Run("remote cluster from CAPI secret defaults", func(t *testing.T) {
+		local := NewMockK8sClient()
+		local.Resources["clusters/abc-kubeconfig"] = newKubeconfigSecret("clusters", "abc-kubeconfig", "value", "kubeconfig-abc")
+		clients, remotes, created := newTestClusterClients(t, local, ClusterClientsConfig{SecretNamespace: "clusters"})
+
+		target := &TransportContext{Cluster: "abc"}
+		_, err := clients.ApplyResource(ctx, []byte(testManifest), nil, target)
+		require.NoError(t, err)
+		_, err = clients.GetResource(ctx, schema.GroupVersionKind{Version: "v1", Kind: "Namespace"}, "", "day2", target)
+		require.NoError(t, err)
+
+		require.Contains(t, remotes, "kubeconfig-abc")
+		assert.Contains(t, remotes["kubeconfig-abc"].Resources, "/day2")
+		assert.NotContains(t, local.Resources, "/day2")
+		assert.Equal(t, 1, *created, "the cluster client is cached")
+	})
+
+	t.Run("remote cluster from HyperShift secret templates", func(t *testing.T) {
+		local := NewMockK8sClient()
+		local.Resources["clusters-abc/admin-kubeconfig"] = newKubeconfigSecret("clusters-abc", "admin-kubeconfig", "kubeconfig", "kubeconfig-abc")
+		clients, remotes, _ := newTestClusterClients(t, local, ClusterClientsConfig{
+			SecretNamespace: "clusters-{{ .cluster }}",
+			SecretName:      "admin-kubeconfig",
+			SecretKey:       "kubeconfig",
+		})
+
+		_, err := clients.ApplyResource(ctx, []byte(testManifest), nil, &TransportContext{Cluster: "abc"})
+		require.NoError(t, err)
+		assert.Contains(t, remotes, "kubeconfig-abc")
+	})
+
+	t.Run("missing secret", func(t *testing.T) {
+		clients, _, _ := newTestClusterClients(t, NewMockK8sClient(), ClusterClientsConfig{SecretNamespace: "clusters"})
+
+		_, err := clients.ApplyResource(ctx, []byte(testManifest), nil, &TransportContext{Cluster: "abc"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "clusters/abc-kubeconfig")
+	})
+
+	t.Run("authentication error evicts the cluster client", func(t *testing.T) {
+		local := NewMockK8sClient()
+		local.Resources["clusters/abc-kubeconfig"] = newKubeconfigSecret("clusters", "abc-kubeconfig", "value", "kubeconfig-abc")
+		clients, remotes, created := newTestClusterClients(t, local, ClusterClientsConfig{SecretNamespace: "clusters"})
+		target := &TransportContext{Cluster: "abc"}
+
+		_, err := clients.ApplyResource(ctx, []byte(testManifest), nil, target)
+		require.NoError(t, err)
+		remotes["kubeconfig-abc"].ApplyResourceError = apierrors.NewUnauthorized("token expired")
+		_, err = clients.ApplyResource(ctx, []byte(testManifest), nil, target)
+		require.Error(t, err)
+
+		_, err = clients.ApplyResource(ctx, []byte(testManifest), nil, target)
+		require.NoError(t, err)
+		assert.Equal(t, 2, *created, "the kubeconfig secret is read again")
+	})
+
+	t.Run("cache is bounded", func(t *testing.T) {
+		local := NewMockK8sClient()
+		for _, cluster := range []string{"a", "b"} {
+			local.Resources["clusters/"+cluster+"-kubeconfig"] = newKubeconfigSecret("clusters", cluster+"-kubeconfig", "value", "kubeconfig-"+cluster)
+		}
+		clients, _, created := newTestClusterClients(t, local, ClusterClientsConfig{SecretNamespace: "clusters", CacheSize: 1})
+
+		for _, cluster := range []string{"a", "b", "a"} {
+			_, err := clients.ApplyResource(ctx, []byte(testManifest), nil, &TransportContext{Cluster: cluster})
+			require.NoError(t, err)
+		}
+		assert.Equal(t, 3, *created, "the least recently used client is evicted")
+	})
+
+	t.Run("slow client creation does not block other clusters", func(t *testing.T) {
+		local := NewMockK8sClient()
+		for _, cluster := range []string{"slow", "fast"} {
+			local.Resources["clusters/"+cluster+"-kubeconfig"] = newKubeconfigSecret("clusters", cluster+"-kubeconfig", "value", "kubeconfig-"+cluster)
+		}
+		release := make(chan struct{})
+		var created atomic.Int32
+		factory := func(_ context.Context, kubeconfig []byte) (transport_client.TransportClient, error) {
+			created.Add(1)
+			if string(kubeconfig) == "kubeconfig-slow" {
+				<-release
+			}
+			return NewMockK8sClient(), nil
+		}
+		clients, err := newClusterClients(local, ClusterClientsConfig{SecretNamespace: "clusters"}, factory, logger.NewTestLogger())
+		require.NoError(t, err)
+
+		gvk := schema.GroupVersionKind{Version: "v1", Kind: "Namespace"}
+		var wg sync.WaitGroup
+		slowClients := make([]transport_client.TransportClient, 3)
+		for i := range slowClients {
+			wg.Go(func() {
+				slowClients[i], _ = clients.clientFor(ctx, "slow")
+			})
+		}
+
+		done := make(chan error, 1)
+		go func() {
+			_, err := clients.GetResource(ctx, gvk, "", "day2", &TransportContext{Cluster: "fast"})
+			done <- err
+		}()
+		select {
+		case err := <-done:
+			assert.True(t, apierrors.IsNotFound(err))
+		case <-time.After(5 * time.Second):
+			t.Fatal("fast cluster waited on the slow cluster's client creation")
+		}
+
+		close(release)
+		wg.Wait()
+		assert.Equal(t, int32(2), created.Load(), "concurrent callers of a cluster share one client")
+		assert.Same(t, slowClients[0], slowClients[1])
+		assert.Same(t, slowClients[0], slowClients[2])
+	})
+
+	t.Run("requires secret namespace", func(t *testing.T) {
+		_, err := NewClusterClients(NewMockK8sClient(), ClusterClientsConfig{}, logger.NewTestLogger())
+		assert.Error(t, err)
+	})
+}
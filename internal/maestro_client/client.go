@@ -43,6 +43,8 @@ type Client struct {
 	config           *Config
 	log              logger.Logger
 	grpcOptions      *grpcopts.GRPCOptions
+	// cache holds ManifestWork status kept up to date by WatchStatus
+	cache *workCache
 }
 
 // Config holds configuration for creating a Maestro client
@@ -213,6 +215,7 @@ func NewMaestroClient(ctx context.Context, config *Config, log logger.Logger) (*
 		config:           config,
 		log:              log,
 		grpcOptions:      grpcOptions,
+		cache:            newWorkCache(),
 	}, nil
 }
 
@@ -504,7 +507,7 @@ func (c *Client) GetResource(
 
 	// If the GVK is ManifestWork, get the ManifestWork object directly
 	if gvk.Kind == constants.ManifestWorkKind && gvk.Group == constants.ManifestWorkGroup {
-		work, err := c.GetManifestWork(ctx, consumerName, name)
+		work, err := c.cachedManifestWork(ctx, consumerName, name)
 		if err != nil {
 			return nil, err
 		}
@@ -512,7 +515,7 @@ func (c *Client) GetResource(
 	}
 
 	// Otherwise, list all ManifestWorks and search within their workloads
-	workList, err := c.ListManifestWorks(ctx, consumerName, "")
+	workList, err := c.cachedManifestWorks(ctx, consumerName)
 	if err != nil {
 		return nil, err
 	}
@@ -537,6 +540,39 @@ func (c *Client) GetResource(
 	return nil, apierrors.NewNotFound(gr, name)
 }
 
+// Ensure Client implements transport_client.ResourceRemover
+var _ transport_client.ResourceRemover = (*Client)(nil)
+
+// RemoveResource deletes a ManifestWork from the target consumer. The workload on the managed
+// cluster is deleted or orphaned following the ManifestWork's spec.deleteOption.
+// With opts.Wait set it waits until the ManifestWork is gone (see WaitForManifestWorkDeletion).
+// Requires a *maestro_client.TransportContext with ConsumerName.
+func (c *Client) RemoveResource(
+	ctx context.Context,
+	gvk schema.GroupVersionKind,
+	namespace, name string,
+	opts *transport_client.DeleteOptions,
+	target transport_client.TransportContext,
+) error {
+	if gvk.Kind != constants.ManifestWorkKind || gvk.Group != constants.ManifestWorkGroup {
+		return fmt.Errorf("maestro transport can only remove ManifestWorks, got %s", gvk.String())
+	}
+
+	transportCtx := c.resolveTransportContext(target)
+	if transportCtx == nil || transportCtx.ConsumerName == "" {
+		return fmt.Errorf("consumer name (target cluster) is required: set TransportContext.ConsumerName")
+	}
+	consumerName := transportCtx.ConsumerName
+
+	if err := c.DeleteManifestWork(ctx, consumerName, name); err != nil {
+		return err
+	}
+	if opts == nil || !opts.Wait {
+		return nil
+	}
+	return c.WaitForManifestWorkDeletion(ctx, consumerName, name, opts.Timeout)
+}
+
 // DiscoverResources discovers resources by searching all ManifestWorks for the target consumer.
 // If the GVK is ManifestWork, it matches against the ManifestWork objects themselves.
 // Otherwise, it searches within the workloads of each ManifestWork.
@@ -558,7 +594,7 @@ func (c *Client) DiscoverResources(
 	ctx = logger.WithMaestroConsumer(ctx, consumerName)
 
 	// List all ManifestWorks for this consumer
-	workList, err := c.ListManifestWorks(ctx, consumerName, "")
+	workList, err := c.cachedManifestWorks(ctx, consumerName)
 	if err != nil {
 		return nil, err
 	}
@@ -2,6 +2,7 @@ package maestro_client
 
 import (
 	"context"
+	"time"
 
 	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/manifest"
 	workv1 "open-cluster-management.io/api/work/v1"
@@ -33,6 +34,9 @@ type ManifestWorkClient interface {
 	// DeleteManifestWork deletes a ManifestWork from a target cluster
 	DeleteManifestWork(ctx context.Context, consumerName string, workName string) error
 
+	// WaitForManifestWorkDeletion blocks until a deleted ManifestWork is gone from a target cluster
+	WaitForManifestWorkDeletion(ctx context.Context, consumerName string, workName string, timeout time.Duration) error
+
 	// ListManifestWorks lists all ManifestWorks for a target cluster
 	ListManifestWorks(ctx context.Context, consumerName string, labelSelector string) (*workv1.ManifestWorkList, error)
 
@@ -3,6 +3,7 @@ package maestro_client
 import (
 	"context"
 	"encoding/json"
+	"time"
 
 	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/manifest"
 	"github.com/openshift-hyperfleet/hyperfleet-adapter/pkg/constants"
@@ -14,6 +15,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	kubetypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
 	workv1 "open-cluster-management.io/api/work/v1"
 )
 
@@ -64,6 +66,7 @@ func (c *Client) CreateManifestWork(
 			consumerName, work.Name, err)
 	}
 
+	c.cache.upsert(created)
 	c.log.Info(ctx, "Created ManifestWork")
 	return created, nil
 }
@@ -116,6 +119,7 @@ func (c *Client) PatchManifestWork(
 			consumerName, workName, err)
 	}
 
+	c.cache.upsert(patched)
 	c.log.Info(ctx, "Patched ManifestWork")
 	return patched, nil
 }
@@ -142,10 +146,56 @@ func (c *Client) DeleteManifestWork(
 			consumerName, workName, err)
 	}
 
+	c.cache.remove(consumerName, workName)
 	c.log.Info(ctx, "Deleted ManifestWork")
 	return nil
 }
 
+// Defaults of WaitForManifestWorkDeletion
+const (
+	DefaultDeleteTimeout      = 5 * time.Minute
+	DefaultDeletePollInterval = 2 * time.Second
+)
+
+// WaitForManifestWorkDeletion blocks until a ManifestWork is gone from a target cluster.
+// Maestro keeps a deleted ManifestWork until the agent reports its workload removed from the
+// consumer (or orphaned, following spec.deleteOption), so this waits for the actual cleanup.
+// A zero timeout uses DefaultDeleteTimeout.
+func (c *Client) WaitForManifestWorkDeletion(
+	ctx context.Context,
+	consumerName string,
+	workName string,
+	timeout time.Duration,
+) error {
+	if timeout <= 0 {
+		timeout = DefaultDeleteTimeout
+	}
+	ctx = logger.WithMaestroConsumer(ctx, consumerName)
+	ctx = logger.WithLogField(ctx, "manifestwork", workName)
+
+	c.log.Debugf(ctx, "Waiting up to %s for ManifestWork removal", timeout)
+
+	err := wait.PollUntilContextTimeout(ctx, DefaultDeletePollInterval, timeout, true,
+		func(ctx context.Context) (bool, error) {
+			_, err := c.workClient.ManifestWorks(consumerName).Get(ctx, workName, metav1.GetOptions{})
+			if apierrors.IsNotFound(err) {
+				return true, nil
+			}
+			if err != nil {
+				c.log.Debugf(ctx, "Failed to get ManifestWork while waiting for removal: %v", err)
+			}
+			return false, nil
+		})
+	if err != nil {
+		return apperrors.MaestroError("ManifestWork %s/%s was not removed within %s: %v",
+			consumerName, workName, timeout, err)
+	}
+
+	c.cache.remove(consumerName, workName)
+	c.log.Info(ctx, "ManifestWork removed from consumer")
+	return nil
+}
+
 // ListManifestWorks lists all ManifestWorks for a target cluster
 func (c *Client) ListManifestWorks(
 	ctx context.Context,
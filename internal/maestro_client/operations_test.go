@@ -6,11 +6,22 @@
 package maestro_client
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/manifest"
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/transport_client"
 	"github.com/openshift-hyperfleet/hyperfleet-adapter/pkg/constants"
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8stesting "k8s.io/client-go/testing"
+	workfake "open-cluster-management.io/api/client/work/clientset/versioned/fake"
 	workv1 "open-cluster-management.io/api/work/v1"
 )
 
@@ -168,3 +179,44 @@ func TestGenerationComparison(t *testing.T) {
 		})
 	}
 }
+
+func TestRemoveResource(t *testing.T) {
+	workGVK := schema.GroupVersionKind{Group: constants.ManifestWorkGroup, Version: "v1", Kind: constants.ManifestWorkKind}
+	target := &TransportContext{ConsumerName: "cluster-a"}
+	ctx := context.Background()
+
+	t.Run("deletes the ManifestWork and waits for its removal", func(t *testing.T) {
+		fakeClient := workfake.NewSimpleClientset(newStatusTestWork("cluster-a", "work-1"))
+		c := &Client{workClient: fakeClient.WorkV1(), log: logger.NewTestLogger(), cache: newWorkCache()}
+
+		err := c.RemoveResource(ctx, workGVK, "", "work-1", &transport_client.DeleteOptions{Wait: true}, target)
+		require.NoError(t, err)
+		_, err = fakeClient.WorkV1().ManifestWorks("cluster-a").Get(ctx, "work-1", metav1.GetOptions{})
+		assert.True(t, apierrors.IsNotFound(err))
+
+		// Already removed
+		require.NoError(t, c.RemoveResource(ctx, workGVK, "", "work-1", nil, target))
+	})
+
+	t.Run("times out while the agent keeps the ManifestWork", func(t *testing.T) {
+		fakeClient := workfake.NewSimpleClientset(newStatusTestWork("cluster-a", "work-1"))
+		fakeClient.PrependReactor("delete", "manifestworks", func(k8stesting.Action) (bool, runtime.Object, error) {
+			return true, nil, nil
+		})
+		c := &Client{workClient: fakeClient.WorkV1(), log: logger.NewTestLogger(), cache: newWorkCache()}
+
+		opts := &transport_client.DeleteOptions{Wait: true, Timeout: 50 * time.Millisecond}
+		err := c.RemoveResource(ctx, workGVK, "", "work-1", opts, target)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "was not removed within")
+	})
+
+	t.Run("rejects other kinds and missing consumers", func(t *testing.T) {
+		c := &Client{workClient: workfake.NewSimpleClientset().WorkV1(), log: logger.NewTestLogger()}
+
+		err := c.RemoveResource(ctx, schema.GroupVersionKind{Version: "v1", Kind: "Namespace"}, "", "apps", nil, target)
+		assert.Error(t, err)
+		err = c.RemoveResource(ctx, workGVK, "", "work-1", nil, nil)
+		assert.Error(t, err)
+	})
+}
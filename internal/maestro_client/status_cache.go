@@ -0,0 +1,256 @@
+package maestro_client
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/pkg/logger"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	workv1 "open-cluster-management.io/api/work/v1"
+)
+
+// DefaultWatchRetryInterval is the wait before re-establishing a closed or failed status watch
+const DefaultWatchRetryInterval = 5 * time.Second
+
+// StatusChangeFunc is called for each change of a watched ManifestWork with its previous
+// and current state. previous is nil the first time a ManifestWork is seen.
+type StatusChangeFunc func(ctx context.Context, previous, current *workv1.ManifestWork)
+
+// workCache holds the ManifestWorks of the adapter's source keyed by "consumer/name".
+// It serves reads only while synced, i.e. while the status watch is running.
+// A nil workCache is never synced.
+type workCache struct {
+	mu     sync.RWMutex
+	works  map[string]*workv1.ManifestWork
+	synced bool
+}
+
+func newWorkCache() *workCache {
+	return &workCache{works: make(map[string]*workv1.ManifestWork)}
+}
+
+// workKey returns the cache key of a ManifestWork
+func workKey(consumerName, name string) string {
+	return consumerName + "/" + name
+}
+
+// get returns a copy of the cached ManifestWork and whether the cache is synced
+func (w *workCache) get(consumerName, name string) (*workv1.ManifestWork, bool) {
+	if w == nil {
+		return nil, false
+	}
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	if !w.synced {
+		return nil, false
+	}
+	if work, ok := w.works[workKey(consumerName, name)]; ok {
+		return work.DeepCopy(), true
+	}
+	return nil, true
+}
+
+// list returns copies of the cached ManifestWorks of a consumer and whether the cache is synced
+func (w *workCache) list(consumerName string) ([]workv1.ManifestWork, bool) {
+	if w == nil {
+		return nil, false
+	}
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	if !w.synced {
+		return nil, false
+	}
+	var works []workv1.ManifestWork
+	for _, work := range w.works {
+		if work.Namespace == consumerName {
+			works = append(works, *work.DeepCopy())
+		}
+	}
+	return works, true
+}
+
+// upsert stores a ManifestWork and returns the previously cached one (nil if none).
+// A ManifestWork older than the cached one is not stored and upsert returns false, so late
+// watch events cannot replace the result of a newer create or patch.
+func (w *workCache) upsert(work *workv1.ManifestWork) (*workv1.ManifestWork, bool) {
+	if w == nil || work == nil {
+		return nil, false
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	key := workKey(work.Namespace, work.Name)
+	previous := w.works[key]
+	if previous != nil && isOlderWork(work, previous) {
+		return previous, false
+	}
+	w.works[key] = work.DeepCopy()
+	return previous, true
+}
+
+// isOlderWork reports whether work is an older version of cached, by generation or by
+// resourceVersion. Maestro resourceVersions are integers; non-numeric ones are not compared.
+func isOlderWork(work, cached *workv1.ManifestWork) bool {
+	if work.Generation < cached.Generation {
+		return true
+	}
+	workRV, err := strconv.ParseInt(work.ResourceVersion, 10, 64)
+	if err != nil {
+		return false
+	}
+	cachedRV, err := strconv.ParseInt(cached.ResourceVersion, 10, 64)
+	if err != nil {
+		return false
+	}
+	return workRV < cachedRV
+}
+
+// remove drops a ManifestWork from the cache
+func (w *workCache) remove(consumerName, name string) {
+	if w == nil {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	delete(w.works, workKey(consumerName, name))
+}
+
+// retain drops the cached ManifestWorks missing from works (deleted while the watch was down)
+func (w *workCache) retain(works []workv1.ManifestWork) {
+	if w == nil {
+		return
+	}
+	keep := make(map[string]bool, len(works))
+	for i := range works {
+		keep[workKey(works[i].Namespace, works[i].Name)] = true
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for key := range w.works {
+		if !keep[key] {
+			delete(w.works, key)
+		}
+	}
+}
+
+// setSynced marks whether the cache is kept up to date by a running watch
+func (w *workCache) setSynced(synced bool) {
+	if w == nil {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.synced = synced
+}
+
+// cachedManifestWork returns a ManifestWork from the status cache while the status watch runs,
+// falling back to the Maestro API when the cache is not synced or misses
+func (c *Client) cachedManifestWork(ctx context.Context, consumerName, name string) (*workv1.ManifestWork, error) {
+	if work, synced := c.cache.get(consumerName, name); synced && work != nil {
+		return work, nil
+	}
+	return c.GetManifestWork(ctx, consumerName, name)
+}
+
+// cachedManifestWorks returns the ManifestWorks of a consumer from the status cache while the
+// status watch runs, falling back to the Maestro API when the cache is not synced
+func (c *Client) cachedManifestWorks(ctx context.Context, consumerName string) (*workv1.ManifestWorkList, error) {
+	if works, synced := c.cache.list(consumerName); synced {
+		return &workv1.ManifestWorkList{Items: works}, nil
+	}
+	return c.ListManifestWorks(ctx, consumerName, "")
+}
+
+// WatchStatus watches the ManifestWorks of the adapter's source across all consumers and keeps
+// the status cache serving GetResource and DiscoverResources up to date, until the context is
+// cancelled. onChange (optional) is called for each added or modified ManifestWork.
+// Every watch start is a full resync: the Maestro watcher replays all works when a watch is
+// opened and does not resume from a resourceVersion. A closed or failed watch is re-established
+// after DefaultWatchRetryInterval; meanwhile reads go to the Maestro API.
+func (c *Client) WatchStatus(ctx context.Context, onChange StatusChangeFunc) error {
+	if c.cache == nil {
+		return fmt.Errorf("maestro client has no status cache")
+	}
+
+	for {
+		err := c.watchStatus(ctx, onChange)
+		c.cache.setSynced(false)
+		if ctx.Err() != nil {
+			c.log.Info(ctx, "ManifestWork status watch stopped")
+			return nil
+		}
+		if err != nil {
+			errCtx := logger.WithErrorField(ctx, err)
+			c.log.Warnf(errCtx, "ManifestWork status watch failed, retrying in %s", DefaultWatchRetryInterval)
+		}
+
+		select {
+		case <-ctx.Done():
+			c.log.Info(ctx, "ManifestWork status watch stopped")
+			return nil
+		case <-time.After(DefaultWatchRetryInterval):
+		}
+	}
+}
+
+// watchStatus resyncs the status cache with a full list, then applies watch events until the
+// watch closes
+func (c *Client) watchStatus(ctx context.Context, onChange StatusChangeFunc) error {
+	list, err := c.workClient.ManifestWorks(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list ManifestWorks: %w", err)
+	}
+	c.cache.retain(list.Items)
+	for i := range list.Items {
+		c.handleStatusChange(ctx, &list.Items[i], onChange)
+	}
+
+	watcher, err := c.workClient.ManifestWorks(metav1.NamespaceAll).Watch(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to watch ManifestWorks: %w", err)
+	}
+	defer watcher.Stop()
+
+	c.cache.setSynced(true)
+	c.log.Infof(ctx, "ManifestWork status watch started: %d ManifestWorks cached", len(list.Items))
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case evt, ok := <-watcher.ResultChan():
+			if !ok {
+				return fmt.Errorf("watch channel closed")
+			}
+			switch evt.Type {
+			case watch.Added, watch.Modified:
+				if work, ok := evt.Object.(*workv1.ManifestWork); ok {
+					c.handleStatusChange(ctx, work, onChange)
+				}
+			case watch.Deleted:
+				if work, ok := evt.Object.(*workv1.ManifestWork); ok {
+					c.cache.remove(work.Namespace, work.Name)
+				}
+			case watch.Error:
+				return fmt.Errorf("watch error: %v", evt.Object)
+			}
+		}
+	}
+}
+
+// handleStatusChange stores a watched ManifestWork and notifies onChange.
+// Events older than the cached ManifestWork are ignored.
+func (c *Client) handleStatusChange(ctx context.Context, work *workv1.ManifestWork, onChange StatusChangeFunc) {
+	previous, stored := c.cache.upsert(work)
+	if !stored {
+		c.log.Debugf(ctx, "Ignoring stale ManifestWork %s/%s: resourceVersion=%s generation=%d",
+			work.Namespace, work.Name, work.ResourceVersion, work.Generation)
+		return
+	}
+	if onChange != nil {
+		onChange(ctx, previous, work)
+	}
+}
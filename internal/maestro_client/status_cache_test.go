@@ -0,0 +1,145 @@
+package maestro_client
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	workfake "open-cluster-management.io/api/client/work/clientset/versioned/fake"
+	workv1 "open-cluster-management.io/api/work/v1"
+)
+
+func newStatusTestWork(consumer, name string) *workv1.ManifestWork {
+	return &workv1.ManifestWork{ObjectMeta: metav1.ObjectMeta{Namespace: consumer, Name: name}}
+}
+
+func TestWorkCache(t *testing.T) {
+	cache := newWorkCache()
+	cache.upsert(newStatusTestWork("cluster-a", "work-1"))
+
+	// Reads are not served until synced
+	_, synced := cache.get("cluster-a", "work-1")
+	assert.False(t, synced)
+	_, synced = cache.list("cluster-a")
+	assert.False(t, synced)
+
+	cache.setSynced(true)
+	work, synced := cache.get("cluster-a", "work-1")
+	assert.True(t, synced)
+	require.NotNil(t, work)
+	work.Labels = map[string]string{"mutated": "true"}
+	cached, _ := cache.get("cluster-a", "work-1")
+	assert.Empty(t, cached.Labels, "reads return copies")
+
+	cache.upsert(newStatusTestWork("cluster-b", "work-2"))
+	works, _ := cache.list("cluster-a")
+	assert.Len(t, works, 1)
+
+	cache.retain([]workv1.ManifestWork{*newStatusTestWork("cluster-b", "work-2")})
+	work, synced = cache.get("cluster-a", "work-1")
+	assert.True(t, synced)
+	assert.Nil(t, work)
+
+	var nilCache *workCache
+	_, synced = nilCache.get("cluster-a", "work-1")
+	assert.False(t, synced)
+}
+
+func TestWatchStatus(t *testing.T) {
+	fakeClient := workfake.NewSimpleClientset(newStatusTestWork("cluster-a", "work-1"))
+	c := &Client{workClient: fakeClient.WorkV1(), log: logger.NewTestLogger(), cache: newWorkCache()}
+
+	var mu sync.Mutex
+	var changes [][2]*workv1.ManifestWork
+	onChange := func(_ context.Context, previous, current *workv1.ManifestWork) {
+		mu.Lock()
+		defer mu.Unlock()
+		changes = append(changes, [2]*workv1.ManifestWork{previous, current})
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- c.WatchStatus(ctx, onChange) }()
+
+	require.Eventually(t, func() bool {
+		_, synced := c.cache.get("cluster-a", "work-1")
+		return synced
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// Status updates reach the cache and the change handler
+	updated := newStatusTestWork("cluster-a", "work-1")
+	updated.Status.Conditions = []metav1.Condition{{Type: workv1.WorkAvailable, Status: metav1.ConditionTrue, Reason: "Available"}}
+	_, err := fakeClient.WorkV1().ManifestWorks("cluster-a").UpdateStatus(ctx, updated, metav1.UpdateOptions{})
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		obj, err := c.GetResource(ctx, schema.GroupVersionKind{
+			Group: workv1.GroupName, Version: "v1", Kind: "ManifestWork",
+		}, "", "work-1", &TransportContext{ConsumerName: "cluster-a"})
+		if err != nil {
+			return false
+		}
+		conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+		return len(conditions) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	mu.Lock()
+	require.NotEmpty(t, changes)
+	assert.Nil(t, changes[0][0], "the first sighting has no previous state")
+	last := changes[len(changes)-1]
+	assert.NotNil(t, last[0])
+	assert.Len(t, last[1].Status.Conditions, 1)
+	mu.Unlock()
+
+	// Deleted works are dropped from the cache
+	require.NoError(t, fakeClient.WorkV1().ManifestWorks("cluster-a").Delete(ctx, "work-1", metav1.DeleteOptions{}))
+	require.Eventually(t, func() bool {
+		works, synced := c.cache.list("cluster-a")
+		return synced && len(works) == 0
+	}, 5*time.Second, 10*time.Millisecond)
+
+	cancel()
+	require.NoError(t, <-done)
+	_, synced := c.cache.get("cluster-a", "work-1")
+	assert.False(t, synced)
+}
+
+func TestWorkCacheIgnoresStaleUpdates(t *testing.T) {
+	cache := newWorkCache()
+	cache.setSynced(true)
+
+	patched := newStatusTestWork("cluster-a", "work-1")
+	patched.ResourceVersion = "5"
+	patched.Generation = 2
+	_, stored := cache.upsert(patched)
+	assert.True(t, stored)
+
+	// A late watch event with an older resourceVersion does not replace the patched work
+	late := newStatusTestWork("cluster-a", "work-1")
+	late.ResourceVersion = "4"
+	late.Generation = 2
+	previous, stored := cache.upsert(late)
+	assert.False(t, stored)
+	assert.Equal(t, "5", previous.ResourceVersion)
+
+	// Nor does one with an older generation
+	late.ResourceVersion = "6"
+	late.Generation = 1
+	_, stored = cache.upsert(late)
+	assert.False(t, stored)
+
+	newer := newStatusTestWork("cluster-a", "work-1")
+	newer.ResourceVersion = "7"
+	newer.Generation = 2
+	_, stored = cache.upsert(newer)
+	assert.True(t, stored)
+	work, _ := cache.get("cluster-a", "work-1")
+	assert.Equal(t, "7", work.ResourceVersion)
+}
@@ -0,0 +1,306 @@
+package manifest
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/pkg/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// Chart is a local Helm chart loaded into memory.
+// Only the parts needed to render manifests are kept: Chart.yaml metadata,
+// values.yaml defaults and the files under templates/.
+type Chart struct {
+	// Name is the chart name from Chart.yaml
+	Name string
+	// Version is the chart version from Chart.yaml
+	Version string
+	// AppVersion is the application version from Chart.yaml
+	AppVersion string
+	// Values holds the default values from values.yaml
+	Values map[string]interface{}
+	// Templates maps template paths relative to the chart root (e.g., "templates/deployment.yaml") to content
+	Templates map[string]string
+}
+
+// ChartRelease describes the release a chart is rendered for (the .Release object in templates)
+type ChartRelease struct {
+	Name      string
+	Namespace string
+}
+
+// chartMetadata is the subset of Chart.yaml used for rendering
+type chartMetadata struct {
+	Name       string `yaml:"name"`
+	Version    string `yaml:"version"`
+	AppVersion string `yaml:"appVersion"`
+}
+
+// LoadChart loads a local Helm chart directory.
+// The directory must contain a Chart.yaml; values.yaml and templates/ are optional.
+// Symlinks are followed as long as they resolve inside the chart directory, so charts mounted
+// from ConfigMaps load; entries starting with ".." (the ConfigMap volume's ..data and timestamped
+// directories) and symlinked subdirectories of templates/ are skipped.
+// Subcharts (charts/) are not supported.
+func LoadChart(dir string) (*Chart, error) {
+	root, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve chart directory: %w", err)
+	}
+
+	metaData, err := readChartFile(root, "Chart.yaml")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Chart.yaml: %w", err)
+	}
+	var meta chartMetadata
+	if err := yaml.Unmarshal(metaData, &meta); err != nil {
+		return nil, fmt.Errorf("failed to parse Chart.yaml: %w", err)
+	}
+	if meta.Name == "" {
+		return nil, fmt.Errorf("chart in %q has no name in Chart.yaml", dir)
+	}
+
+	chart := &Chart{
+		Name:       meta.Name,
+		Version:    meta.Version,
+		AppVersion: meta.AppVersion,
+		Values:     map[string]interface{}{},
+		Templates:  map[string]string{},
+	}
+
+	valuesData, err := readChartFile(root, "values.yaml")
+	switch {
+	case err == nil:
+		if err := yaml.Unmarshal(valuesData, &chart.Values); err != nil {
+			return nil, fmt.Errorf("failed to parse values.yaml: %w", err)
+		}
+		if chart.Values == nil {
+			chart.Values = map[string]interface{}{}
+		}
+	case !os.IsNotExist(err):
+		return nil, fmt.Errorf("failed to read values.yaml: %w", err)
+	}
+
+	// WalkDir does not follow a symlinked root, so the templates directory is resolved first
+	templatesDir, err := resolveChartPath(root, filepath.Join(root, "templates"))
+	if os.IsNotExist(err) {
+		return chart, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load chart templates: %w", err)
+	}
+	err = filepath.WalkDir(templatesDir, func(p string, d fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		if p != templatesDir && strings.HasPrefix(d.Name(), "..") {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			return nil
+		}
+		target := p
+		if d.Type()&fs.ModeSymlink != 0 {
+			if target, err = resolveChartPath(root, p); err != nil {
+				return err
+			}
+			info, err := os.Stat(target)
+			if err != nil {
+				return err
+			}
+			if !info.Mode().IsRegular() {
+				return nil
+			}
+		}
+		content, err := os.ReadFile(target)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(templatesDir, p)
+		if err != nil {
+			return err
+		}
+		chart.Templates[path.Join("templates", filepath.ToSlash(rel))] = string(content)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load chart templates: %w", err)
+	}
+
+	return chart, nil
+}
+
+// readChartFile reads a file of the chart directory, following symlinks inside the chart
+func readChartFile(root, name string) ([]byte, error) {
+	p, err := resolveChartPath(root, filepath.Join(root, name))
+	if err != nil {
+		return nil, err
+	}
+	return os.ReadFile(p)
+}
+
+// resolveChartPath resolves the symlinks of a path of the chart directory, rejecting paths that
+// resolve outside of it. Missing paths are returned as not-exist errors.
+func resolveChartPath(root, p string) (string, error) {
+	resolved, err := filepath.EvalSymlinks(p)
+	if err != nil {
+		return "", err
+	}
+	rel, err := filepath.Rel(root, resolved)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("%q resolves outside the chart directory", p)
+	}
+	return resolved, nil
+}
+
+// Render renders the chart templates with the given values merged over the chart defaults.
+// Like Helm, files whose name starts with "_" are partials (only usable via include/template)
+// and NOTES.txt is ignored. Every rendered YAML document that is not empty is returned as an object,
+// in template path order. A chart rendering no objects is an error.
+func (c *Chart) Render(release ChartRelease, values map[string]interface{}) ([]map[string]interface{}, error) {
+	data := map[string]interface{}{
+		"Values": MergeValues(c.Values, values),
+		"Release": map[string]interface{}{
+			"Name":      release.Name,
+			"Namespace": release.Namespace,
+			"Service":   "Helm",
+			"IsInstall": true,
+			"IsUpgrade": false,
+		},
+		"Chart": map[string]interface{}{
+			"Name":       c.Name,
+			"Version":    c.Version,
+			"AppVersion": c.AppVersion,
+		},
+	}
+
+	names := make([]string, 0, len(c.Templates))
+	for name := range c.Templates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	tmpl := template.New(c.Name).Option("missingkey=zero")
+	funcs := chartFuncs(tmpl)
+	tmpl.Funcs(funcs)
+	for _, name := range names {
+		if _, err := tmpl.New(name).Parse(c.Templates[name]); err != nil {
+			return nil, fmt.Errorf("failed to parse chart template %s: %w", name, err)
+		}
+	}
+
+	// Helm renders missing values as an empty string
+	utils.RenderMissingAsEmpty(tmpl)
+
+	var objects []map[string]interface{}
+	for _, name := range names {
+		base := path.Base(name)
+		if strings.HasPrefix(base, "_") || base == "NOTES.txt" {
+			continue
+		}
+
+		var buf bytes.Buffer
+		if err := tmpl.ExecuteTemplate(&buf, name, data); err != nil {
+			return nil, fmt.Errorf("failed to render chart template %s: %w", name, err)
+		}
+
+		docs, err := parseYAMLDocuments(buf.Bytes())
+		if err != nil {
+			return nil, fmt.Errorf("chart template %s produced invalid YAML: %w", name, err)
+		}
+		objects = append(objects, docs...)
+	}
+
+	if len(objects) == 0 {
+		return nil, fmt.Errorf("chart %s rendered no objects", c.Name)
+	}
+	return objects, nil
+}
+
+// chartFuncs returns the template functions for chart rendering: the standard template
+// functions plus Helm's include and tpl, which need access to the template set.
+func chartFuncs(root *template.Template) template.FuncMap {
+	funcs := make(template.FuncMap, len(utils.TemplateFuncs)+2)
+	for name, fn := range utils.TemplateFuncs {
+		funcs[name] = fn
+	}
+	funcs["include"] = func(name string, data interface{}) (string, error) {
+		var buf bytes.Buffer
+		if err := root.ExecuteTemplate(&buf, name, data); err != nil {
+			return "", err
+		}
+		return buf.String(), nil
+	}
+	// tpl parses the text as a standalone template; named templates remain reachable through include
+	funcs["tpl"] = func(text string, data interface{}) (string, error) {
+		t, err := template.New("tpl").Option("missingkey=zero").Funcs(funcs).Parse(text)
+		if err != nil {
+			return "", err
+		}
+		utils.RenderMissingAsEmpty(t)
+		var buf bytes.Buffer
+		if err := t.Execute(&buf, data); err != nil {
+			return "", err
+		}
+		return buf.String(), nil
+	}
+	return funcs
+}
+
+// parseYAMLDocuments parses a rendered multi-document YAML stream into objects.
+// Empty documents are skipped; every other document must be a mapping.
+func parseYAMLDocuments(data []byte) ([]map[string]interface{}, error) {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	var objects []map[string]interface{}
+	for i := 0; ; i++ {
+		var doc interface{}
+		err := decoder.Decode(&doc)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("document %d: %w", i, err)
+		}
+		if doc == nil {
+			continue
+		}
+		obj, ok := doc.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("document %d: expected a mapping, got %T", i, doc)
+		}
+		objects = append(objects, obj)
+	}
+	return objects, nil
+}
+
+// MergeValues deep-merges overrides into base and returns the result; neither input is modified.
+// Nested maps are merged key by key; any other override value replaces the base value.
+func MergeValues(base, overrides map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(base)+len(overrides))
+	for k, v := range base {
+		result[k] = v
+	}
+	for k, v := range overrides {
+		if overrideMap, ok := v.(map[string]interface{}); ok {
+			if baseMap, ok := result[k].(map[string]interface{}); ok {
+				result[k] = MergeValues(baseMap, overrideMap)
+				continue
+			}
+		}
+		result[k] = v
+	}
+	return result
+}
@@ -0,0 +1,228 @@
+package manifest
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeChart writes a chart directory from a map of relative file paths to content
+func writeChart(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0755))
+		require.NoError(t, os.WriteFile(p, []byte(content), 0644))
+	}
+	return dir
+}
+
+func TestLoadChart(t *testing.T) {
+	t.Run("loads metadata, values and templates", func(t *testing.T) {
+		dir := writeChart(t, map[string]string{
+			"Chart.yaml":                "name: addon\nversion: 1.2.3\nappVersion: \"4.5\"\n",
+			"values.yaml":               "replicas: 1\n",
+			"templates/deployment.yaml": "kind: Deployment\n",
+			"templates/_helpers.tpl":    "{{- define \"addon.name\" -}}addon{{- end -}}\n",
+		})
+
+		chart, err := LoadChart(dir)
+		require.NoError(t, err)
+		assert.Equal(t, "addon", chart.Name)
+		assert.Equal(t, "1.2.3", chart.Version)
+		assert.Equal(t, "4.5", chart.AppVersion)
+		assert.Equal(t, 1, chart.Values["replicas"])
+		assert.Len(t, chart.Templates, 2)
+		assert.Contains(t, chart.Templates, "templates/deployment.yaml")
+	})
+
+	t.Run("values.yaml and templates are optional", func(t *testing.T) {
+		dir := writeChart(t, map[string]string{"Chart.yaml": "name: addon\n"})
+
+		chart, err := LoadChart(dir)
+		require.NoError(t, err)
+		assert.Empty(t, chart.Values)
+		assert.Empty(t, chart.Templates)
+	})
+
+	t.Run("follows symlinks inside the chart", func(t *testing.T) {
+		dir := writeChart(t, map[string]string{
+			"Chart.yaml":                  "name: addon\n",
+			"..data/templates/cm.yaml":    "kind: ConfigMap\n",
+			"..data/templates/sa.yaml":    "kind: ServiceAccount\n",
+			"..data/templates/nested/x.y": "kind: Secret\n",
+		})
+		// A symlinked templates root with a symlinked file, like a mounted volume
+		require.NoError(t, os.Symlink(filepath.Join("..data", "templates"), filepath.Join(dir, "templates")))
+		require.NoError(t, os.Symlink("cm.yaml", filepath.Join(dir, "..data", "templates", "link.yaml")))
+
+		chart, err := LoadChart(dir)
+		require.NoError(t, err)
+		assert.Equal(t, "kind: ConfigMap\n", chart.Templates["templates/cm.yaml"])
+		assert.Equal(t, "kind: ConfigMap\n", chart.Templates["templates/link.yaml"])
+		assert.Contains(t, chart.Templates, "templates/sa.yaml")
+		assert.Contains(t, chart.Templates, "templates/nested/x.y")
+	})
+
+	t.Run("rejects symlinks outside the chart", func(t *testing.T) {
+		outside := filepath.Join(t.TempDir(), "secret.yaml")
+		require.NoError(t, os.WriteFile(outside, []byte("kind: Secret\n"), 0644))
+		dir := writeChart(t, map[string]string{"Chart.yaml": "name: addon\n", "templates/cm.yaml": "kind: ConfigMap\n"})
+		require.NoError(t, os.Symlink(outside, filepath.Join(dir, "templates", "secret.yaml")))
+
+		_, err := LoadChart(dir)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "outside the chart directory")
+	})
+
+	t.Run("missing Chart.yaml", func(t *testing.T) {
+		_, err := LoadChart(t.TempDir())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Chart.yaml")
+	})
+
+	t.Run("Chart.yaml without name", func(t *testing.T) {
+		dir := writeChart(t, map[string]string{"Chart.yaml": "version: 1.0.0\n"})
+
+		_, err := LoadChart(dir)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "has no name")
+	})
+}
+
+func TestChartRender(t *testing.T) {
+	dir := writeChart(t, map[string]string{
+		"Chart.yaml":  "name: addon\nversion: 1.0.0\n",
+		"values.yaml": "replicas: 1\nimage:\n  repository: quay.io/addon\n  tag: v1\n",
+		"templates/_helpers.tpl": `{{- define "addon.fullname" -}}
+{{ .Release.Name }}-{{ .Chart.Name }}
+{{- end -}}`,
+		"templates/configmap.yaml": `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: {{ include "addon.fullname" . }}
+  namespace: {{ .Release.Namespace }}
+data:
+  image: {{ tpl "{{ .Values.image.repository }}:{{ .Values.image.tag }}" . }}
+  missing: "{{ .Values.notSet }}"
+  literal: "{{ .Values.literal }}"
+  tplMissing: "{{ tpl "{{ .Values.notSet }}" . }}"
+---
+# empty document
+---
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: {{ include "addon.fullname" . }}
+`,
+		"templates/deployment.yaml": `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: {{ include "addon.fullname" . }}
+spec:
+  replicas: {{ .Values.replicas }}
+`,
+		"templates/NOTES.txt": "Installed {{ .Release.Name }}",
+	})
+
+	chart, err := LoadChart(dir)
+	require.NoError(t, err)
+
+	objects, err := chart.Render(ChartRelease{Name: "cls1", Namespace: "addons"}, map[string]interface{}{
+		"replicas": 3,
+		"image":    map[string]interface{}{"tag": "v2"},
+		"literal":  "<no value>",
+	})
+	require.NoError(t, err)
+	require.Len(t, objects, 3, "empty documents, partials and NOTES.txt produce no objects")
+
+	// Templates are rendered in path order: configmap.yaml before deployment.yaml
+	assert.Equal(t, "ConfigMap", objects[0]["kind"])
+	metadata := objects[0]["metadata"].(map[string]interface{})
+	assert.Equal(t, "cls1-addon", metadata["name"])
+	assert.Equal(t, "addons", metadata["namespace"])
+	data := objects[0]["data"].(map[string]interface{})
+	assert.Equal(t, "quay.io/addon:v2", data["image"], "overrides are deep-merged over defaults")
+	assert.Equal(t, "", data["missing"])
+	assert.Equal(t, "", data["tplMissing"])
+	assert.Equal(t, "<no value>", data["literal"], "values that look like text/template's placeholder are kept")
+
+	assert.Equal(t, "ServiceAccount", objects[1]["kind"])
+	assert.Equal(t, "Deployment", objects[2]["kind"])
+	assert.Equal(t, 3, objects[2]["spec"].(map[string]interface{})["replicas"])
+}
+
+func TestChartRenderErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		errMsg   string
+	}{
+		{
+			name:     "parse error",
+			template: "{{ .Values.x",
+			errMsg:   "failed to parse chart template",
+		},
+		{
+			name:     "required value",
+			template: `name: {{ required "name is required" .Values.name }}`,
+			errMsg:   "name is required",
+		},
+		{
+			name:     "no objects",
+			template: "# nothing enabled\n",
+			errMsg:   "rendered no objects",
+		},
+		{
+			name:     "non-mapping document",
+			template: "- a\n- b\n",
+			errMsg:   "expected a mapping",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chart := &Chart{
+				Name:      "addon",
+				Values:    map[string]interface{}{},
+				Templates: map[string]string{"templates/obj.yaml": tt.template},
+			}
+			_, err := chart.Render(ChartRelease{Name: "r"}, nil)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+}
+
+func TestMergeValues(t *testing.T) {
+	base := map[string]interface{}{
+		"a": 1,
+		"nested": map[string]interface{}{
+			"x": "base",
+			"y": "base",
+		},
+		"list": []interface{}{"a"},
+	}
+	overrides := map[string]interface{}{
+		"nested": map[string]interface{}{"y": "override"},
+		"list":   []interface{}{"b"},
+		"new":    true,
+	}
+
+	merged := MergeValues(base, overrides)
+
+	assert.Equal(t, map[string]interface{}{
+		"a": 1,
+		"nested": map[string]interface{}{
+			"x": "base",
+			"y": "override",
+		},
+		"list": []interface{}{"b"},
+		"new":  true,
+	}, merged)
+	assert.Equal(t, "base", base["nested"].(map[string]interface{})["y"], "base must not be modified")
+}
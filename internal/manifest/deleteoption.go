@@ -0,0 +1,65 @@
+package manifest
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	workv1 "open-cluster-management.io/api/work/v1"
+)
+
+// SetDeleteOption sets the ManifestWork's spec.deleteOption propagation policy.
+// For the SelectivelyOrphan policy, every manifest nested in spec.workload.manifests matching one
+// of the orphan discoveries gets an orphaning rule; the other manifests are deleted with the
+// ManifestWork. Orphaning rules written in the template are kept.
+// Returns the updated ManifestWork and the number of orphaned manifests.
+// The input object is not modified.
+func SetDeleteOption(work map[string]interface{}, policy workv1.DeletePropagationPolicyType, orphans []Discovery) (map[string]interface{}, int, error) {
+	// Normalize to JSON types so the unstructured helpers can safely copy nested values
+	result, err := toJSONObject(work)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if err := unstructured.SetNestedField(result, string(policy), "spec", "deleteOption", "propagationPolicy"); err != nil {
+		return nil, 0, fmt.Errorf("failed to set spec.deleteOption: %w", err)
+	}
+	if policy != workv1.DeletePropagationPolicyTypeSelectivelyOrphan || len(orphans) == 0 {
+		return result, 0, nil
+	}
+
+	rules, _, err := unstructured.NestedSlice(result, "spec", "deleteOption", "selectivelyOrphans", "orphaningRules")
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read spec.deleteOption.selectivelyOrphans: %w", err)
+	}
+
+	matched := 0
+	for _, discovery := range orphans {
+		list, err := DiscoverNestedManifest(&unstructured.Unstructured{Object: result}, discovery)
+		if err != nil {
+			return nil, 0, err
+		}
+		for i := range list.Items {
+			identifier := resourceIdentifier(&list.Items[i])
+			if findOrphaningRule(rules, identifier) {
+				continue
+			}
+			rules = append(rules, identifier)
+			matched++
+		}
+	}
+
+	if err := unstructured.SetNestedSlice(result, rules, "spec", "deleteOption", "selectivelyOrphans", "orphaningRules"); err != nil {
+		return nil, 0, fmt.Errorf("failed to set spec.deleteOption.selectivelyOrphans: %w", err)
+	}
+	return result, matched, nil
+}
+
+// findOrphaningRule reports whether rules already holds an orphaning rule for a resource identifier
+func findOrphaningRule(rules []interface{}, identifier map[string]interface{}) bool {
+	for _, r := range rules {
+		if rule, ok := r.(map[string]interface{}); ok && sameResourceIdentifier(rule, identifier) {
+			return true
+		}
+	}
+	return false
+}
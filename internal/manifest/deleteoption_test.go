@@ -0,0 +1,53 @@
+package manifest
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	workv1 "open-cluster-management.io/api/work/v1"
+)
+
+func TestSetDeleteOption(t *testing.T) {
+	t.Run("sets the propagation policy", func(t *testing.T) {
+		work := testFeedbackWork()
+		result, matched, err := SetDeleteOption(work, workv1.DeletePropagationPolicyTypeOrphan, nil)
+		require.NoError(t, err)
+		assert.Equal(t, 0, matched)
+		assert.NotContains(t, work["spec"], "deleteOption", "the input is not modified")
+
+		policy, _, err := unstructured.NestedString(result, "spec", "deleteOption", "propagationPolicy")
+		require.NoError(t, err)
+		assert.Equal(t, "Orphan", policy)
+	})
+
+	t.Run("orphans the matching manifests", func(t *testing.T) {
+		work := testFeedbackWork()
+		work["spec"].(map[string]interface{})["deleteOption"] = map[string]interface{}{
+			"selectivelyOrphans": map[string]interface{}{
+				"orphaningRules": []interface{}{
+					map[string]interface{}{"group": "", "resource": "namespaces", "namespace": "", "name": "apps"},
+				},
+			},
+		}
+		orphans := []Discovery{
+			&DiscoveryConfig{ByName: "apps"},
+			&DiscoveryConfig{Namespace: "apps", ByName: "web"},
+		}
+		result, matched, err := SetDeleteOption(work, workv1.DeletePropagationPolicyTypeSelectivelyOrphan, orphans)
+		require.NoError(t, err)
+		assert.Equal(t, 1, matched, "existing rules are not duplicated")
+
+		deleteOption, err := json.Marshal(result["spec"].(map[string]interface{})["deleteOption"])
+		require.NoError(t, err)
+		assert.JSONEq(t, `{
+			"propagationPolicy": "SelectivelyOrphan",
+			"selectivelyOrphans": {"orphaningRules": [
+				{"group": "", "resource": "namespaces", "namespace": "", "name": "apps"},
+				{"group": "apps", "resource": "deployments", "namespace": "apps", "name": "web"}
+			]}
+		}`, string(deleteOption))
+	})
+}
@@ -0,0 +1,158 @@
+package manifest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/pkg/constants"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// specHashPrefix identifies the hash algorithm in the spec hash annotation
+const specHashPrefix = "sha256:"
+
+// DriftResult contains the result of comparing a desired resource against the live object
+type DriftResult struct {
+	// Drifted is true when the live object no longer matches the desired spec
+	Drifted bool
+	// SpecChanged is true when the desired spec differs from the spec recorded in the
+	// live object's spec hash annotation (the manifest changed without a generation bump)
+	SpecChanged bool
+	// Reason explains the result
+	Reason string
+}
+
+// desiredSpec returns the parts of an object that drift detection compares:
+// everything except apiVersion, kind, metadata and status, plus metadata.labels.
+func desiredSpec(obj map[string]interface{}) map[string]interface{} {
+	spec := make(map[string]interface{}, len(obj))
+	for k, v := range obj {
+		switch k {
+		case "apiVersion", "kind", "metadata", "status":
+			continue
+		}
+		spec[k] = v
+	}
+	if labels, found, _ := unstructured.NestedFieldNoCopy(obj, "metadata", "labels"); found && labels != nil {
+		spec["labels"] = labels
+	}
+	return spec
+}
+
+// hashValue returns the hex-encoded SHA-256 of the canonical JSON of v.
+// encoding/json sorts map keys, so equal values always hash the same.
+func hashValue(v interface{}) (string, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal spec: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return specHashPrefix + hex.EncodeToString(sum[:]), nil
+}
+
+// ComputeSpecHash returns the spec hash of a desired object, as stored in the
+// hyperfleet.io/spec-hash annotation.
+func ComputeSpecHash(obj *unstructured.Unstructured) (string, error) {
+	if obj == nil {
+		return "", fmt.Errorf("object cannot be nil")
+	}
+	return hashValue(desiredSpec(obj.Object))
+}
+
+// SetSpecHash computes the spec hash of a desired object and stores it in the
+// hyperfleet.io/spec-hash annotation.
+func SetSpecHash(obj *unstructured.Unstructured) error {
+	hash, err := ComputeSpecHash(obj)
+	if err != nil {
+		return err
+	}
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[constants.AnnotationSpecHash] = hash
+	obj.SetAnnotations(annotations)
+	return nil
+}
+
+// DetectDrift compares a desired object against the live object.
+//
+// The live object is projected onto the fields of the desired spec before hashing, so fields
+// added by the API server (defaults, status, managed metadata) do not count as drift, while
+// edited or removed managed fields do. The spec hash annotation of the live object is also
+// compared with the desired hash to detect manifest changes made without a generation bump.
+//
+// Values normalized by the API server (e.g., quantities "1000m" -> "1") differ from the
+// desired value and are reported as drift; manifests should use the canonical form.
+func DetectDrift(desired, live *unstructured.Unstructured) (DriftResult, error) {
+	if desired == nil || live == nil {
+		return DriftResult{}, fmt.Errorf("desired and live objects are required")
+	}
+
+	desiredJSON, err := toJSONObject(desiredSpec(desired.Object))
+	if err != nil {
+		return DriftResult{}, err
+	}
+	desiredHash, err := hashValue(desiredJSON)
+	if err != nil {
+		return DriftResult{}, err
+	}
+
+	if recorded := live.GetAnnotations()[constants.AnnotationSpecHash]; recorded != "" && recorded != desiredHash {
+		return DriftResult{
+			SpecChanged: true,
+			Reason:      "desired spec changed",
+		}, nil
+	}
+
+	liveJSON, err := toJSONObject(desiredSpec(live.Object))
+	if err != nil {
+		return DriftResult{}, err
+	}
+	liveHash, err := hashValue(projectOnto(desiredJSON, liveJSON))
+	if err != nil {
+		return DriftResult{}, err
+	}
+
+	if liveHash != desiredHash {
+		return DriftResult{
+			Drifted: true,
+			Reason:  "live object drifted from desired spec",
+		}, nil
+	}
+	return DriftResult{Reason: "no drift"}, nil
+}
+
+// projectOnto returns the parts of live that correspond to the fields present in desired.
+// Maps keep only the desired keys (missing keys stay missing); lists are projected element-wise
+// when their lengths match and returned as-is otherwise; scalars are returned as-is.
+func projectOnto(desired, live interface{}) interface{} {
+	switch d := desired.(type) {
+	case map[string]interface{}:
+		l, ok := live.(map[string]interface{})
+		if !ok {
+			return live
+		}
+		result := make(map[string]interface{}, len(d))
+		for k, dv := range d {
+			if lv, found := l[k]; found {
+				result[k] = projectOnto(dv, lv)
+			}
+		}
+		return result
+	case []interface{}:
+		l, ok := live.([]interface{})
+		if !ok || len(l) != len(d) {
+			return live
+		}
+		result := make([]interface{}, len(d))
+		for i := range d {
+			result[i] = projectOnto(d[i], l[i])
+		}
+		return result
+	default:
+		return live
+	}
+}
@@ -0,0 +1,144 @@
+package manifest
+
+import (
+	"testing"
+
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/pkg/constants"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func driftConfigMap(data map[string]interface{}, labels map[string]interface{}) *unstructured.Unstructured {
+	metadata := map[string]interface{}{
+		"name":        "cfg",
+		"namespace":   "ns",
+		"annotations": map[string]interface{}{constants.AnnotationGeneration: "1"},
+	}
+	if labels != nil {
+		metadata["labels"] = labels
+	}
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   metadata,
+		"data":       data,
+	}}
+}
+
+func TestComputeSpecHash(t *testing.T) {
+	a := driftConfigMap(map[string]interface{}{"a": "1", "b": "2"}, nil)
+	b := driftConfigMap(map[string]interface{}{"b": "2", "a": "1"}, nil)
+	b.SetAnnotations(map[string]string{"other": "annotation"})
+	b.SetResourceVersion("42")
+
+	hashA, err := ComputeSpecHash(a)
+	require.NoError(t, err)
+	hashB, err := ComputeSpecHash(b)
+	require.NoError(t, err)
+	assert.Equal(t, hashA, hashB, "metadata other than labels does not affect the hash")
+	assert.Contains(t, hashA, "sha256:")
+
+	c := driftConfigMap(map[string]interface{}{"a": "1", "b": "2"}, map[string]interface{}{"team": "core"})
+	hashC, err := ComputeSpecHash(c)
+	require.NoError(t, err)
+	assert.NotEqual(t, hashA, hashC, "labels are part of the hash")
+
+	require.NoError(t, SetSpecHash(a))
+	assert.Equal(t, hashA, a.GetAnnotations()[constants.AnnotationSpecHash])
+	assert.Equal(t, "1", a.GetAnnotations()[constants.AnnotationGeneration], "existing annotations are kept")
+}
+
+func TestDetectDrift(t *testing.T) {
+	desired := func() *unstructured.Unstructured {
+		obj := driftConfigMap(map[string]interface{}{"a": "1", "list": []interface{}{"x", "y"}}, map[string]interface{}{"team": "core"})
+		require.NoError(t, SetSpecHash(obj))
+		return obj
+	}
+
+	tests := []struct {
+		name             string
+		live             func() *unstructured.Unstructured
+		expectDrift      bool
+		expectSpecChange bool
+	}{
+		{
+			name: "unchanged live object with server-added fields",
+			live: func() *unstructured.Unstructured {
+				live := desired()
+				live.SetResourceVersion("10")
+				live.SetUID("uid")
+				labels := live.GetLabels()
+				labels["added-by-controller"] = "true"
+				live.SetLabels(labels)
+				live.Object["data"].(map[string]interface{})["extra"] = "server default"
+				return live
+			},
+		},
+		{
+			name: "edited field",
+			live: func() *unstructured.Unstructured {
+				live := desired()
+				live.Object["data"].(map[string]interface{})["a"] = "edited"
+				return live
+			},
+			expectDrift: true,
+		},
+		{
+			name: "removed field",
+			live: func() *unstructured.Unstructured {
+				live := desired()
+				delete(live.Object["data"].(map[string]interface{}), "a")
+				return live
+			},
+			expectDrift: true,
+		},
+		{
+			name: "removed label",
+			live: func() *unstructured.Unstructured {
+				live := desired()
+				live.SetLabels(nil)
+				return live
+			},
+			expectDrift: true,
+		},
+		{
+			name: "list changed",
+			live: func() *unstructured.Unstructured {
+				live := desired()
+				live.Object["data"].(map[string]interface{})["list"] = []interface{}{"x"}
+				return live
+			},
+			expectDrift: true,
+		},
+		{
+			name: "live object applied from a different spec",
+			live: func() *unstructured.Unstructured {
+				live := driftConfigMap(map[string]interface{}{"a": "old"}, nil)
+				require.NoError(t, SetSpecHash(live))
+				return live
+			},
+			expectSpecChange: true,
+		},
+		{
+			name: "live object without spec hash annotation",
+			live: func() *unstructured.Unstructured {
+				return driftConfigMap(map[string]interface{}{"a": "1", "list": []interface{}{"x", "y"}}, map[string]interface{}{"team": "core"})
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := DetectDrift(desired(), tt.live())
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectDrift, result.Drifted, result.Reason)
+			assert.Equal(t, tt.expectSpecChange, result.SpecChanged, result.Reason)
+		})
+	}
+
+	t.Run("nil objects", func(t *testing.T) {
+		_, err := DetectDrift(nil, desired())
+		assert.Error(t, err)
+	})
+}
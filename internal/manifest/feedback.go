@@ -0,0 +1,269 @@
+package manifest
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	workv1 "open-cluster-management.io/api/work/v1"
+)
+
+// ManifestStatusField is the field of a nested manifest holding the status the ManifestWork
+// agent reported for it in the parent's status.resourceStatus
+const ManifestStatusField = "manifestStatus"
+
+// simpleJSONPath matches JSONPaths made only of field names (e.g. .status.readyReplicas)
+var simpleJSONPath = regexp.MustCompile(`^(\.[A-Za-z0-9_-]+)+$`)
+
+// FeedbackJSONPath is a named JSONPath the ManifestWork agent reads from an applied manifest
+type FeedbackJSONPath struct {
+	Name string
+	Path string
+}
+
+// FeedbackRules describes the status feedback requested for a nested manifest
+type FeedbackRules struct {
+	JSONPaths       []FeedbackJSONPath
+	WellKnownStatus bool
+}
+
+// SetFeedbackRules requests status feedback in the ManifestWork's spec.manifestConfigs for every
+// manifest nested in spec.workload.manifests that matches the discovery criteria.
+// Rules are appended to an existing entry for the same resource, so manifestConfigs written in the
+// template are kept. Returns the updated ManifestWork and the number of matched manifests.
+// The input object is not modified.
+func SetFeedbackRules(work map[string]interface{}, discovery Discovery, rules FeedbackRules) (map[string]interface{}, int, error) {
+	// Normalize to JSON types so the unstructured helpers can safely copy nested values
+	result, err := toJSONObject(work)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	feedbackRules := buildFeedbackRules(rules)
+	if len(feedbackRules) == 0 {
+		return result, 0, nil
+	}
+
+	list, err := DiscoverNestedManifest(&unstructured.Unstructured{Object: result}, discovery)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(list.Items) == 0 {
+		return result, 0, nil
+	}
+
+	configs, _, err := unstructured.NestedSlice(result, "spec", "manifestConfigs")
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read spec.manifestConfigs: %w", err)
+	}
+
+	for i := range list.Items {
+		identifier := resourceIdentifier(&list.Items[i])
+		if entry := findManifestConfig(configs, identifier); entry != nil {
+			existing, _ := entry["feedbackRules"].([]interface{})
+			entry["feedbackRules"] = append(existing, feedbackRules...)
+			continue
+		}
+		configs = append(configs, map[string]interface{}{
+			"resourceIdentifier": identifier,
+			"feedbackRules":      feedbackRules,
+		})
+	}
+
+	if err := unstructured.SetNestedSlice(result, configs, "spec", "manifestConfigs"); err != nil {
+		return nil, 0, fmt.Errorf("failed to set spec.manifestConfigs: %w", err)
+	}
+	return result, len(list.Items), nil
+}
+
+// buildFeedbackRules returns the ManifestWork feedbackRules for the requested feedback
+func buildFeedbackRules(rules FeedbackRules) []interface{} {
+	var feedbackRules []interface{}
+	if len(rules.JSONPaths) > 0 {
+		jsonPaths := make([]interface{}, 0, len(rules.JSONPaths))
+		for _, p := range rules.JSONPaths {
+			jsonPaths = append(jsonPaths, map[string]interface{}{"name": p.Name, "path": p.Path})
+		}
+		feedbackRules = append(feedbackRules, map[string]interface{}{
+			"type":      string(workv1.JSONPathsType),
+			"jsonPaths": jsonPaths,
+		})
+	}
+	if rules.WellKnownStatus {
+		feedbackRules = append(feedbackRules, map[string]interface{}{
+			"type": string(workv1.WellKnownStatusType),
+		})
+	}
+	return feedbackRules
+}
+
+// resourceIdentifier returns the ManifestWork resourceIdentifier of a nested manifest.
+// The resource name is guessed from the kind, as the agent matches on the plural resource.
+func resourceIdentifier(obj *unstructured.Unstructured) map[string]interface{} {
+	gvk := obj.GroupVersionKind()
+	plural, _ := meta.UnsafeGuessKindToResource(gvk)
+	return map[string]interface{}{
+		"group":     gvk.Group,
+		"resource":  plural.Resource,
+		"namespace": obj.GetNamespace(),
+		"name":      obj.GetName(),
+	}
+}
+
+// findManifestConfig returns the manifestConfigs entry for a resource identifier, or nil
+func findManifestConfig(configs []interface{}, identifier map[string]interface{}) map[string]interface{} {
+	for _, c := range configs {
+		entry, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		existing, ok := entry["resourceIdentifier"].(map[string]interface{})
+		if ok && sameResourceIdentifier(existing, identifier) {
+			return entry
+		}
+	}
+	return nil
+}
+
+// sameResourceIdentifier reports whether two resource identifiers select the same resource
+func sameResourceIdentifier(a, b map[string]interface{}) bool {
+	for _, key := range []string{"group", "resource", "namespace", "name"} {
+		av, _ := a[key].(string)
+		bv, _ := b[key].(string)
+		if av != bv {
+			return false
+		}
+	}
+	return true
+}
+
+// ApplyManifestStatus maps the status the ManifestWork agent reported for a nested manifest
+// (its entry in the parent's status.resourceStatus.manifests) onto a copy of the manifest:
+//   - manifestStatus.conditions holds the manifest conditions, and manifestStatus.applied,
+//     manifestStatus.available and manifestStatus.degraded whether those conditions are True
+//   - manifestStatus.statusFeedback maps each feedback value name to its value
+//   - values of simple JSONPaths (e.g. .status.readyReplicas) are also set at that path, so
+//     expressions read them as on an object discovered through the kubernetes transport
+//
+// Returns obj itself when the parent has no status for it.
+func ApplyManifestStatus(work, obj *unstructured.Unstructured, jsonPaths []FeedbackJSONPath) (*unstructured.Unstructured, error) {
+	if work == nil || obj == nil {
+		return obj, nil
+	}
+	entry, err := findResourceStatus(work, obj)
+	if err != nil || entry == nil {
+		return obj, err
+	}
+
+	result := obj.DeepCopy()
+	status := map[string]interface{}{}
+
+	conditions, _ := entry["conditions"].([]interface{})
+	status["conditions"] = conditions
+	status["applied"] = conditionTrue(conditions, workv1.ManifestApplied)
+	status["available"] = conditionTrue(conditions, workv1.ManifestAvailable)
+	status["degraded"] = conditionTrue(conditions, workv1.ManifestDegraded)
+
+	paths := make(map[string]string, len(jsonPaths))
+	for _, p := range jsonPaths {
+		paths[p.Name] = p.Path
+	}
+
+	feedback := map[string]interface{}{}
+	values, _, _ := unstructured.NestedSlice(entry, "statusFeedback", "values")
+	for _, v := range values {
+		value, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _ := value["name"].(string)
+		fieldValue, _ := value["fieldValue"].(map[string]interface{})
+		if name == "" || fieldValue == nil {
+			continue
+		}
+		parsed, err := parseFeedbackValue(fieldValue)
+		if err != nil {
+			return nil, fmt.Errorf("status feedback %q of %s/%s: %w", name, obj.GetKind(), obj.GetName(), err)
+		}
+		feedback[name] = parsed
+
+		if path := paths[name]; simpleJSONPath.MatchString(path) {
+			fields := strings.Split(strings.TrimPrefix(path, "."), ".")
+			if err := unstructured.SetNestedField(result.Object, parsed, fields...); err != nil {
+				return nil, fmt.Errorf("status feedback %q of %s/%s: failed to set %s: %w",
+					name, obj.GetKind(), obj.GetName(), path, err)
+			}
+		}
+	}
+	status["statusFeedback"] = feedback
+
+	result.Object[ManifestStatusField] = status
+	return result, nil
+}
+
+// findResourceStatus returns the status.resourceStatus.manifests entry of work matching obj, or nil
+func findResourceStatus(work, obj *unstructured.Unstructured) (map[string]interface{}, error) {
+	manifests, found, err := unstructured.NestedSlice(work.Object, "status", "resourceStatus", "manifests")
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract status.resourceStatus.manifests from %q: %v", work.GetName(), err)
+	}
+	if !found {
+		return nil, nil
+	}
+
+	gvk := obj.GroupVersionKind()
+	for _, m := range manifests {
+		entry, ok := m.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		resourceMeta, _ := entry["resourceMeta"].(map[string]interface{})
+		group, _ := resourceMeta["group"].(string)
+		kind, _ := resourceMeta["kind"].(string)
+		namespace, _ := resourceMeta["namespace"].(string)
+		name, _ := resourceMeta["name"].(string)
+		if group == gvk.Group && kind == gvk.Kind && namespace == obj.GetNamespace() && name == obj.GetName() {
+			return entry, nil
+		}
+	}
+	return nil, nil
+}
+
+// conditionTrue reports whether the condition of the given type has status True
+func conditionTrue(conditions []interface{}, conditionType string) bool {
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if condition["type"] == conditionType {
+			return condition["status"] == "True"
+		}
+	}
+	return false
+}
+
+// parseFeedbackValue returns the value of a status feedback fieldValue
+func parseFeedbackValue(fieldValue map[string]interface{}) (interface{}, error) {
+	valueType, _ := fieldValue["type"].(string)
+	switch workv1.ValueType(valueType) {
+	case workv1.Integer:
+		return fieldValue["integer"], nil
+	case workv1.String:
+		return fieldValue["string"], nil
+	case workv1.Boolean:
+		return fieldValue["boolean"], nil
+	case workv1.JsonRaw:
+		raw, _ := fieldValue["jsonRaw"].(string)
+		var value interface{}
+		if err := json.Unmarshal([]byte(raw), &value); err != nil {
+			return nil, fmt.Errorf("invalid jsonRaw value: %w", err)
+		}
+		return value, nil
+	default:
+		return nil, fmt.Errorf("unsupported value type %q", valueType)
+	}
+}
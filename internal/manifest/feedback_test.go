@@ -0,0 +1,163 @@
+package manifest
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func testFeedbackWork() map[string]interface{} {
+	return map[string]interface{}{
+		"apiVersion": "work.open-cluster-management.io/v1",
+		"kind":       "ManifestWork",
+		"metadata":   map[string]interface{}{"name": "mw"},
+		"spec": map[string]interface{}{
+			"workload": map[string]interface{}{
+				"manifests": []interface{}{
+					map[string]interface{}{
+						"apiVersion": "apps/v1",
+						"kind":       "Deployment",
+						"metadata":   map[string]interface{}{"name": "web", "namespace": "apps"},
+						"spec":       map[string]interface{}{"replicas": 2},
+					},
+					map[string]interface{}{
+						"apiVersion": "v1",
+						"kind":       "Namespace",
+						"metadata":   map[string]interface{}{"name": "apps"},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestSetFeedbackRules(t *testing.T) {
+	rules := FeedbackRules{
+		JSONPaths:       []FeedbackJSONPath{{Name: "readyReplicas", Path: ".status.readyReplicas"}},
+		WellKnownStatus: true,
+	}
+
+	t.Run("adds a manifest config for each matching manifest", func(t *testing.T) {
+		work := testFeedbackWork()
+		result, matched, err := SetFeedbackRules(work, &DiscoveryConfig{Namespace: "apps", ByName: "web"}, rules)
+		require.NoError(t, err)
+		assert.Equal(t, 1, matched)
+		assert.NotContains(t, work["spec"], "manifestConfigs", "the input is not modified")
+
+		configs, err := json.Marshal(result["spec"].(map[string]interface{})["manifestConfigs"])
+		require.NoError(t, err)
+		assert.JSONEq(t, `[{
+			"resourceIdentifier": {"group": "apps", "resource": "deployments", "namespace": "apps", "name": "web"},
+			"feedbackRules": [
+				{"type": "JSONPaths", "jsonPaths": [{"name": "readyReplicas", "path": ".status.readyReplicas"}]},
+				{"type": "WellKnownStatus"}
+			]
+		}]`, string(configs))
+	})
+
+	t.Run("appends to an existing manifest config", func(t *testing.T) {
+		work := testFeedbackWork()
+		work["spec"].(map[string]interface{})["manifestConfigs"] = []interface{}{
+			map[string]interface{}{
+				"resourceIdentifier": map[string]interface{}{"group": "apps", "resource": "deployments", "namespace": "apps", "name": "web"},
+				"updateStrategy":     map[string]interface{}{"type": "ServerSideApply"},
+				"feedbackRules":      []interface{}{map[string]interface{}{"type": "WellKnownStatus"}},
+			},
+		}
+		result, matched, err := SetFeedbackRules(work, &DiscoveryConfig{ByName: "web"}, FeedbackRules{JSONPaths: rules.JSONPaths})
+		require.NoError(t, err)
+		assert.Equal(t, 1, matched)
+
+		configs, _, err := unstructured.NestedSlice(result, "spec", "manifestConfigs")
+		require.NoError(t, err)
+		require.Len(t, configs, 1)
+		entry := configs[0].(map[string]interface{})
+		assert.Contains(t, entry, "updateStrategy")
+		assert.Len(t, entry["feedbackRules"], 2)
+	})
+
+	t.Run("no match leaves the work unchanged", func(t *testing.T) {
+		result, matched, err := SetFeedbackRules(testFeedbackWork(), &DiscoveryConfig{ByName: "missing"}, rules)
+		require.NoError(t, err)
+		assert.Equal(t, 0, matched)
+		assert.NotContains(t, result["spec"], "manifestConfigs")
+	})
+}
+
+func TestApplyManifestStatus(t *testing.T) {
+	work := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "work.open-cluster-management.io/v1",
+		"kind":       "ManifestWork",
+		"metadata":   map[string]interface{}{"name": "mw"},
+		"status": map[string]interface{}{
+			"resourceStatus": map[string]interface{}{
+				"manifests": []interface{}{
+					map[string]interface{}{
+						"resourceMeta": map[string]interface{}{
+							"group": "apps", "version": "v1", "kind": "Deployment", "resource": "deployments",
+							"namespace": "apps", "name": "web",
+						},
+						"statusFeedback": map[string]interface{}{
+							"values": []interface{}{
+								map[string]interface{}{"name": "readyReplicas", "fieldValue": map[string]interface{}{"type": "Integer", "integer": int64(2)}},
+								map[string]interface{}{"name": "image", "fieldValue": map[string]interface{}{"type": "String", "string": "web:v1"}},
+								map[string]interface{}{"name": "conditions", "fieldValue": map[string]interface{}{"type": "JsonRaw", "jsonRaw": `[{"type":"Available"}]`}},
+							},
+						},
+						"conditions": []interface{}{
+							map[string]interface{}{"type": "Applied", "status": "True"},
+							map[string]interface{}{"type": "Available", "status": "True"},
+							map[string]interface{}{"type": "Degraded", "status": "False"},
+						},
+					},
+				},
+			},
+		},
+	}}
+	deployment := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata":   map[string]interface{}{"name": "web", "namespace": "apps"},
+		"spec":       map[string]interface{}{"replicas": int64(2)},
+	}}
+	jsonPaths := []FeedbackJSONPath{
+		{Name: "readyReplicas", Path: ".status.readyReplicas"},
+		{Name: "image", Path: ".spec.template.spec.containers[0].image"},
+	}
+
+	t.Run("maps feedback values and conditions", func(t *testing.T) {
+		result, err := ApplyManifestStatus(work, deployment, jsonPaths)
+		require.NoError(t, err)
+		assert.NotContains(t, deployment.Object, "status", "the input is not modified")
+
+		readyReplicas, found, err := unstructured.NestedInt64(result.Object, "status", "readyReplicas")
+		require.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, int64(2), readyReplicas)
+
+		status := result.Object[ManifestStatusField].(map[string]interface{})
+		assert.Equal(t, true, status["applied"])
+		assert.Equal(t, true, status["available"])
+		assert.Equal(t, false, status["degraded"])
+		assert.Len(t, status["conditions"], 3)
+		assert.Equal(t, map[string]interface{}{
+			"readyReplicas": int64(2),
+			"image":         "web:v1",
+			"conditions":    []interface{}{map[string]interface{}{"type": "Available"}},
+		}, status["statusFeedback"])
+	})
+
+	t.Run("manifest without status is returned as is", func(t *testing.T) {
+		namespace := &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "Namespace",
+			"metadata":   map[string]interface{}{"name": "apps"},
+		}}
+		result, err := ApplyManifestStatus(work, namespace, nil)
+		require.NoError(t, err)
+		assert.Same(t, namespace, result)
+	})
+}
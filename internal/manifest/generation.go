@@ -34,6 +34,11 @@ const (
 	OperationRecreate Operation = "recreate"
 	// OperationSkip indicates no operation is needed (generations match)
 	OperationSkip Operation = "skip"
+	// OperationCorrectDrift indicates the resource is reapplied because the live object
+	// drifted from the desired spec although generations match
+	OperationCorrectDrift Operation = "correct-drift"
+	// OperationDelete indicates the resource was deleted
+	OperationDelete Operation = "delete"
 )
 
 // ApplyDecision contains the decision about what operation to perform
@@ -0,0 +1,172 @@
+package manifest
+
+import (
+	"encoding/json"
+	"fmt"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	utiljson "k8s.io/apimachinery/pkg/util/json"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+// PatchType identifies how a patch document is applied to a manifest
+type PatchType string
+
+const (
+	// PatchTypeJSON is an RFC 6902 JSON patch: a list of add/remove/replace/move/copy/test operations
+	PatchTypeJSON PatchType = "json"
+	// PatchTypeStrategic is a Kubernetes strategic merge patch.
+	// Types unknown to the built-in scheme (e.g., CRDs) fall back to JSON merge patch semantics.
+	PatchTypeStrategic PatchType = "strategic"
+	// PatchTypeMerge is an RFC 7386 JSON merge patch
+	PatchTypeMerge PatchType = "merge"
+)
+
+// ApplyPatch applies a patch document to a manifest object and returns the patched object.
+// For PatchTypeJSON the patch is a list of operations; for the merge types it is an object.
+// The input object is not modified.
+func ApplyPatch(obj map[string]interface{}, patchType PatchType, patch interface{}) (map[string]interface{}, error) {
+	original, err := json.Marshal(obj)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal manifest: %w", err)
+	}
+	patchBytes, err := json.Marshal(patch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal patch: %w", err)
+	}
+
+	var patched []byte
+	switch patchType {
+	case PatchTypeJSON:
+		p, err := jsonpatch.DecodePatch(patchBytes)
+		if err != nil {
+			return nil, fmt.Errorf("invalid JSON patch: %w", err)
+		}
+		if patched, err = p.Apply(original); err != nil {
+			return nil, fmt.Errorf("failed to apply JSON patch: %w", err)
+		}
+	case PatchTypeStrategic:
+		dataStruct, err := strategicPatchSchema(obj)
+		if err != nil {
+			return nil, err
+		}
+		if dataStruct == nil {
+			patched, err = jsonpatch.MergePatch(original, patchBytes)
+		} else {
+			patched, err = strategicpatch.StrategicMergePatch(original, patchBytes, dataStruct)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to apply strategic merge patch: %w", err)
+		}
+	case PatchTypeMerge:
+		if patched, err = jsonpatch.MergePatch(original, patchBytes); err != nil {
+			return nil, fmt.Errorf("failed to apply merge patch: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported patch type %q", patchType)
+	}
+
+	var result map[string]interface{}
+	if err := utiljson.Unmarshal(patched, &result); err != nil {
+		return nil, fmt.Errorf("patched manifest is not an object: %w", err)
+	}
+	return result, nil
+}
+
+// strategicPatchSchema returns the typed Go struct describing the object's kind, which carries
+// the patch merge keys and strategies. Returns nil for kinds not in the built-in scheme.
+func strategicPatchSchema(obj map[string]interface{}) (runtime.Object, error) {
+	gvk := (&unstructured.Unstructured{Object: obj}).GroupVersionKind()
+	typed, err := scheme.Scheme.New(gvk)
+	if err != nil {
+		if runtime.IsNotRegisteredError(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to resolve schema for %s: %w", gvk, err)
+	}
+	return typed, nil
+}
+
+// PatchTarget selects the objects a patch applies to. Empty fields match any value;
+// an empty target selects only the top-level object (see PatchManifests).
+type PatchTarget struct {
+	Kind string
+	Name string
+}
+
+// Matches reports whether the object matches the target
+func (t PatchTarget) Matches(obj map[string]interface{}) bool {
+	u := &unstructured.Unstructured{Object: obj}
+	return (t.Kind == "" || t.Kind == u.GetKind()) && (t.Name == "" || t.Name == u.GetName())
+}
+
+// PatchManifests applies a patch to every object matching the target: the object itself and,
+// for a ManifestWork, the manifests nested in spec.workload.manifests.
+// An empty target patches only the object itself. Returns the patched object and the number of objects patched. The input object is not modified.
+func PatchManifests(obj map[string]interface{}, target PatchTarget, patchType PatchType, patch interface{}) (map[string]interface{}, int, error) {
+	// Normalize to JSON types so the unstructured helpers can safely copy nested values
+	result, err := toJSONObject(obj)
+	if err != nil {
+		return nil, 0, err
+	}
+	if target == (PatchTarget{}) {
+		patched, err := ApplyPatch(result, patchType, patch)
+		if err != nil {
+			return nil, 0, err
+		}
+		return patched, 1, nil
+	}
+	count := 0
+
+	if target.Matches(result) {
+		if result, err = ApplyPatch(result, patchType, patch); err != nil {
+			return nil, 0, err
+		}
+		count++
+	}
+
+	if kind, _ := result["kind"].(string); kind != "ManifestWork" {
+		return result, count, nil
+	}
+	manifests, found, err := unstructured.NestedSlice(result, "spec", "workload", "manifests")
+	if err != nil || !found {
+		return result, count, nil
+	}
+
+	nestedCount := 0
+	for i, item := range manifests {
+		nested, ok := item.(map[string]interface{})
+		if !ok || !target.Matches(nested) {
+			continue
+		}
+		patched, err := ApplyPatch(nested, patchType, patch)
+		if err != nil {
+			return nil, 0, fmt.Errorf("workload manifest %d: %w", i, err)
+		}
+		manifests[i] = patched
+		nestedCount++
+	}
+	if nestedCount > 0 {
+		if err := unstructured.SetNestedSlice(result, manifests, "spec", "workload", "manifests"); err != nil {
+			return nil, 0, fmt.Errorf("failed to update workload manifests: %w", err)
+		}
+	}
+	return result, count + nestedCount, nil
+}
+
+// toJSONObject returns a copy of obj holding only JSON types ([]interface{}, map[string]interface{}, ...).
+// Like unstructured objects, integers decode as int64 so large values keep their precision.
+func toJSONObject(obj map[string]interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal manifest: %w", err)
+	}
+	var result map[string]interface{}
+	if err := utiljson.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal manifest: %w", err)
+	}
+	return result, nil
+}
@@ -0,0 +1,225 @@
+package manifest
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testDeployment() map[string]interface{} {
+	return map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata":   map[string]interface{}{"name": "app"},
+		"spec": map[string]interface{}{
+			"replicas": 1,
+			"template": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"containers": []interface{}{
+						map[string]interface{}{"name": "app", "image": "app:v1"},
+						map[string]interface{}{"name": "sidecar", "image": "sidecar:v1"},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestApplyPatch(t *testing.T) {
+	tests := []struct {
+		name        string
+		obj         map[string]interface{}
+		patchType   PatchType
+		patch       interface{}
+		expected    string
+		expectError string
+	}{
+		{
+			name:      "json patch",
+			obj:       map[string]interface{}{"kind": "ConfigMap", "data": map[string]interface{}{"a": "1", "b": "2"}},
+			patchType: PatchTypeJSON,
+			patch: []interface{}{
+				map[string]interface{}{"op": "replace", "path": "/data/a", "value": "10"},
+				map[string]interface{}{"op": "remove", "path": "/data/b"},
+				map[string]interface{}{"op": "add", "path": "/data/c", "value": "3"},
+			},
+			expected: `{"kind": "ConfigMap", "data": {"a": "10", "c": "3"}}`,
+		},
+		{
+			name:      "json patch on missing path",
+			obj:       map[string]interface{}{"kind": "ConfigMap"},
+			patchType: PatchTypeJSON,
+			patch: []interface{}{
+				map[string]interface{}{"op": "replace", "path": "/data/a", "value": "10"},
+			},
+			expectError: "failed to apply JSON patch",
+		},
+		{
+			name:      "merge patch replaces lists and removes nulls",
+			obj:       map[string]interface{}{"kind": "Thing", "list": []interface{}{"a", "b"}, "drop": "x", "keep": "y"},
+			patchType: PatchTypeMerge,
+			patch:     map[string]interface{}{"list": []interface{}{"c"}, "drop": nil},
+			expected:  `{"kind": "Thing", "list": ["c"], "keep": "y"}`,
+		},
+		{
+			name:      "strategic merge patch merges containers by name",
+			obj:       testDeployment(),
+			patchType: PatchTypeStrategic,
+			patch: map[string]interface{}{
+				"spec": map[string]interface{}{
+					"replicas": 3,
+					"template": map[string]interface{}{
+						"spec": map[string]interface{}{
+							"containers": []interface{}{
+								map[string]interface{}{"name": "app", "image": "app:v2"},
+							},
+						},
+					},
+				},
+			},
+			expected: `{
+				"apiVersion": "apps/v1",
+				"kind": "Deployment",
+				"metadata": {"name": "app"},
+				"spec": {
+					"replicas": 3,
+					"template": {"spec": {"containers": [
+						{"name": "app", "image": "app:v2"},
+						{"name": "sidecar", "image": "sidecar:v1"}
+					]}}
+				}
+			}`,
+		},
+		{
+			name:      "strategic merge patch on unknown kind falls back to merge patch",
+			obj:       map[string]interface{}{"apiVersion": "example.com/v1", "kind": "Widget", "spec": map[string]interface{}{"items": []interface{}{"a"}, "size": 1}},
+			patchType: PatchTypeStrategic,
+			patch:     map[string]interface{}{"spec": map[string]interface{}{"items": []interface{}{"b"}}},
+			expected:  `{"apiVersion": "example.com/v1", "kind": "Widget", "spec": {"items": ["b"], "size": 1}}`,
+		},
+		{
+			name:        "unsupported patch type",
+			obj:         map[string]interface{}{},
+			patchType:   "yaml",
+			patch:       map[string]interface{}{},
+			expectError: "unsupported patch type",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			original, err := json.Marshal(tt.obj)
+			require.NoError(t, err)
+
+			result, err := ApplyPatch(tt.obj, tt.patchType, tt.patch)
+			if tt.expectError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectError)
+				return
+			}
+			require.NoError(t, err)
+
+			actual, err := json.Marshal(result)
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.expected, string(actual))
+
+			after, err := json.Marshal(tt.obj)
+			require.NoError(t, err)
+			assert.JSONEq(t, string(original), string(after), "input object must not be modified")
+		})
+	}
+}
+
+func TestPatchManifests(t *testing.T) {
+	manifestWork := map[string]interface{}{
+		"apiVersion": "work.open-cluster-management.io/v1",
+		"kind":       "ManifestWork",
+		"metadata":   map[string]interface{}{"name": "mw"},
+		"spec": map[string]interface{}{
+			"workload": map[string]interface{}{
+				"manifests": []interface{}{
+					testDeployment(),
+					map[string]interface{}{"apiVersion": "v1", "kind": "ConfigMap", "metadata": map[string]interface{}{"name": "cfg"}},
+				},
+			},
+		},
+	}
+	labelPatch := map[string]interface{}{"metadata": map[string]interface{}{"labels": map[string]interface{}{"patched": "true"}}}
+
+	tests := []struct {
+		name          string
+		target        PatchTarget
+		expectedCount int
+		patchedKinds  []string
+	}{
+		{
+			name:          "empty target patches only the ManifestWork",
+			target:        PatchTarget{},
+			expectedCount: 1,
+			patchedKinds:  []string{"ManifestWork"},
+		},
+		{
+			name:          "target by kind patches nested manifests",
+			target:        PatchTarget{Kind: "Deployment"},
+			expectedCount: 1,
+			patchedKinds:  []string{"Deployment"},
+		},
+		{
+			name:          "target by name",
+			target:        PatchTarget{Name: "cfg"},
+			expectedCount: 1,
+			patchedKinds:  []string{"ConfigMap"},
+		},
+		{
+			name:          "target without matches",
+			target:        PatchTarget{Kind: "Secret"},
+			expectedCount: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, count, err := PatchManifests(manifestWork, tt.target, PatchTypeStrategic, labelPatch)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedCount, count)
+
+			objects := []interface{}{result}
+			objects = append(objects, result["spec"].(map[string]interface{})["workload"].(map[string]interface{})["manifests"].([]interface{})...)
+			var patchedKinds []string
+			for _, o := range objects {
+				obj := o.(map[string]interface{})
+				labels, _ := obj["metadata"].(map[string]interface{})["labels"].(map[string]interface{})
+				if labels["patched"] == "true" {
+					patchedKinds = append(patchedKinds, obj["kind"].(string))
+				}
+			}
+			assert.Equal(t, tt.patchedKinds, patchedKinds)
+
+			_, hasLabels := manifestWork["metadata"].(map[string]interface{})["labels"]
+			assert.False(t, hasLabels, "input object must not be modified")
+		})
+	}
+}
+
+func TestPatchManifestsKeepsIntegerPrecision(t *testing.T) {
+	// 2^53 + 1 cannot be represented exactly as a float64
+	const large = int64(9007199254740993)
+	obj := map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]interface{}{"name": "cm", "generation": large},
+		"spec":       map[string]interface{}{"ratio": 0.5},
+	}
+
+	patched, count, err := PatchManifests(obj, PatchTarget{Kind: "ConfigMap"}, PatchTypeMerge,
+		map[string]interface{}{"spec": map[string]interface{}{"limit": large}})
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	assert.Equal(t, large, patched["metadata"].(map[string]interface{})["generation"])
+	spec := patched["spec"].(map[string]interface{})
+	assert.Equal(t, large, spec["limit"])
+	assert.Equal(t, 0.5, spec["ratio"])
+}
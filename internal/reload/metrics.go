@@ -0,0 +1,52 @@
+package reload
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Reload outcomes recorded by configReloadsTotal
+const (
+	resultSuccess = "success"
+	resultFailure = "failure"
+)
+
+// Config reload metrics, labeled with the adapter component
+var (
+	configInfo = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "hyperfleet_adapter_config_info",
+			Help: "Hash of the task configs currently in use (always 1)",
+		},
+		[]string{"component", "hash"},
+	)
+
+	configReloadsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "hyperfleet_adapter_config_reloads_total",
+			Help: "Number of task config reload attempts, by result",
+		},
+		[]string{"component", "result"},
+	)
+
+	registerMetricsOnce sync.Once
+)
+
+// registerMetrics registers the reload metrics with the default Prometheus registry
+func registerMetrics() {
+	registerMetricsOnce.Do(func() {
+		prometheus.MustRegister(configInfo, configReloadsTotal)
+	})
+}
+
+// recordConfigHash replaces the config hash reported for the component
+func recordConfigHash(component, hash string) {
+	configInfo.DeletePartialMatch(prometheus.Labels{"component": component})
+	configInfo.WithLabelValues(component, hash).Set(1)
+}
+
+// recordReload records the result of a reload attempt
+func recordReload(component, result string) {
+	configReloadsTotal.WithLabelValues(component, result).Inc()
+}
@@ -0,0 +1,223 @@
+// Package reload hot-reloads the task configs when their files change.
+//
+// Task configs are usually mounted from a ConfigMap. Kubernetes updates a ConfigMap volume
+// by atomically swapping the "..data" symlink of the mount directory, so the reloader watches
+// the directories holding the task config files and the files they reference rather than the
+// files themselves. Changes are debounced, then the configs are loaded again with full
+// validation and handed to the target (the executor router), which swaps them between events.
+// When loading or applying fails, the current configs stay in use.
+package reload
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/config_loader"
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/pkg/logger"
+)
+
+// DefaultDebounce is the quiet period after the last file change before reloading
+const DefaultDebounce = 2 * time.Second
+
+// LoadFunc loads and validates the task configs
+type LoadFunc func() ([]*config_loader.Config, error)
+
+// Target applies reloaded task configs (implemented by *executor.Router)
+type Target interface {
+	Reload(configs []*config_loader.Config) error
+}
+
+// Option configures a Reloader
+type Option func(*Reloader)
+
+// WithDebounce sets the quiet period after the last file change before reloading
+func WithDebounce(debounce time.Duration) Option {
+	return func(r *Reloader) {
+		r.debounce = debounce
+	}
+}
+
+// WithOnReload sets a function called with the new config hash after each successful reload
+func WithOnReload(fn func(hash string)) Option {
+	return func(r *Reloader) {
+		r.onReload = fn
+	}
+}
+
+// Reloader watches the task config files and reloads them on change
+type Reloader struct {
+	component string
+	debounce  time.Duration
+	load      LoadFunc
+	target    Target
+	onReload  func(hash string)
+	log       logger.Logger
+
+	mu          sync.Mutex
+	hash        string
+	sourceFiles []string
+}
+
+// NewReloader creates a Reloader for the currently loaded configs
+func NewReloader(configs []*config_loader.Config, load LoadFunc, target Target, log logger.Logger, opts ...Option) (*Reloader, error) {
+	if len(configs) == 0 {
+		return nil, fmt.Errorf("at least one config is required")
+	}
+	if load == nil || target == nil || log == nil {
+		return nil, fmt.Errorf("load function, target and logger are required")
+	}
+
+	hash, err := config_loader.Hash(configs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash configs: %w", err)
+	}
+
+	r := &Reloader{
+		component:   configs[0].Metadata.Name,
+		debounce:    DefaultDebounce,
+		load:        load,
+		target:      target,
+		log:         log,
+		hash:        hash,
+		sourceFiles: sourceFiles(configs),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	registerMetrics()
+	recordConfigHash(r.component, hash)
+	return r, nil
+}
+
+// Hash returns the hash of the configs currently in use
+func (r *Reloader) Hash() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.hash
+}
+
+// Run watches the config files and reloads the configs until the context is cancelled
+func (r *Reloader) Run(ctx context.Context) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to create file watcher: %w", err)
+	}
+	defer func() { _ = watcher.Close() }()
+
+	r.watch(ctx, watcher)
+	r.log.Infof(ctx, "Config reload watching %d directories: hash=%s", len(watcher.WatchList()), r.Hash())
+
+	timer := time.NewTimer(r.debounce)
+	if !timer.Stop() {
+		<-timer.C
+	}
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			r.log.Info(ctx, "Config reload stopped")
+			return nil
+		case evt, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if evt.Op == fsnotify.Chmod {
+				continue
+			}
+			r.log.Debugf(ctx, "Config file change detected: %s", evt)
+			timer.Reset(r.debounce)
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			errCtx := logger.WithErrorField(ctx, err)
+			r.log.Warnf(errCtx, "Config file watcher error")
+		case <-timer.C:
+			if err := r.Reload(ctx); err != nil {
+				errCtx := logger.WithErrorField(ctx, err)
+				r.log.Errorf(errCtx, "Config reload failed, keeping the current config")
+			}
+			// Reloaded configs may reference new files
+			r.watch(ctx, watcher)
+		}
+	}
+}
+
+// Reload loads the configs and applies them to the target when their hash changed.
+// On error the current configs stay in use.
+func (r *Reloader) Reload(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	configs, err := r.load()
+	if err != nil {
+		recordReload(r.component, resultFailure)
+		return fmt.Errorf("failed to load configs: %w", err)
+	}
+	hash, err := config_loader.Hash(configs)
+	if err != nil {
+		recordReload(r.component, resultFailure)
+		return fmt.Errorf("failed to hash configs: %w", err)
+	}
+	if hash == r.hash {
+		r.log.Debugf(ctx, "Config unchanged, skipping reload: hash=%s", hash)
+		return nil
+	}
+
+	if err := r.target.Reload(configs); err != nil {
+		recordReload(r.component, resultFailure)
+		return fmt.Errorf("failed to apply configs: %w", err)
+	}
+
+	r.log.Infof(ctx, "Config reloaded: hash=%s previousHash=%s tasks=%d", hash, r.hash, len(configs))
+	for _, config := range configs {
+		for _, warning := range config.Warnings {
+			r.log.Warnf(ctx, "Task config %s: %s", config.GetTaskName(), warning)
+		}
+	}
+	r.hash = hash
+	r.sourceFiles = sourceFiles(configs)
+	recordReload(r.component, resultSuccess)
+	recordConfigHash(r.component, hash)
+	if r.onReload != nil {
+		r.onReload(hash)
+	}
+	return nil
+}
+
+// watch adds the directories of the source files to the watcher.
+// Referenced directories (manifestFrom paths, charts) are watched as well.
+func (r *Reloader) watch(ctx context.Context, watcher *fsnotify.Watcher) {
+	r.mu.Lock()
+	files := r.sourceFiles
+	r.mu.Unlock()
+
+	for _, file := range files {
+		dirs := []string{filepath.Dir(file)}
+		if info, err := os.Stat(file); err == nil && info.IsDir() {
+			dirs = append(dirs, file)
+		}
+		for _, dir := range dirs {
+			if err := watcher.Add(dir); err != nil {
+				errCtx := logger.WithErrorField(ctx, err)
+				r.log.Warnf(errCtx, "Failed to watch config directory %s", dir)
+			}
+		}
+	}
+}
+
+// sourceFiles returns the source files of all configs
+func sourceFiles(configs []*config_loader.Config) []string {
+	var files []string
+	for _, config := range configs {
+		files = append(files, config.SourceFiles...)
+	}
+	return files
+}
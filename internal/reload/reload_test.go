@@ -0,0 +1,127 @@
+package reload
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/config_loader"
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeTarget struct {
+	mu      sync.Mutex
+	configs [][]*config_loader.Config
+	err     error
+}
+
+func (t *fakeTarget) Reload(configs []*config_loader.Config) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.err != nil {
+		return t.err
+	}
+	t.configs = append(t.configs, configs)
+	return nil
+}
+
+func (t *fakeTarget) reloads() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return len(t.configs)
+}
+
+// newTestConfig returns a config whose only source file is the given task config file
+func newTestConfig(taskPath string) *config_loader.Config {
+	return &config_loader.Config{
+		Metadata:     config_loader.Metadata{Name: "test-adapter"},
+		TaskMetadata: config_loader.Metadata{Name: "test-task"},
+		SourceFiles:  []string{taskPath},
+	}
+}
+
+func TestNewReloader(t *testing.T) {
+	taskPath := filepath.Join(t.TempDir(), "task.yaml")
+	require.NoError(t, os.WriteFile(taskPath, []byte("a: 1"), 0644))
+	configs := []*config_loader.Config{newTestConfig(taskPath)}
+	load := func() ([]*config_loader.Config, error) { return configs, nil }
+
+	r, err := NewReloader(configs, load, &fakeTarget{}, logger.NewTestLogger())
+	require.NoError(t, err)
+	assert.Equal(t, DefaultDebounce, r.debounce)
+	assert.NotEmpty(t, r.Hash())
+
+	_, err = NewReloader(nil, load, &fakeTarget{}, logger.NewTestLogger())
+	assert.Error(t, err)
+	_, err = NewReloader(configs, nil, &fakeTarget{}, logger.NewTestLogger())
+	assert.Error(t, err)
+}
+
+func TestReload(t *testing.T) {
+	taskPath := filepath.Join(t.TempDir(), "task.yaml")
+	require.NoError(t, os.WriteFile(taskPath, []byte("a: 1"), 0644))
+	configs := []*config_loader.Config{newTestConfig(taskPath)}
+
+	var loadErr error
+	load := func() ([]*config_loader.Config, error) { return configs, loadErr }
+	target := &fakeTarget{}
+	var notified []string
+	r, err := NewReloader(configs, load, target, logger.NewTestLogger(),
+		WithOnReload(func(hash string) { notified = append(notified, hash) }))
+	require.NoError(t, err)
+	initialHash := r.Hash()
+
+	// Unchanged files are not reapplied
+	require.NoError(t, r.Reload(context.Background()))
+	assert.Equal(t, 0, target.reloads())
+
+	// A load failure keeps the current config
+	require.NoError(t, os.WriteFile(taskPath, []byte("a: 2"), 0644))
+	loadErr = errors.New("validation failed")
+	assert.Error(t, r.Reload(context.Background()))
+	assert.Equal(t, initialHash, r.Hash())
+	assert.Equal(t, 0, target.reloads())
+
+	// A target failure keeps the current config
+	loadErr = nil
+	target.err = errors.New("task config removed")
+	assert.Error(t, r.Reload(context.Background()))
+	assert.Equal(t, initialHash, r.Hash())
+
+	// A successful reload swaps the hash and notifies the listener
+	target.err = nil
+	require.NoError(t, r.Reload(context.Background()))
+	assert.Equal(t, 1, target.reloads())
+	assert.NotEqual(t, initialHash, r.Hash())
+	assert.Equal(t, []string{r.Hash()}, notified)
+}
+
+func TestRunReloadsOnFileChange(t *testing.T) {
+	taskPath := filepath.Join(t.TempDir(), "task.yaml")
+	require.NoError(t, os.WriteFile(taskPath, []byte("a: 1"), 0644))
+	configs := []*config_loader.Config{newTestConfig(taskPath)}
+	load := func() ([]*config_loader.Config, error) { return configs, nil }
+
+	target := &fakeTarget{}
+	r, err := NewReloader(configs, load, target, logger.NewTestLogger(), WithDebounce(10*time.Millisecond))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- r.Run(ctx) }()
+
+	// Keep writing until the watcher is running and picks the change up
+	assert.Eventually(t, func() bool {
+		_ = os.WriteFile(taskPath, []byte("a: "+time.Now().String()), 0644)
+		return target.reloads() > 0
+	}, 5*time.Second, 50*time.Millisecond)
+
+	cancel()
+	require.NoError(t, <-done)
+}
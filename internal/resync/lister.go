@@ -0,0 +1,182 @@
+package resync
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/config_loader"
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/executor"
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/hyperfleet_api"
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/manifest"
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/transport_client"
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/pkg/constants"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Defaults applied to unset resync source fields
+const (
+	DefaultEventKind = "Cluster"
+	DefaultPageSize  = 100
+)
+
+// KubernetesLister lists resources labeled hyperfleet.io/managed-by=<adapter name> and
+// returns one event per distinct owner id, carrying the highest generation found.
+type KubernetesLister struct {
+	client      transport_client.TransportClient
+	source      config_loader.ResyncKubernetesSource
+	adapterName string
+	eventKind   string
+	idLabel     string
+}
+
+// NewKubernetesLister creates a Lister backed by the kubernetes transport client
+func NewKubernetesLister(client transport_client.TransportClient, source *config_loader.ResyncKubernetesSource, adapterName string) (*KubernetesLister, error) {
+	if client == nil {
+		return nil, fmt.Errorf("transport client is required")
+	}
+	if source == nil || len(source.Resources) == 0 {
+		return nil, fmt.Errorf("at least one resource kind is required")
+	}
+	if adapterName == "" {
+		return nil, fmt.Errorf("adapter name is required")
+	}
+
+	l := &KubernetesLister{
+		client:      client,
+		source:      *source,
+		adapterName: adapterName,
+		eventKind:   source.EventKind,
+		idLabel:     source.IDLabel,
+	}
+	if l.eventKind == "" {
+		l.eventKind = DefaultEventKind
+	}
+	if l.idLabel == "" {
+		l.idLabel = constants.LabelClusterID
+	}
+	return l, nil
+}
+
+// List implements Lister
+func (l *KubernetesLister) List(ctx context.Context) ([]executor.EventData, error) {
+	generations := make(map[string]int64)
+	selector := constants.LabelManagedBy + "=" + l.adapterName
+
+	for _, res := range l.source.Resources {
+		gv, err := schema.ParseGroupVersion(res.APIVersion)
+		if err != nil {
+			return nil, fmt.Errorf("invalid apiVersion %q: %w", res.APIVersion, err)
+		}
+		gvk := gv.WithKind(res.Kind)
+
+		list, err := l.client.DiscoverResources(ctx, gvk, &manifest.DiscoveryConfig{
+			Namespace:     res.Namespace,
+			LabelSelector: selector,
+		}, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %s: %w", gvk.Kind, err)
+		}
+
+		for i := range list.Items {
+			id := list.Items[i].GetLabels()[l.idLabel]
+			if id == "" {
+				continue
+			}
+			gen, _ := strconv.ParseInt(list.Items[i].GetAnnotations()[constants.AnnotationGeneration], 10, 64)
+			if current, ok := generations[id]; !ok || gen > current {
+				generations[id] = gen
+			}
+		}
+	}
+
+	ids := make([]string, 0, len(generations))
+	for id := range generations {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	events := make([]executor.EventData, 0, len(ids))
+	for _, id := range ids {
+		events = append(events, executor.EventData{ID: id, Kind: l.eventKind, Generation: generations[id]})
+	}
+	return events, nil
+}
+
+// APILister lists resources from a paginated HyperFleet API list endpoint
+type APILister struct {
+	client   hyperfleet_api.Client
+	path     string
+	pageSize int
+}
+
+// apiListResponse is the subset of a HyperFleet API list response used by the lister
+type apiListResponse struct {
+	Items []executor.EventData `json:"items"`
+	Total int                  `json:"total"`
+}
+
+// NewAPILister creates a Lister backed by the HyperFleet API
+func NewAPILister(client hyperfleet_api.Client, source *config_loader.ResyncAPISource) (*APILister, error) {
+	if client == nil {
+		return nil, fmt.Errorf("HyperFleet API client is required")
+	}
+	if source == nil || source.Path == "" {
+		return nil, fmt.Errorf("list path is required")
+	}
+
+	pageSize := source.PageSize
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	return &APILister{client: client, path: source.Path, pageSize: pageSize}, nil
+}
+
+// List implements Lister
+func (l *APILister) List(ctx context.Context) ([]executor.EventData, error) {
+	var events []executor.EventData
+	fetched := 0
+
+	for page := 1; ; page++ {
+		resp, err := l.client.Get(ctx, l.pageURL(page))
+		if err != nil {
+			return nil, fmt.Errorf("failed to list page %d: %w", page, err)
+		}
+		if !resp.IsSuccess() {
+			return nil, fmt.Errorf("failed to list page %d: status=%s body=%s", page, resp.Status, resp.BodyString())
+		}
+
+		var list apiListResponse
+		if err := json.Unmarshal(resp.Body, &list); err != nil {
+			return nil, fmt.Errorf("failed to parse page %d: %w", page, err)
+		}
+
+		fetched += len(list.Items)
+		for _, item := range list.Items {
+			if item.ID == "" {
+				continue
+			}
+			events = append(events, item)
+		}
+
+		if len(list.Items) < l.pageSize || (list.Total > 0 && fetched >= list.Total) {
+			return events, nil
+		}
+	}
+}
+
+// pageURL appends the pagination query parameters to the list path
+func (l *APILister) pageURL(page int) string {
+	sep := "?"
+	if strings.Contains(l.path, "?") {
+		sep = "&"
+	}
+	query := url.Values{}
+	query.Set("page", strconv.Itoa(page))
+	query.Set("pageSize", strconv.Itoa(l.pageSize))
+	return l.path + sep + query.Encode()
+}
@@ -0,0 +1,116 @@
+// Package resync periodically reprocesses the resources owned by the adapter.
+//
+// The adapter only acts on broker events, so a missed event would leave a resource stale.
+// The resync loop lists the owned resources on a jittered interval and synthesizes an event
+// for each of them, executed at a limited rate (see package synthetic).
+package resync
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/config_loader"
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/executor"
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/synthetic"
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/pkg/logger"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// Defaults applied to unset ResyncConfig fields (the rate limit defaults to synthetic.DefaultRateLimit)
+const (
+	DefaultInterval = 10 * time.Minute
+	DefaultJitter   = 0.1
+)
+
+// EventIDPrefix prefixes the ids of synthesized events
+const EventIDPrefix = "resync"
+
+// Lister lists the resources owned by the adapter as event data
+type Lister interface {
+	List(ctx context.Context) ([]executor.EventData, error)
+}
+
+// Resyncer runs the periodic resync loop
+type Resyncer struct {
+	interval time.Duration
+	jitter   float64
+	runner   *synthetic.Runner
+	lister   Lister
+	log      logger.Logger
+}
+
+// NewResyncer creates a Resyncer from the resync configuration
+func NewResyncer(cfg *config_loader.ResyncConfig, lister Lister, exec synthetic.Executor, log logger.Logger) (*Resyncer, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("resync config is required")
+	}
+	if lister == nil || exec == nil || log == nil {
+		return nil, fmt.Errorf("lister, executor and logger are required")
+	}
+
+	interval := cfg.Interval
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	jitter := cfg.Jitter
+	if jitter == 0 {
+		jitter = DefaultJitter
+	}
+
+	return &Resyncer{
+		interval: interval,
+		jitter:   jitter,
+		runner:   synthetic.NewRunner(EventIDPrefix, cfg.RateLimit, exec, log),
+		lister:   lister,
+		log:      log,
+	}, nil
+}
+
+// Run runs resync passes until the context is cancelled.
+// The first pass starts after one interval: on startup the broker backlog is processed first.
+func (r *Resyncer) Run(ctx context.Context) {
+	r.log.Infof(ctx, "Resync loop started: interval=%s jitter=%.2f rateLimit=%.2f/s",
+		r.interval, r.jitter, r.runner.RateLimit())
+
+	timer := time.NewTimer(wait.Jitter(r.interval, r.jitter))
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			r.log.Info(ctx, "Resync loop stopped")
+			return
+		case <-timer.C:
+		}
+
+		if err := r.RunOnce(ctx); err != nil && ctx.Err() == nil {
+			errCtx := logger.WithErrorField(ctx, err)
+			r.log.Warnf(errCtx, "Resync pass failed")
+		}
+		timer.Reset(wait.Jitter(r.interval, r.jitter))
+	}
+}
+
+// RunOnce lists the owned resources and executes one synthesized event for each of them
+func (r *Resyncer) RunOnce(ctx context.Context) error {
+	events, err := r.lister.List(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list resources for resync: %w", err)
+	}
+	r.log.Infof(ctx, "Resync pass started: %d resources", len(events))
+
+	failed := 0
+	for i, evt := range events {
+		succeeded, err := r.runner.Execute(ctx, evt)
+		if err != nil {
+			return fmt.Errorf("resync pass interrupted after %d of %d resources: %w", i, len(events), err)
+		}
+		if !succeeded {
+			failed++
+		}
+	}
+
+	r.log.Infof(ctx, "Resync pass completed: processed=%d failed=%d", len(events), failed)
+	return nil
+}
@@ -0,0 +1,262 @@
+package resync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/config_loader"
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/executor"
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/hyperfleet_api"
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/k8s_client"
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/manifest"
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/synthetic"
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/transport_client"
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/pkg/constants"
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+type fakeLister struct {
+	events []executor.EventData
+	err    error
+}
+
+func (l *fakeLister) List(_ context.Context) ([]executor.EventData, error) {
+	return l.events, l.err
+}
+
+type fakeExecutor struct {
+	mu     sync.Mutex
+	data   []interface{}
+	status executor.ExecutionStatus
+}
+
+func (e *fakeExecutor) Execute(_ context.Context, data interface{}) *executor.ExecutionResult {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.data = append(e.data, data)
+	status := e.status
+	if status == "" {
+		status = executor.StatusSuccess
+	}
+	return &executor.ExecutionResult{Status: status}
+}
+
+func TestNewResyncer(t *testing.T) {
+	lister := &fakeLister{}
+	exec := &fakeExecutor{}
+
+	r, err := NewResyncer(&config_loader.ResyncConfig{Enabled: true}, lister, exec, logger.NewTestLogger())
+	require.NoError(t, err)
+	assert.Equal(t, DefaultInterval, r.interval)
+	assert.Equal(t, DefaultJitter, r.jitter)
+	assert.InDelta(t, synthetic.DefaultRateLimit, r.runner.RateLimit(), 0.0001)
+
+	_, err = NewResyncer(nil, lister, exec, logger.NewTestLogger())
+	assert.Error(t, err)
+	_, err = NewResyncer(&config_loader.ResyncConfig{}, nil, exec, logger.NewTestLogger())
+	assert.Error(t, err)
+}
+
+func TestRunOnce(t *testing.T) {
+	events := []executor.EventData{
+		{ID: "c1", Kind: "Cluster", Generation: 2},
+		{ID: "c2", Kind: "Cluster", Generation: 5},
+	}
+
+	t.Run("executes one event per listed resource", func(t *testing.T) {
+		exec := &fakeExecutor{}
+		r, err := NewResyncer(&config_loader.ResyncConfig{RateLimit: 1000}, &fakeLister{events: events}, exec, logger.NewTestLogger())
+		require.NoError(t, err)
+
+		require.NoError(t, r.RunOnce(context.Background()))
+		require.Len(t, exec.data, 2)
+		for i, data := range exec.data {
+			eventData, _, err := executor.ParseEventData(data)
+			require.NoError(t, err)
+			assert.Equal(t, events[i], *eventData)
+		}
+	})
+
+	t.Run("failed executions do not stop the pass", func(t *testing.T) {
+		exec := &fakeExecutor{status: executor.StatusFailed}
+		r, err := NewResyncer(&config_loader.ResyncConfig{RateLimit: 1000}, &fakeLister{events: events}, exec, logger.NewTestLogger())
+		require.NoError(t, err)
+
+		require.NoError(t, r.RunOnce(context.Background()))
+		assert.Len(t, exec.data, 2)
+	})
+
+	t.Run("list error", func(t *testing.T) {
+		exec := &fakeExecutor{}
+		r, err := NewResyncer(&config_loader.ResyncConfig{}, &fakeLister{err: errors.New("boom")}, exec, logger.NewTestLogger())
+		require.NoError(t, err)
+
+		err = r.RunOnce(context.Background())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "boom")
+		assert.Empty(t, exec.data)
+	})
+
+	t.Run("cancelled context interrupts the pass", func(t *testing.T) {
+		exec := &fakeExecutor{}
+		r, err := NewResyncer(&config_loader.ResyncConfig{RateLimit: 0.001}, &fakeLister{events: events}, exec, logger.NewTestLogger())
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		assert.Error(t, r.RunOnce(ctx))
+	})
+}
+
+// recordingDiscoverer records the discoveries passed to the mock client
+type recordingDiscoverer struct {
+	*k8s_client.MockK8sClient
+	gvks        []schema.GroupVersionKind
+	discoveries []manifest.Discovery
+}
+
+func (d *recordingDiscoverer) DiscoverResources(ctx context.Context, gvk schema.GroupVersionKind, discovery manifest.Discovery, target transport_client.TransportContext) (*unstructured.UnstructuredList, error) {
+	d.gvks = append(d.gvks, gvk)
+	d.discoveries = append(d.discoveries, discovery)
+	return d.MockK8sClient.DiscoverResources(ctx, gvk, discovery, target)
+}
+
+func ownedObject(name, clusterID, generation string) unstructured.Unstructured {
+	obj := unstructured.Unstructured{}
+	obj.SetName(name)
+	if clusterID != "" {
+		obj.SetLabels(map[string]string{constants.LabelClusterID: clusterID})
+	}
+	obj.SetAnnotations(map[string]string{constants.AnnotationGeneration: generation})
+	return obj
+}
+
+func TestKubernetesLister(t *testing.T) {
+	mock := k8s_client.NewMockK8sClient()
+	mock.DiscoverResult = &unstructured.UnstructuredList{Items: []unstructured.Unstructured{
+		ownedObject("ns-b", "b", "3"),
+		ownedObject("job-a", "a", "1"),
+		ownedObject("ns-a", "a", "4"),
+		ownedObject("unlabeled", "", "9"),
+	}}
+	client := &recordingDiscoverer{MockK8sClient: mock}
+
+	lister, err := NewKubernetesLister(client, &config_loader.ResyncKubernetesSource{
+		Resources: []config_loader.ResyncResource{
+			{APIVersion: "v1", Kind: "Namespace"},
+			{APIVersion: "batch/v1", Kind: "Job", Namespace: "jobs"},
+		},
+	}, "my-adapter")
+	require.NoError(t, err)
+
+	events, err := lister.List(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []executor.EventData{
+		{ID: "a", Kind: "Cluster", Generation: 4},
+		{ID: "b", Kind: "Cluster", Generation: 3},
+	}, events)
+
+	require.Len(t, client.discoveries, 2)
+	assert.Equal(t, schema.GroupVersionKind{Group: "batch", Version: "v1", Kind: "Job"}, client.gvks[1])
+	assert.Equal(t, "jobs", client.discoveries[1].GetNamespace())
+	assert.Equal(t, "hyperfleet.io/managed-by=my-adapter", client.discoveries[0].GetLabelSelector())
+
+	t.Run("list error", func(t *testing.T) {
+		mock.DiscoverError = errors.New("forbidden")
+		defer func() { mock.DiscoverError = nil }()
+		_, err := lister.List(context.Background())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "forbidden")
+	})
+
+	t.Run("requires resources", func(t *testing.T) {
+		_, err := NewKubernetesLister(client, &config_loader.ResyncKubernetesSource{}, "my-adapter")
+		assert.Error(t, err)
+	})
+}
+
+// pagedAPIClient serves one page per GET request
+type pagedAPIClient struct {
+	*hyperfleet_api.MockClient
+	pages []string
+	urls  []string
+}
+
+func (c *pagedAPIClient) Get(_ context.Context, url string, _ ...hyperfleet_api.RequestOption) (*hyperfleet_api.Response, error) {
+	c.urls = append(c.urls, url)
+	if len(c.urls) > len(c.pages) {
+		return nil, fmt.Errorf("unexpected request %s", url)
+	}
+	return &hyperfleet_api.Response{StatusCode: 200, Status: "200 OK", Body: []byte(c.pages[len(c.urls)-1])}, nil
+}
+
+func TestAPILister(t *testing.T) {
+	tests := []struct {
+		name         string
+		path         string
+		pageSize     int
+		pages        []string
+		expectedIDs  []string
+		expectedURLs []string
+	}{
+		{
+			name:     "paginates until total is reached",
+			path:     "/api/hyperfleet/v1/clusters",
+			pageSize: 2,
+			pages: []string{
+				`{"items": [{"id": "c1", "kind": "Cluster", "generation": 1}, {"id": "c2", "kind": "Cluster"}], "total": 3}`,
+				`{"items": [{"id": "c3", "kind": "Cluster", "href": "/clusters/c3"}], "total": 3}`,
+			},
+			expectedIDs: []string{"c1", "c2", "c3"},
+			expectedURLs: []string{
+				"/api/hyperfleet/v1/clusters?page=1&pageSize=2",
+				"/api/hyperfleet/v1/clusters?page=2&pageSize=2",
+			},
+		},
+		{
+			name:     "stops on a short page and keeps existing query",
+			path:     "/clusters?search=ready",
+			pageSize: 0,
+			pages: []string{
+				`{"items": [{"id": "c1", "kind": "Cluster"}, {"kind": "Cluster"}]}`,
+			},
+			expectedIDs:  []string{"c1"},
+			expectedURLs: []string{"/clusters?search=ready&page=1&pageSize=100"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &pagedAPIClient{MockClient: hyperfleet_api.NewMockClient(), pages: tt.pages}
+			lister, err := NewAPILister(client, &config_loader.ResyncAPISource{Path: tt.path, PageSize: tt.pageSize})
+			require.NoError(t, err)
+
+			events, err := lister.List(context.Background())
+			require.NoError(t, err)
+			ids := make([]string, 0, len(events))
+			for _, evt := range events {
+				ids = append(ids, evt.ID)
+			}
+			assert.Equal(t, tt.expectedIDs, ids)
+			assert.Equal(t, tt.expectedURLs, client.urls)
+		})
+	}
+
+	t.Run("error status", func(t *testing.T) {
+		mock := hyperfleet_api.NewMockClient()
+		mock.GetResponse = &hyperfleet_api.Response{StatusCode: 500, Status: "500 Internal Server Error"}
+		lister, err := NewAPILister(mock, &config_loader.ResyncAPISource{Path: "/clusters"})
+		require.NoError(t, err)
+
+		_, err = lister.List(context.Background())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "500")
+	})
+}
@@ -0,0 +1,169 @@
+// Package statuswatch reconciles resources when the status of their ManifestWorks changes.
+//
+// The Maestro client watches the ManifestWorks of the adapter's source (see
+// maestro_client.Client.WatchStatus). For each change the Trigger evaluates the configured CEL
+// condition against the previous and the current ManifestWork; when the result flips, it
+// synthesizes an event for the resource owning the ManifestWork, executed at a limited rate
+// (see package synthetic). Status is then reported to HyperFleet without waiting
+// for the next broker event.
+package statuswatch
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/config_loader"
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/criteria"
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/executor"
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/synthetic"
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/pkg/constants"
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/pkg/logger"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/util/workqueue"
+	workv1 "open-cluster-management.io/api/work/v1"
+)
+
+// DefaultEventKind is the kind of the synthesized events when unset
+const DefaultEventKind = "Cluster"
+
+// EventIDPrefix prefixes the ids of synthesized events
+const EventIDPrefix = "status"
+
+// workVariable is the CEL variable holding the ManifestWork
+const workVariable = "work"
+
+// Trigger synthesizes an event when a ManifestWork status change flips the reconcile condition
+type Trigger struct {
+	condition string
+	eventKind string
+	idLabel   string
+	runner    *synthetic.Runner
+	log       logger.Logger
+
+	// queue deduplicates pending events per resource key; pending holds their latest event
+	queue   workqueue.TypedInterface[string]
+	mu      sync.Mutex
+	pending map[string]executor.EventData
+}
+
+// NewTrigger creates a Trigger from the status watch configuration
+func NewTrigger(cfg *config_loader.MaestroStatusWatchConfig, exec synthetic.Executor, log logger.Logger) (*Trigger, error) {
+	if cfg == nil || cfg.ReconcileWhen == "" {
+		return nil, fmt.Errorf("status watch reconcileWhen condition is required")
+	}
+	if exec == nil || log == nil {
+		return nil, fmt.Errorf("executor and logger are required")
+	}
+
+	eventKind := cfg.EventKind
+	if eventKind == "" {
+		eventKind = DefaultEventKind
+	}
+	idLabel := cfg.IDLabel
+	if idLabel == "" {
+		idLabel = constants.LabelClusterID
+	}
+
+	return &Trigger{
+		condition: cfg.ReconcileWhen,
+		eventKind: eventKind,
+		idLabel:   idLabel,
+		runner:    synthetic.NewRunner(EventIDPrefix, cfg.RateLimit, exec, log),
+		log:       log,
+		queue:     workqueue.NewTyped[string](),
+		pending:   make(map[string]executor.EventData),
+	}, nil
+}
+
+// OnStatusChange enqueues an event for the resource owning the ManifestWork when the change
+// flips the reconcile condition. ManifestWorks seen for the first time (previous is nil) are
+// skipped, so a restart does not reprocess every resource.
+// It implements maestro_client.StatusChangeFunc.
+func (t *Trigger) OnStatusChange(ctx context.Context, previous, current *workv1.ManifestWork) {
+	if previous == nil || current == nil {
+		return
+	}
+
+	before := t.matches(ctx, previous)
+	after := t.matches(ctx, current)
+	if before == after {
+		return
+	}
+
+	id := current.Labels[t.idLabel]
+	if id == "" {
+		t.log.Debugf(ctx, "ManifestWork %s/%s has no %s label, skipping reconcile", current.Namespace, current.Name, t.idLabel)
+		return
+	}
+	generation, _ := strconv.ParseInt(current.Annotations[constants.AnnotationGeneration], 10, 64)
+	evt := executor.EventData{ID: id, Kind: t.eventKind, Generation: generation}
+
+	t.log.Infof(ctx, "ManifestWork %s/%s status changed the reconcile condition to %t, enqueueing %s",
+		current.Namespace, current.Name, after, evt.Key())
+	t.mu.Lock()
+	t.pending[evt.Key()] = evt
+	t.mu.Unlock()
+	t.queue.Add(evt.Key())
+}
+
+// Run executes the enqueued events until the context is cancelled
+func (t *Trigger) Run(ctx context.Context) {
+	t.log.Infof(ctx, "Status reconcile trigger started: rateLimit=%.2f/s", t.runner.RateLimit())
+
+	go func() {
+		<-ctx.Done()
+		t.queue.ShutDown()
+	}()
+
+	for {
+		key, shutdown := t.queue.Get()
+		if shutdown {
+			t.log.Info(ctx, "Status reconcile trigger stopped")
+			return
+		}
+		t.process(ctx, key)
+	}
+}
+
+// process executes the pending event of a resource key
+func (t *Trigger) process(ctx context.Context, key string) {
+	defer t.queue.Done(key)
+
+	t.mu.Lock()
+	evt, ok := t.pending[key]
+	delete(t.pending, key)
+	t.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	// Failures are logged by the runner; the next status change or resync retries the resource
+	_, _ = t.runner.Execute(ctx, evt)
+}
+
+// matches evaluates the reconcile condition against a ManifestWork.
+// Evaluation errors (e.g. a missing field) count as not matched.
+func (t *Trigger) matches(ctx context.Context, work *workv1.ManifestWork) bool {
+	obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(work)
+	if err != nil {
+		errCtx := logger.WithErrorField(ctx, err)
+		t.log.Warnf(errCtx, "Failed to convert ManifestWork %s/%s", work.Namespace, work.Name)
+		return false
+	}
+
+	evalCtx := criteria.NewEvaluationContext()
+	evalCtx.Set(workVariable, obj)
+	evaluator, err := criteria.NewEvaluator(ctx, evalCtx, t.log)
+	if err != nil {
+		return false
+	}
+	result, err := evaluator.EvaluateCEL(t.condition)
+	if err != nil {
+		errCtx := logger.WithErrorField(ctx, err)
+		t.log.Warnf(errCtx, "Failed to evaluate status reconcile condition")
+		return false
+	}
+	return result.Matched
+}
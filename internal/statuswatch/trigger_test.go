@@ -0,0 +1,109 @@
+package statuswatch
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/config_loader"
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/executor"
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/synthetic"
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/pkg/constants"
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	workv1 "open-cluster-management.io/api/work/v1"
+)
+
+const availableCondition = `work.status.conditions.exists(c, c.type == "Available" && c.status == "True")`
+
+type fakeExecutor struct {
+	mu   sync.Mutex
+	data []interface{}
+}
+
+func (e *fakeExecutor) Execute(_ context.Context, data interface{}) *executor.ExecutionResult {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.data = append(e.data, data)
+	return &executor.ExecutionResult{Status: executor.StatusSuccess}
+}
+
+func (e *fakeExecutor) executed() []interface{} {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]interface{}(nil), e.data...)
+}
+
+// newTestWork returns a ManifestWork owned by a cluster, Available when available is true
+func newTestWork(clusterID string, available bool) *workv1.ManifestWork {
+	work := &workv1.ManifestWork{ObjectMeta: metav1.ObjectMeta{
+		Namespace:   "consumer-" + clusterID,
+		Name:        "work-" + clusterID,
+		Labels:      map[string]string{constants.LabelClusterID: clusterID},
+		Annotations: map[string]string{constants.AnnotationGeneration: "3"},
+	}}
+	if available {
+		work.Status.Conditions = []metav1.Condition{{Type: workv1.WorkAvailable, Status: metav1.ConditionTrue}}
+	}
+	return work
+}
+
+func TestNewTrigger(t *testing.T) {
+	exec := &fakeExecutor{}
+
+	trigger, err := NewTrigger(&config_loader.MaestroStatusWatchConfig{ReconcileWhen: availableCondition}, exec, logger.NewTestLogger())
+	require.NoError(t, err)
+	assert.Equal(t, DefaultEventKind, trigger.eventKind)
+	assert.Equal(t, constants.LabelClusterID, trigger.idLabel)
+	assert.InDelta(t, synthetic.DefaultRateLimit, trigger.runner.RateLimit(), 0.0001)
+
+	_, err = NewTrigger(&config_loader.MaestroStatusWatchConfig{}, exec, logger.NewTestLogger())
+	assert.Error(t, err)
+	_, err = NewTrigger(&config_loader.MaestroStatusWatchConfig{ReconcileWhen: availableCondition}, nil, logger.NewTestLogger())
+	assert.Error(t, err)
+}
+
+func TestTrigger(t *testing.T) {
+	exec := &fakeExecutor{}
+	trigger, err := NewTrigger(&config_loader.MaestroStatusWatchConfig{
+		ReconcileWhen: availableCondition,
+		RateLimit:     1000,
+	}, exec, logger.NewTestLogger())
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	// First sightings and changes that keep the condition result are ignored
+	trigger.OnStatusChange(ctx, nil, newTestWork("abc", true))
+	trigger.OnStatusChange(ctx, newTestWork("abc", false), newTestWork("abc", false))
+	trigger.OnStatusChange(ctx, newTestWork("abc", true), newTestWork("abc", true))
+	assert.Equal(t, 0, trigger.queue.Len())
+
+	// Crossings enqueue one event per resource
+	trigger.OnStatusChange(ctx, newTestWork("abc", false), newTestWork("abc", true))
+	trigger.OnStatusChange(ctx, newTestWork("abc", true), newTestWork("abc", false))
+	trigger.OnStatusChange(ctx, newTestWork("def", false), newTestWork("def", true))
+	unlabeled := newTestWork("ghi", true)
+	unlabeled.Labels = nil
+	trigger.OnStatusChange(ctx, newTestWork("ghi", false), unlabeled)
+	assert.Equal(t, 2, trigger.queue.Len())
+
+	runCtx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		trigger.Run(runCtx)
+		close(done)
+	}()
+
+	require.Eventually(t, func() bool { return len(exec.executed()) == 2 }, 5*time.Second, 10*time.Millisecond)
+	assert.ElementsMatch(t, []interface{}{
+		executor.EventData{ID: "abc", Kind: DefaultEventKind, Generation: 3},
+		executor.EventData{ID: "def", Kind: DefaultEventKind, Generation: 3},
+	}, exec.executed())
+
+	cancel()
+	<-done
+}
@@ -0,0 +1,74 @@
+// Package synthetic executes the events the adapter synthesizes itself, e.g. for a periodic resync
+// or a ManifestWork status change, rather than receives from the broker.
+//
+// Synthesized events go through Executor.Execute like broker events, at a limited rate so that a
+// burst of them does not flood the HyperFleet API. Execute serializes events per resource, so
+// synthesized and broker events never run concurrently for the same resource.
+package synthetic
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/executor"
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/pkg/logger"
+	"golang.org/x/time/rate"
+)
+
+// DefaultRateLimit is the number of synthesized events executed per second when unset
+const DefaultRateLimit = 1.0
+
+// Executor executes synthesized events (implemented by *executor.Executor)
+type Executor interface {
+	Execute(ctx context.Context, data interface{}) *executor.ExecutionResult
+}
+
+// Runner executes synthesized events at a limited rate, each under a unique event id
+type Runner struct {
+	idPrefix string
+	limiter  *rate.Limiter
+	exec     Executor
+	log      logger.Logger
+}
+
+// NewRunner creates a Runner executing at most rateLimit events per second (DefaultRateLimit
+// when zero). The ids of its events start with idPrefix, e.g. "resync".
+func NewRunner(idPrefix string, rateLimit float64, exec Executor, log logger.Logger) *Runner {
+	if rateLimit == 0 {
+		rateLimit = DefaultRateLimit
+	}
+	return &Runner{
+		idPrefix: idPrefix,
+		limiter:  rate.NewLimiter(rate.Limit(rateLimit), 1),
+		exec:     exec,
+		log:      log,
+	}
+}
+
+// RateLimit returns the maximum number of events executed per second
+func (r *Runner) RateLimit() float64 {
+	return float64(r.limiter.Limit())
+}
+
+// Execute waits for the rate limiter, then executes the event and logs its failure.
+// It returns false when the execution failed, and an error when ctx is cancelled while waiting.
+func (r *Runner) Execute(ctx context.Context, evt executor.EventData) (bool, error) {
+	if err := r.limiter.Wait(ctx); err != nil {
+		return false, err
+	}
+
+	evtCtx := logger.WithEventID(ctx, r.newEventID(evt))
+	result := r.exec.Execute(evtCtx, evt)
+	if result != nil && result.Status == executor.StatusFailed {
+		r.log.Warnf(evtCtx, "Synthesized %s event for %s failed", r.idPrefix, evt.Key())
+		return false, nil
+	}
+	return true, nil
+}
+
+// newEventID builds a unique id for a synthesized event, e.g. "resync-cluster-abc-1700000000000000000"
+func (r *Runner) newEventID(evt executor.EventData) string {
+	return fmt.Sprintf("%s-%s-%d", r.idPrefix, strings.ReplaceAll(evt.Key(), "/", "-"), time.Now().UnixNano())
+}
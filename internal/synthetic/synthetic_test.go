@@ -0,0 +1,74 @@
+package synthetic
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/executor"
+	"github.com/openshift-hyperfleet/hyperfleet-adapter/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeExecutor records the event ids and data it executes
+type fakeExecutor struct {
+	ids    []string
+	data   []interface{}
+	status executor.ExecutionStatus
+}
+
+func (e *fakeExecutor) Execute(ctx context.Context, data interface{}) *executor.ExecutionResult {
+	id, _ := logger.GetLogFields(ctx)[logger.EventIDKey].(string)
+	e.ids = append(e.ids, id)
+	e.data = append(e.data, data)
+	status := e.status
+	if status == "" {
+		status = executor.StatusSuccess
+	}
+	return &executor.ExecutionResult{Status: status}
+}
+
+func TestRunner(t *testing.T) {
+	evt := executor.EventData{ID: "abc", Kind: "Cluster", Generation: 2}
+
+	t.Run("default rate limit", func(t *testing.T) {
+		runner := NewRunner("resync", 0, &fakeExecutor{}, logger.NewTestLogger())
+		assert.InDelta(t, DefaultRateLimit, runner.RateLimit(), 0.0001)
+	})
+
+	t.Run("executes the event under a unique id", func(t *testing.T) {
+		exec := &fakeExecutor{}
+		runner := NewRunner("status", 1000, exec, logger.NewTestLogger())
+
+		for range 2 {
+			ok, err := runner.Execute(context.Background(), evt)
+			require.NoError(t, err)
+			assert.True(t, ok)
+		}
+		require.Len(t, exec.data, 2)
+		assert.Equal(t, evt, exec.data[0])
+		assert.True(t, strings.HasPrefix(exec.ids[0], "status-cluster-abc-"), exec.ids[0])
+	})
+
+	t.Run("reports failed executions", func(t *testing.T) {
+		runner := NewRunner("resync", 1000, &fakeExecutor{status: executor.StatusFailed}, logger.NewTestLogger())
+
+		ok, err := runner.Execute(context.Background(), evt)
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("cancelled context", func(t *testing.T) {
+		exec := &fakeExecutor{}
+		runner := NewRunner("resync", 0.001, exec, logger.NewTestLogger())
+		_, err := runner.Execute(context.Background(), evt)
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err = runner.Execute(ctx, evt)
+		assert.Error(t, err)
+		assert.Len(t, exec.data, 1)
+	})
+}
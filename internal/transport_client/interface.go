@@ -44,3 +44,13 @@ type TransportClient interface {
 	// Otherwise, it lists resources matching the label selector.
 	DiscoverResources(ctx context.Context, gvk schema.GroupVersionKind, discovery manifest.Discovery, target TransportContext) (*unstructured.UnstructuredList, error)
 }
+
+// ResourceRemover is implemented by transport clients that can delete a resource on behalf of
+// the executor (currently maestro_client). The resource executor type-asserts the transport
+// client of a resource configured with delete.
+type ResourceRemover interface {
+	// RemoveResource deletes a resource by GVK, namespace, and name. A missing resource is not
+	// an error. With opts.Wait set it blocks until the backend reports the resource removed
+	// or opts.Timeout elapses.
+	RemoveResource(ctx context.Context, gvk schema.GroupVersionKind, namespace, name string, opts *DeleteOptions, target TransportContext) error
+}
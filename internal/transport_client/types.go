@@ -3,6 +3,8 @@
 package transport_client
 
 import (
+	"time"
+
 	"github.com/openshift-hyperfleet/hyperfleet-adapter/internal/manifest"
 )
 
@@ -11,11 +13,26 @@ type ApplyOptions struct {
 	// RecreateOnChange forces delete+create instead of update when resource exists
 	// and generation has changed. Useful for resources that don't support in-place updates.
 	RecreateOnChange bool
+
+	// DriftDetection records a hash of the desired spec on the resource and, when generations
+	// match, reapplies the resource if the live object drifted from the desired spec
+	// (operation correct-drift) or the desired spec changed without a generation bump.
+	DriftDetection bool
+}
+
+// DeleteOptions configures the behavior of resource delete operations.
+type DeleteOptions struct {
+	// Wait blocks until the backend reports the resource removed, e.g. until the ManifestWork
+	// agent removed the workload from the managed cluster
+	Wait bool
+
+	// Timeout bounds the wait. Zero uses the backend default.
+	Timeout time.Duration
 }
 
 // ApplyResult contains the result of applying a single resource.
 type ApplyResult struct {
-	// Operation is the operation that was performed (create, update, recreate, skip)
+	// Operation is the operation that was performed (create, update, recreate, skip, correct-drift)
 	Operation manifest.Operation
 
 	// Reason explains why the operation was chosen
@@ -24,7 +41,7 @@ type ApplyResult struct {
 
 // TransportContext carries per-request routing information for the transport backend.
 // Each transport client defines its own concrete context type and type-asserts:
-//   - k8s_client: ignores it (nil)
+//   - k8s_client: Client ignores it (nil); ClusterClients accepts *k8s_client.TransportContext with Cluster
 //   - maestro_client: expects *maestro_client.TransportContext with ConsumerName
 //
 // This is typed as `any` to allow each backend to define its own context shape.
@@ -50,6 +50,12 @@ const (
 	// Format: "hyperfleet.io/created-by"
 	// Example value: "hyperfleet-adapter"
 	AnnotationCreatedBy = "hyperfleet.io/created-by"
+
+	// AnnotationSpecHash is the annotation key holding the hash of the desired spec.
+	// Set on resources applied with drift detection to detect out-of-band changes.
+	// Format: "hyperfleet.io/spec-hash"
+	// Example value: "sha256:3a6eb0790f39ac87..." (hex-encoded SHA-256)
+	AnnotationSpecHash = "hyperfleet.io/spec-hash"
 )
 
 // OCM ManifestWork GVK constants
@@ -3,6 +3,7 @@ package errors
 import (
 	"errors"
 	"fmt"
+	"time"
 )
 
 // -----------------------------------------------------------------------------
@@ -13,14 +14,17 @@ import (
 type CELErrorType string
 
 const (
-	CELErrorTypeParse   CELErrorType = "parse"
-	CELErrorTypeProgram CELErrorType = "program"
-	CELErrorTypeEval    CELErrorType = "evaluation"
+	CELErrorTypeParse        CELErrorType = "parse"
+	CELErrorTypeProgram      CELErrorType = "program"
+	CELErrorTypeEval         CELErrorType = "evaluation"
+	CELErrorTypeCostExceeded CELErrorType = "cost exceeded"
+	CELErrorTypeTimeout      CELErrorType = "timeout"
+	CELErrorTypeCanceled     CELErrorType = "canceled"
 )
 
 // CELError represents an error during CEL expression processing
 type CELError struct {
-	// Type is the error type (parse, program, evaluation)
+	// Type is the error type (parse, program, evaluation, cost exceeded, timeout, canceled)
 	Type CELErrorType
 	// Expression is the CEL expression that caused the error
 	Expression string
@@ -76,6 +80,36 @@ func NewCELEvalError(expression string, err error) *CELError {
 	}
 }
 
+// NewCELCostExceededError creates an error for an evaluation stopped by the cost limit
+func NewCELCostExceededError(expression string, costLimit uint64, err error) *CELError {
+	return &CELError{
+		Type:       CELErrorTypeCostExceeded,
+		Expression: expression,
+		Reason:     fmt.Sprintf("evaluation exceeded the cost limit of %d", costLimit),
+		Err:        err,
+	}
+}
+
+// NewCELTimeoutError creates an error for an evaluation stopped by its deadline
+func NewCELTimeoutError(expression string, timeout time.Duration, err error) *CELError {
+	return &CELError{
+		Type:       CELErrorTypeTimeout,
+		Expression: expression,
+		Reason:     fmt.Sprintf("evaluation did not complete within %s", timeout),
+		Err:        err,
+	}
+}
+
+// NewCELCanceledError creates an error for an evaluation stopped by the cancellation of its context
+func NewCELCanceledError(expression string, err error) *CELError {
+	return &CELError{
+		Type:       CELErrorTypeCanceled,
+		Expression: expression,
+		Reason:     "evaluation canceled",
+		Err:        err,
+	}
+}
+
 // IsCELError checks if an error is a CELError and returns it
 func IsCELError(err error) (*CELError, bool) {
 	var celErr *CELError
@@ -100,6 +134,21 @@ func (e *CELError) IsEval() bool {
 	return e.Type == CELErrorTypeEval
 }
 
+// IsCostExceeded checks if the evaluation was stopped by the cost limit
+func (e *CELError) IsCostExceeded() bool {
+	return e.Type == CELErrorTypeCostExceeded
+}
+
+// IsTimeout checks if the evaluation was stopped by its deadline
+func (e *CELError) IsTimeout() bool {
+	return e.Type == CELErrorTypeTimeout
+}
+
+// IsCanceled checks if the evaluation was stopped by the cancellation of its context
+func (e *CELError) IsCanceled() bool {
+	return e.Type == CELErrorTypeCanceled
+}
+
 // -----------------------------------------------------------------------------
 // CEL Environment Error
 // -----------------------------------------------------------------------------
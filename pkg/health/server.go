@@ -32,6 +32,7 @@ type ReadyResponse struct {
 	Status  string                 `json:"status"`
 	Message string                 `json:"message,omitempty"`
 	Checks  map[string]CheckStatus `json:"checks,omitempty"`
+	Details map[string]string      `json:"details,omitempty"`
 }
 
 // Server provides HTTP health check endpoints.
@@ -48,6 +49,8 @@ type Server struct {
 
 	mu     sync.RWMutex
 	checks map[string]CheckStatus
+	// details are informational values reported by /readyz (e.g. the loaded config hash)
+	details map[string]string
 }
 
 // NewServer creates a new health check server.
@@ -60,6 +63,7 @@ func NewServer(log logger.Logger, port string, component string) *Server {
 			"config": CheckError,
 			"broker": CheckError,
 		},
+		details: map[string]string{},
 	}
 
 	mux := http.NewServeMux()
@@ -102,6 +106,14 @@ func (s *Server) SetCheck(name string, status CheckStatus) {
 	s.checks[name] = status
 }
 
+// SetDetail sets an informational value reported by /readyz.
+// Details do not affect readiness.
+func (s *Server) SetDetail(name, value string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.details[name] = value
+}
+
 // SetBrokerReady sets the broker check status.
 func (s *Server) SetBrokerReady(ready bool) {
 	if ready {
@@ -182,14 +194,19 @@ func (s *Server) readyzHandler(w http.ResponseWriter, r *http.Request) {
 			allOK = false
 		}
 	}
+	details := make(map[string]string, len(s.details))
+	for name, value := range s.details {
+		details[name] = value
+	}
 	s.mu.RUnlock()
 
 	if allOK {
 		w.WriteHeader(http.StatusOK)
 		//nolint:errcheck // best-effort response
 		_ = json.NewEncoder(w).Encode(ReadyResponse{
-			Status: "ok",
-			Checks: checks,
+			Status:  "ok",
+			Checks:  checks,
+			Details: details,
 		})
 		return
 	}
@@ -200,5 +217,6 @@ func (s *Server) readyzHandler(w http.ResponseWriter, r *http.Request) {
 		Status:  "error",
 		Message: "not ready",
 		Checks:  checks,
+		Details: details,
 	})
 }
@@ -25,6 +25,7 @@ func (m *mockLogger) Warnf(ctx context.Context, format string, args ...interface
 func (m *mockLogger) Error(ctx context.Context, msg string)                          {}
 func (m *mockLogger) Errorf(ctx context.Context, format string, args ...interface{}) {}
 func (m *mockLogger) Fatal(ctx context.Context, msg string)                          {}
+func (m *mockLogger) DebugEnabled(ctx context.Context) bool                          { return false }
 func (m *mockLogger) With(key string, value interface{}) logger.Logger               { return m }
 func (m *mockLogger) WithFields(fields map[string]interface{}) logger.Logger         { return m }
 func (m *mockLogger) Without(key string) logger.Logger                               { return m }
@@ -144,6 +145,29 @@ func TestSetCheck(t *testing.T) {
 	assert.Equal(t, CheckOK, response.Checks["broker"])
 }
 
+func TestReadyzHandler_Details(t *testing.T) {
+	server := NewServer(&mockLogger{}, "8080", "test-adapter")
+	server.SetConfigLoaded()
+	server.SetBrokerReady(true)
+	server.SetDetail("configHash", "abc123")
+
+	req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+	w := httptest.NewRecorder()
+
+	server.readyzHandler(w, req)
+
+	resp := w.Result()
+	defer func() { _ = resp.Body.Close() }()
+
+	// Details are informational and do not affect readiness
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var response ReadyResponse
+	err := json.NewDecoder(resp.Body).Decode(&response)
+	require.NoError(t, err)
+	assert.Equal(t, "abc123", response.Details["configHash"])
+}
+
 func TestReadyzHandler_PartialReady(t *testing.T) {
 	server := NewServer(&mockLogger{}, "8080", "test-adapter")
 
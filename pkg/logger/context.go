@@ -42,6 +42,7 @@ const (
 
 	// Adapter-specific fields
 	AdapterKey            = "adapter"
+	TaskKey               = "task"
 	ObservedGenerationKey = "observed_generation"
 	SubscriptionKey       = "subscription"
 
@@ -132,6 +133,11 @@ func WithAdapter(ctx context.Context, adapter string) context.Context {
 	return WithLogField(ctx, AdapterKey, adapter)
 }
 
+// WithTask returns a context with the task config name set
+func WithTask(ctx context.Context, task string) context.Context {
+	return WithLogField(ctx, TaskKey, task)
+}
+
 // WithObservedGeneration returns a context with the observed generation set
 func WithObservedGeneration(ctx context.Context, generation int64) context.Context {
 	return WithLogField(ctx, ObservedGenerationKey, generation)
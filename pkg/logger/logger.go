@@ -30,6 +30,8 @@ type Logger interface {
 	Errorf(ctx context.Context, format string, args ...interface{})
 	// Fatal logs at error level and exits
 	Fatal(ctx context.Context, message string)
+	// DebugEnabled reports whether debug messages are logged
+	DebugEnabled(ctx context.Context) bool
 
 	// With returns a new logger with additional fields
 	With(key string, value interface{}) Logger
@@ -204,6 +206,11 @@ func (l *logger) Debugf(ctx context.Context, format string, args ...interface{})
 	l.slog.DebugContext(ctx, fmt.Sprintf(format, args...), l.buildArgs(ctx)...)
 }
 
+// DebugEnabled reports whether debug messages are logged
+func (l *logger) DebugEnabled(ctx context.Context) bool {
+	return l.slog.Enabled(ctx, slog.LevelDebug)
+}
+
 // Info logs at info level
 func (l *logger) Info(ctx context.Context, message string) {
 	l.slog.InfoContext(ctx, message, l.buildArgs(ctx)...)
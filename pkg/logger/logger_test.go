@@ -413,6 +413,29 @@ func TestParseLevel(t *testing.T) {
 	}
 }
 
+func TestDebugEnabled(t *testing.T) {
+	tests := []struct {
+		level    string
+		expected bool
+	}{
+		{"debug", true},
+		{"info", false},
+		{"error", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.level, func(t *testing.T) {
+			log, err := NewLogger(Config{Level: tt.level, Format: "text", Output: "stderr", Component: "test", Version: "v1.0.0"})
+			if err != nil {
+				t.Fatalf("NewLogger returned error: %v", err)
+			}
+			if got := log.DebugEnabled(context.Background()); got != tt.expected {
+				t.Errorf("DebugEnabled() at level %q = %v, want %v", tt.level, got, tt.expected)
+			}
+		})
+	}
+}
+
 func TestLoggerContextExtraction(t *testing.T) {
 	log, err := NewLogger(Config{
 		Level:     "debug",
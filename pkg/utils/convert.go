@@ -2,19 +2,31 @@
 package utils
 
 import (
+	"encoding/json"
 	"fmt"
 	"math"
+	"reflect"
 	"strconv"
 	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/resource"
 )
 
+// SupportedTypes lists the type names accepted by ConvertToType.
+var SupportedTypes = []string{
+	"string", "int", "int64", "float", "float64", "bool",
+	"list", "map", "duration", "quantity", "json",
+}
+
 // ConvertToType converts a value to the specified type.
-// Supported types: string, int, int64, float, float64, bool
+// Supported types: string, int, int64, float, float64, bool, list, map, duration, quantity, json
 //
 // Example:
 //
-//	val, err := ConvertToType("42", "int64")  // val = int64(42)
-//	val, err := ConvertToType(3.14, "string") // val = "3.14"
+//	val, err := ConvertToType("42", "int64")        // val = int64(42)
+//	val, err := ConvertToType(3.14, "string")       // val = "3.14"
+//	val, err := ConvertToType(`["a","b"]`, "list")  // val = []interface{}{"a", "b"}
 func ConvertToType(value interface{}, targetType string) (interface{}, error) {
 	switch targetType {
 	case "string":
@@ -25,8 +37,18 @@ func ConvertToType(value interface{}, targetType string) (interface{}, error) {
 		return ConvertToFloat64(value)
 	case "bool":
 		return ConvertToBool(value)
+	case "list":
+		return ConvertToList(value)
+	case "map":
+		return ConvertToMap(value)
+	case "duration":
+		return ConvertToDuration(value)
+	case "quantity":
+		return ConvertToQuantity(value)
+	case "json":
+		return ConvertFromJSON(value)
 	default:
-		return nil, fmt.Errorf("unsupported type: %s (supported: string, int, int64, float, float64, bool)", targetType)
+		return nil, fmt.Errorf("unsupported type: %s (supported: %s)", targetType, strings.Join(SupportedTypes, ", "))
 	}
 }
 
@@ -227,3 +249,152 @@ func ConvertToBool(value interface{}) (bool, error) {
 		return false, fmt.Errorf("cannot convert %T to bool", value)
 	}
 }
+
+// ConvertToList converts a value to []interface{}.
+// Slices are returned as-is (typed slices are converted element by element);
+// strings are decoded as a JSON array.
+//
+// Example:
+//
+//	ConvertToList(`["a", "b"]`)          // []interface{}{"a", "b"}, nil
+//	ConvertToList([]string{"a", "b"})    // []interface{}{"a", "b"}, nil
+func ConvertToList(value interface{}) ([]interface{}, error) {
+	switch v := value.(type) {
+	case []interface{}:
+		return v, nil
+	case string:
+		var list []interface{}
+		if err := json.Unmarshal([]byte(v), &list); err != nil {
+			return nil, fmt.Errorf("cannot convert string to list: %w", err)
+		}
+		return list, nil
+	}
+
+	rv := reflect.ValueOf(value)
+	if rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array {
+		list := make([]interface{}, rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			list[i] = rv.Index(i).Interface()
+		}
+		return list, nil
+	}
+	return nil, fmt.Errorf("cannot convert %T to list", value)
+}
+
+// ConvertToMap converts a value to map[string]interface{}.
+// Maps are returned with string keys; strings are decoded as a JSON object.
+//
+// Example:
+//
+//	ConvertToMap(`{"team": "core"}`)                    // map[string]interface{}{"team": "core"}, nil
+//	ConvertToMap(map[string]string{"team": "core"})     // map[string]interface{}{"team": "core"}, nil
+func ConvertToMap(value interface{}) (map[string]interface{}, error) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return v, nil
+	case map[interface{}]interface{}:
+		return ConvertToStringKeyMap(v), nil
+	case string:
+		var m map[string]interface{}
+		if err := json.Unmarshal([]byte(v), &m); err != nil {
+			return nil, fmt.Errorf("cannot convert string to map: %w", err)
+		}
+		if m == nil {
+			return nil, fmt.Errorf("cannot convert string to map: JSON value is null")
+		}
+		return m, nil
+	}
+
+	rv := reflect.ValueOf(value)
+	if rv.Kind() == reflect.Map && rv.Type().Key().Kind() == reflect.String {
+		m := make(map[string]interface{}, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			m[iter.Key().String()] = iter.Value().Interface()
+		}
+		return m, nil
+	}
+	return nil, fmt.Errorf("cannot convert %T to map", value)
+}
+
+// ConvertToDuration converts a value to time.Duration.
+// Strings are parsed with time.ParseDuration (e.g., "90s", "1h30m");
+// numbers are interpreted as seconds.
+//
+// Example:
+//
+//	ConvertToDuration("1h30m") // 90 * time.Minute, nil
+//	ConvertToDuration(30)      // 30 * time.Second, nil
+func ConvertToDuration(value interface{}) (time.Duration, error) {
+	switch v := value.(type) {
+	case time.Duration:
+		return v, nil
+	case string:
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return 0, fmt.Errorf("cannot convert string '%s' to duration: %w", v, err)
+		}
+		return d, nil
+	case bool:
+		return 0, fmt.Errorf("cannot convert %T to duration", value)
+	}
+
+	seconds, err := ConvertToFloat64(value)
+	if err != nil {
+		return 0, fmt.Errorf("cannot convert %T to duration", value)
+	}
+	return time.Duration(seconds * float64(time.Second)), nil
+}
+
+// ConvertToQuantity converts a value to a Kubernetes resource quantity in canonical string form.
+// The canonical form is what the Kubernetes API returns, so it can be compared and rendered directly.
+//
+// Example:
+//
+//	ConvertToQuantity("1024Mi") // "1Gi", nil
+//	ConvertToQuantity(0.5)      // "500m", nil
+func ConvertToQuantity(value interface{}) (string, error) {
+	var str string
+	switch v := value.(type) {
+	case string:
+		str = v
+	case bool:
+		return "", fmt.Errorf("cannot convert %T to quantity", value)
+	default:
+		converted, err := ConvertToString(value)
+		if err != nil {
+			return "", err
+		}
+		str = converted
+	}
+
+	q, err := resource.ParseQuantity(strings.TrimSpace(str))
+	if err != nil {
+		return "", fmt.Errorf("cannot convert '%s' to quantity: %w", str, err)
+	}
+	return q.String(), nil
+}
+
+// ConvertFromJSON decodes a JSON string into its native value (map, list, string, number, bool or nil).
+// Byte slices are decoded the same way; any other value is returned as-is since it is already decoded.
+//
+// Example:
+//
+//	ConvertFromJSON(`{"replicas": 3}`) // map[string]interface{}{"replicas": float64(3)}, nil
+func ConvertFromJSON(value interface{}) (interface{}, error) {
+	var data []byte
+	switch v := value.(type) {
+	case string:
+		data = []byte(v)
+	case []byte:
+		data = v
+	default:
+		return value, nil
+	}
+
+	var decoded interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return nil, fmt.Errorf("cannot decode JSON: %w", err)
+	}
+	return decoded, nil
+}
@@ -0,0 +1,180 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"k8s.io/kube-openapi/pkg/validation/spec"
+	"k8s.io/kube-openapi/pkg/validation/strfmt"
+	"k8s.io/kube-openapi/pkg/validation/validate"
+)
+
+// supportedSchemaKeywords are the JSON Schema keywords enforced by the OpenAPI v3 schema validator.
+// Other keywords (e.g. const, $ref, if/then/else) would be silently ignored, so they are rejected.
+var supportedSchemaKeywords = map[string]bool{
+	"type": true, "format": true, "title": true, "description": true, "default": true, "example": true,
+	"nullable": true, "enum": true, "multipleOf": true, "maximum": true, "exclusiveMaximum": true,
+	"minimum": true, "exclusiveMinimum": true, "maxLength": true, "minLength": true, "pattern": true,
+	"maxItems": true, "minItems": true, "uniqueItems": true, "items": true, "maxProperties": true,
+	"minProperties": true, "required": true, "properties": true, "additionalProperties": true,
+	"patternProperties": true, "allOf": true, "anyOf": true, "oneOf": true, "not": true,
+}
+
+// ParseJSONSchema converts a JSON Schema fragment (as decoded from YAML/JSON) into a schema
+// that can be used for validation. The supported keywords are those of OpenAPI v3 schemas,
+// the same subset Kubernetes uses for CRD validation (type, properties, required, enum,
+// pattern, minimum, maximum, minLength, maxLength, minItems, maxItems, items, ...).
+// Unsupported keywords are rejected, at any nesting level.
+//
+// Example:
+//
+//	schema, err := ParseJSONSchema(map[string]interface{}{
+//		"type":      "string",
+//		"maxLength": 63,
+//	})
+func ParseJSONSchema(fragment map[string]interface{}) (*spec.Schema, error) {
+	if fragment == nil {
+		return nil, nil
+	}
+
+	if err := checkSchemaKeywords(fragment, ""); err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(fragment)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal schema: %w", err)
+	}
+
+	schema := &spec.Schema{}
+	if err := json.Unmarshal(data, schema); err != nil {
+		return nil, fmt.Errorf("invalid schema: %w", err)
+	}
+	return schema, nil
+}
+
+// checkSchemaKeywords returns an error for the first unsupported keyword in a schema fragment,
+// descending into subschemas. path locates the fragment in error messages.
+func checkSchemaKeywords(fragment map[string]interface{}, path string) error {
+	keys := make([]string, 0, len(fragment))
+	for key := range fragment {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if !supportedSchemaKeywords[key] {
+			return fmt.Errorf("invalid schema: unsupported keyword %q", joinSchemaPath(path, key))
+		}
+
+		keyPath := joinSchemaPath(path, key)
+		switch key {
+		case "items", "additionalProperties", "not", "allOf", "anyOf", "oneOf":
+			if err := checkSubschemas(fragment[key], keyPath); err != nil {
+				return err
+			}
+		case "properties", "patternProperties":
+			props, ok := toStringKeyMap(fragment[key])
+			if !ok {
+				continue
+			}
+			for name, sub := range props {
+				if err := checkSubschemas(sub, keyPath+"."+name); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// checkSubschemas checks a value that holds a schema or a list of schemas
+func checkSubschemas(value interface{}, path string) error {
+	if list, ok := value.([]interface{}); ok {
+		for i, item := range list {
+			if err := checkSubschemas(item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if sub, ok := toStringKeyMap(value); ok {
+		return checkSchemaKeywords(sub, path)
+	}
+	// Non-schema values (e.g. additionalProperties: false) are checked when the schema is decoded
+	return nil
+}
+
+// toStringKeyMap returns value as a map[string]interface{}, converting YAML interface-keyed maps
+func toStringKeyMap(value interface{}) (map[string]interface{}, bool) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return v, true
+	case map[interface{}]interface{}:
+		return ConvertToStringKeyMap(v), true
+	}
+	return nil, false
+}
+
+// joinSchemaPath appends a keyword to a schema path
+func joinSchemaPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// ValidateJSONSchema validates a value against a JSON Schema fragment.
+// The value is normalized to its JSON representation before validation, so Go types
+// like int64 or map[interface{}]interface{} are validated as JSON numbers and objects.
+// time.Duration values are validated as their string form (e.g., "1h30m0s").
+// Returns an error listing all schema violations.
+func ValidateJSONSchema(fragment map[string]interface{}, value interface{}) error {
+	schema, err := ParseJSONSchema(fragment)
+	if err != nil {
+		return err
+	}
+	if schema == nil {
+		return nil
+	}
+
+	normalized, err := normalizeForSchema(value)
+	if err != nil {
+		return err
+	}
+
+	result := validate.NewSchemaValidator(schema, nil, "", strfmt.Default).Validate(normalized)
+	if result == nil || result.IsValid() {
+		return nil
+	}
+
+	msgs := make([]string, 0, len(result.Errors))
+	for _, e := range result.Errors {
+		msgs = append(msgs, e.Error())
+	}
+	return fmt.Errorf("schema validation failed: %s", strings.Join(msgs, "; "))
+}
+
+// normalizeForSchema converts a value to its JSON representation for schema validation
+func normalizeForSchema(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case time.Duration:
+		return v.String(), nil
+	case map[interface{}]interface{}:
+		value = ConvertToStringKeyMap(v)
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal value for schema validation: %w", err)
+	}
+
+	var normalized interface{}
+	if err := json.Unmarshal(data, &normalized); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal value for schema validation: %w", err)
+	}
+	return normalized, nil
+}
@@ -3,18 +3,28 @@ package utils
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"reflect"
+	"regexp"
 	"strconv"
 	"strings"
 	"text/template"
+	"text/template/parse"
 	"time"
 
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
+	"sigs.k8s.io/yaml"
 )
 
 // TemplateFuncs provides helper functions for Go templates.
 // These functions are available within {{ }} template expressions.
+// Names and argument order follow Sprig/Helm so that chart templates can be ported as-is.
 var TemplateFuncs = template.FuncMap{
 	// Time functions
 	"now": time.Now,
@@ -116,9 +126,245 @@ var TemplateFuncs = template.FuncMap{
 	"string": func(v interface{}) string {
 		return fmt.Sprintf("%v", v)
 	},
+
+	// Encoding functions
+	"toJson": toJSON,
+	"toYaml": toYAML,
+	"b64enc": func(s string) string { return base64.StdEncoding.EncodeToString([]byte(s)) },
+	"b64dec": b64dec,
+	"sha256sum": func(s string) string {
+		sum := sha256.Sum256([]byte(s))
+		return hex.EncodeToString(sum[:])
+	},
+
+	// Formatting functions
+	"indent":  indent,
+	"nindent": func(spaces int, s string) string { return "\n" + indent(spaces, s) },
+
+	// List and dict functions
+	"list":      func(items ...interface{}) []interface{} { return items },
+	"dict":      dict,
+	"join":      join,
+	"split":     split,
+	"splitList": func(sep, s string) []string { return strings.Split(s, sep) },
+
+	// Text and naming functions
+	"regexReplaceAll": regexReplaceAll,
+	"trunc":           trunc,
+	"dns1123":         DNS1123Label,
+
+	// Validation functions
+	"required": required,
+}
+
+// toJSON encodes a value as compact JSON, like Sprig's toJson.
+func toJSON(v interface{}) (string, error) {
+	if m, ok := v.(map[interface{}]interface{}); ok {
+		v = ConvertToStringKeyMap(m)
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", fmt.Errorf("toJson: %w", err)
+	}
+	return string(data), nil
+}
+
+// toYAML encodes a value as YAML without the trailing newline, like Helm's toYaml.
+func toYAML(v interface{}) (string, error) {
+	if m, ok := v.(map[interface{}]interface{}); ok {
+		v = ConvertToStringKeyMap(m)
+	}
+	data, err := yaml.Marshal(v)
+	if err != nil {
+		return "", fmt.Errorf("toYaml: %w", err)
+	}
+	return strings.TrimSuffix(string(data), "\n"), nil
+}
+
+// b64dec decodes a standard base64 string.
+func b64dec(s string) (string, error) {
+	data, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return "", fmt.Errorf("b64dec: %w", err)
+	}
+	return string(data), nil
+}
+
+// indent prefixes every line of s with the given number of spaces.
+func indent(spaces int, s string) string {
+	pad := strings.Repeat(" ", spaces)
+	return pad + strings.ReplaceAll(s, "\n", "\n"+pad)
+}
+
+// dict builds a map from alternating key/value arguments.
+// A trailing key without a value is mapped to an empty string.
+func dict(pairs ...interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, (len(pairs)+1)/2)
+	for i := 0; i < len(pairs); i += 2 {
+		key := fmt.Sprintf("%v", pairs[i])
+		if i+1 < len(pairs) {
+			result[key] = pairs[i+1]
+		} else {
+			result[key] = ""
+		}
+	}
+	return result
+}
+
+// join concatenates the elements of a list (any slice type) with the separator.
+func join(sep string, v interface{}) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	if v == nil {
+		return ""
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return fmt.Sprintf("%v", v)
+	}
+	parts := make([]string, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		parts[i] = fmt.Sprintf("%v", rv.Index(i).Interface())
+	}
+	return strings.Join(parts, sep)
+}
+
+// split splits s into a map keyed "_0", "_1", ... like Sprig's split.
+// Use splitList to get a plain list.
+func split(sep, s string) map[string]string {
+	parts := strings.Split(s, sep)
+	result := make(map[string]string, len(parts))
+	for i, part := range parts {
+		result["_"+strconv.Itoa(i)] = part
+	}
+	return result
+}
+
+// regexReplaceAll replaces all matches of the regex in s with repl.
+// repl may reference capture groups ($1, ${name}).
+func regexReplaceAll(regex, s, repl string) (string, error) {
+	re, err := regexp.Compile(regex)
+	if err != nil {
+		return "", fmt.Errorf("regexReplaceAll: %w", err)
+	}
+	return re.ReplaceAllString(s, repl), nil
+}
+
+// trunc truncates s to n characters. A negative n keeps the last -n characters.
+func trunc(n int, s string) string {
+	runes := []rune(s)
+	switch {
+	case n >= 0 && len(runes) > n:
+		return string(runes[:n])
+	case n < 0 && len(runes) > -n:
+		return string(runes[len(runes)+n:])
+	default:
+		return s
+	}
+}
+
+// required returns val, or an error with the given message if val is nil or an empty string.
+func required(msg string, val interface{}) (interface{}, error) {
+	if val == nil {
+		return nil, errors.New(msg)
+	}
+	if s, ok := val.(string); ok && s == "" {
+		return nil, errors.New(msg)
+	}
+	return val, nil
+}
+
+var dns1123InvalidChars = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// dns1123MaxLength is the maximum length of a DNS-1123 label
+const dns1123MaxLength = 63
+
+// DNS1123Label sanitizes s into a valid DNS-1123 label: lowercase alphanumerics and '-',
+// starting and ending with an alphanumeric, at most 63 characters.
+// Runs of invalid characters are replaced with a single '-'.
+//
+// Example:
+//
+//	DNS1123Label("My_Cluster.Prod") // "my-cluster-prod"
+func DNS1123Label(s string) string {
+	label := dns1123InvalidChars.ReplaceAllString(strings.ToLower(s), "-")
+	label = strings.Trim(label, "-")
+	if len(label) > dns1123MaxLength {
+		label = strings.TrimRight(label[:dns1123MaxLength], "-")
+	}
+	return label
+}
+
+// TemplateOptions controls how templates are rendered.
+type TemplateOptions struct {
+	// Strict makes references to missing map keys fail rendering.
+	// When false, missing keys render as an empty string, like Helm without --strict.
+	Strict bool
 }
 
-// RenderTemplate renders a Go template string with the given data.
+// DefaultTemplateOptions are the options used by RenderTemplate: strict rendering.
+var DefaultTemplateOptions = TemplateOptions{Strict: true}
+
+// missingAsEmptyFunc is the template function RenderMissingAsEmpty appends to printing actions
+const missingAsEmptyFunc = "_missingAsEmpty"
+
+// RenderMissingAsEmpty makes every template in the set of t print missing and nil values as an
+// empty string instead of "<no value>", like Helm without --strict. It must be called after all
+// templates are parsed and before execution. Use it together with the "missingkey=zero" option.
+//
+// text/template has no hook for missing keys, so each action that prints a value gets an extra
+// pipeline stage that turns nil into "". Values that are printed on purpose are never rewritten.
+func RenderMissingAsEmpty(t *template.Template) {
+	t.Funcs(template.FuncMap{
+		missingAsEmptyFunc: func(v interface{}) interface{} {
+			if v == nil {
+				return ""
+			}
+			return v
+		},
+	})
+	for _, tmpl := range t.Templates() {
+		if tmpl.Tree != nil {
+			appendMissingAsEmpty(tmpl.Tree, tmpl.Tree.Root)
+		}
+	}
+}
+
+// appendMissingAsEmpty walks a parse tree and appends the missingAsEmptyFunc stage to the
+// pipeline of every action that prints its value
+func appendMissingAsEmpty(tree *parse.Tree, node parse.Node) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			appendMissingAsEmpty(tree, child)
+		}
+	case *parse.ActionNode:
+		// Variable declarations and assignments print nothing
+		if len(n.Pipe.Decl) > 0 {
+			return
+		}
+		n.Pipe.Cmds = append(n.Pipe.Cmds, &parse.CommandNode{
+			NodeType: parse.NodeCommand,
+			Pos:      n.Pos,
+			Args:     []parse.Node{parse.NewIdentifier(missingAsEmptyFunc).SetTree(tree).SetPos(n.Pos)},
+		})
+	case *parse.IfNode:
+		appendMissingAsEmpty(tree, n.List)
+		appendMissingAsEmpty(tree, n.ElseList)
+	case *parse.RangeNode:
+		appendMissingAsEmpty(tree, n.List)
+		appendMissingAsEmpty(tree, n.ElseList)
+	case *parse.WithNode:
+		appendMissingAsEmpty(tree, n.List)
+		appendMissingAsEmpty(tree, n.ElseList)
+	}
+}
+
+// RenderTemplate renders a Go template string with the given data using DefaultTemplateOptions.
 // If the string contains no template delimiters ({{ }}), it is returned as-is.
 //
 // Parameters:
@@ -132,15 +378,29 @@ var TemplateFuncs = template.FuncMap{
 //	rendered, err := RenderTemplate("Hello {{.name}}", map[string]interface{}{"name": "World"})
 //	// rendered = "Hello World"
 func RenderTemplate(templateStr string, data map[string]interface{}) (string, error) {
+	return RenderTemplateWithOptions(templateStr, data, DefaultTemplateOptions)
+}
+
+// RenderTemplateWithOptions renders a Go template string with the given data and options.
+// If the string contains no template delimiters ({{ }}), it is returned as-is.
+func RenderTemplateWithOptions(templateStr string, data map[string]interface{}, opts TemplateOptions) (string, error) {
 	// If no template delimiters, return as-is
 	if !strings.Contains(templateStr, "{{") {
 		return templateStr, nil
 	}
 
-	tmpl, err := template.New("template").Funcs(TemplateFuncs).Option("missingkey=error").Parse(templateStr)
+	missingKey := "missingkey=zero"
+	if opts.Strict {
+		missingKey = "missingkey=error"
+	}
+
+	tmpl, err := template.New("template").Funcs(TemplateFuncs).Option(missingKey).Parse(templateStr)
 	if err != nil {
 		return "", fmt.Errorf("failed to parse template: %w", err)
 	}
+	if !opts.Strict {
+		RenderMissingAsEmpty(tmpl)
+	}
 
 	var buf bytes.Buffer
 	if err := tmpl.Execute(&buf, data); err != nil {